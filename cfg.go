@@ -16,10 +16,11 @@ import (
 
 // Config describes a set of settings for a client
 type Config struct {
-	Rpc      []string
-	Mnemonic string
-	Name     string
-	Timeout  time.Duration
+	Rpc        []string
+	Mnemonic   string
+	Name       string
+	Timeout    time.Duration
+	ClientOpts []chain.ClientOption
 }
 
 // Option is a client config option that can be given to the client constructor
@@ -31,7 +32,7 @@ const DefaultName = "cess-sdk-go"
 //
 // This function consumes the config. Do not reuse it (really!).
 func (cfg *Config) NewSDK(ctx context.Context) (chain.Chainer, error) {
-	return chain.NewChainClient(ctx, cfg.Name, cfg.Rpc, cfg.Mnemonic, cfg.Timeout)
+	return chain.NewChainClient(ctx, cfg.Name, cfg.Rpc, cfg.Mnemonic, cfg.Timeout, cfg.ClientOpts...)
 }
 
 // Apply applies the given options to the config, returning the first error
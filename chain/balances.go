@@ -18,124 +18,42 @@ import (
 )
 
 // QueryTotalIssuance query the total amount of token issuance
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - string: the total amount of token issuance
 //   - error: error message
-func (c *ChainClient) QueryTotalIssuance(block int32) (string, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return "", fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), Balances, TotalIssuance, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryTotalIssuance(block BlockRef) (string, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data types.U128
-
-	key, err := types.CreateStorageKey(c.metadata, Balances, TotalIssuance)
+	data, err := QueryStorage[types.U128](c.ctx, c, Balances, TotalIssuance, block)
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), Balances, TotalIssuance, err)
 		return "", err
 	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), Balances, TotalIssuance, err)
-			c.SetRpcState(false)
-			return "", err
-		}
-		if !ok {
-			return "", ERR_RPC_EMPTY_VALUE
-		}
-		return data.String(), nil
-	}
-
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), Balances, TotalIssuance, err)
-		return "", err
-	}
-
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), Balances, TotalIssuance, err)
-		c.SetRpcState(false)
-		return "", err
-	}
-	if !ok {
-		return "0", nil
-	}
-	if data.String() == "" {
-		return "0", nil
-	}
 	return data.String(), nil
 }
 
 // QueryInactiveIssuance query the amount of inactive token issuance
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - string: the amount of inactive token issuance
 //   - error: error message
-func (c *ChainClient) QueryInactiveIssuance(block int32) (string, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return "", fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), Balances, InactiveIssuance, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryInactiveIssuance(block BlockRef) (string, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data types.U128
-
-	key, err := types.CreateStorageKey(c.metadata, Balances, InactiveIssuance)
+	data, err := QueryStorage[types.U128](c.ctx, c, Balances, InactiveIssuance, block)
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), Balances, InactiveIssuance, err)
 		return "", err
 	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), Balances, InactiveIssuance, err)
-			c.SetRpcState(false)
-			return "", err
-		}
-		if !ok {
-			return "", ERR_RPC_EMPTY_VALUE
-		}
-		return data.String(), nil
-	}
-
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), Balances, InactiveIssuance, err)
-		return "", err
-	}
-
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), Balances, InactiveIssuance, err)
-		c.SetRpcState(false)
-		return "", err
-	}
-	if !ok {
-		return "0", nil
-	}
-	if data.String() == "" {
-		return "0", nil
-	}
 	return data.String(), nil
 }
 
@@ -145,12 +63,10 @@ func (c *ChainClient) QueryInactiveIssuance(block int32) (string, error) {
 //     For example, if you transfer 1 CESS, you need to fill in "1000000000000000000"
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
-func (c *ChainClient) TransferToken(dest string, amount string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) TransferToken(dest string, amount string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -158,27 +74,27 @@ func (c *ChainClient) TransferToken(dest string, amount string) (string, error)
 
 	pubkey, err := utils.ParsingPublickey(dest)
 	if err != nil {
-		return "", errors.Wrapf(err, "[ParsingPublickey]")
+		return TxReceipt{}, errors.Wrapf(err, "[ParsingPublickey]")
 	}
 
 	address, err := types.NewMultiAddressFromAccountID(pubkey)
 	if err != nil {
-		return "", errors.Wrapf(err, "[NewMultiAddressFromAccountID]")
+		return TxReceipt{}, errors.Wrapf(err, "[NewMultiAddressFromAccountID]")
 	}
 
 	amount_bg, ok := new(big.Int).SetString(amount, 10)
 	if !ok {
-		return "", errors.New("[TransferToken] invalid amount")
+		return TxReceipt{}, errors.New("[TransferToken] invalid amount")
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_Balances_transferKeepAlive, address, types.NewUCompact(amount_bg))
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Balances_transferKeepAlive, address, types.NewUCompact(amount_bg))
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Balances_transferKeepAlive, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Balances_transferKeepAlive, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_Balances_transferKeepAlive)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Balances_transferKeepAlive, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_Balances_transferKeepAlive, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Balances_transferKeepAlive, err)
 	}
-	return blockhash, nil
+	return receipt, nil
 }
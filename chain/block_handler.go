@@ -0,0 +1,225 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// AnyName registers a handler for all events or all extrinsics
+const AnyName = "*"
+
+// ParseContext is passed to the handlers of the events and extrinsics of a block
+type ParseContext struct {
+	BlockNumber uint64
+	BlockHash   string
+	// index of the extrinsic in the block, -1 for the events outside extrinsics
+	ExtrinsicIndex int
+	// info of the extrinsic, nil for the events outside extrinsics
+	Extrinsic *ExtrinsicsInfo
+	// call of the extrinsic, nil for the events outside extrinsics
+	Call *types.Call
+	// all events of the extrinsic
+	Events []*parser.Event
+
+	data *BlockData
+}
+
+// EventHandler handles an event of a block. The events emitted by an extrinsic are
+// handled once the extrinsic is completed, and only if it succeeded.
+type EventHandler func(ctx *ParseContext, e *parser.Event) error
+
+// ExtrinsicHandler handles a completed extrinsic of a block, whether it succeeded or not
+type ExtrinsicHandler func(ctx *ParseContext) error
+
+// BlockHandlers is a registry of handlers of the events and extrinsics of a block,
+// pass it to ParseBlockData with WithHandlers
+type BlockHandlers struct {
+	lock       sync.RWMutex
+	events     map[string][]EventHandler
+	extrinsics map[string][]ExtrinsicHandler
+}
+
+// NewBlockHandlers creates an empty registry of handlers
+func NewBlockHandlers() *BlockHandlers {
+	return &BlockHandlers{
+		events:     make(map[string][]EventHandler),
+		extrinsics: make(map[string][]ExtrinsicHandler),
+	}
+}
+
+// OnEvent registers a handler of an event
+//   - name: event name, e.g. StorageHandlerMintTerritory, or AnyName
+//   - fn: the handler
+func (h *BlockHandlers) OnEvent(name string, fn EventHandler) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.events[name] = append(h.events[name], fn)
+}
+
+// OnExtrinsic registers a handler of an extrinsic
+//   - name: extrinsic name, e.g. ExtName_StorageHandler_mint_territory, or AnyName
+//   - fn: the handler
+func (h *BlockHandlers) OnExtrinsic(name string, fn ExtrinsicHandler) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.extrinsics[name] = append(h.extrinsics[name], fn)
+}
+
+func (h *BlockHandlers) eventHandlers(name string) []EventHandler {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	fns := make([]EventHandler, 0, len(h.events[name])+len(h.events[AnyName]))
+	return append(append(fns, h.events[name]...), h.events[AnyName]...)
+}
+
+func (h *BlockHandlers) extrinsicHandlers(name string) []ExtrinsicHandler {
+	h.lock.RLock()
+	defer h.lock.RUnlock()
+	fns := make([]ExtrinsicHandler, 0, len(h.extrinsics[name])+len(h.extrinsics[AnyName]))
+	return append(append(fns, h.extrinsics[name]...), h.extrinsics[AnyName]...)
+}
+
+// HandleEvent registers a handler of an event that writes into the result of type T
+// of the block, see OnEvent and BlockResult
+func HandleEvent[T any](h *BlockHandlers, name string, fn func(ctx *ParseContext, e *parser.Event, result *T) error) {
+	h.OnEvent(name, func(ctx *ParseContext, e *parser.Event) error {
+		return fn(ctx, e, ResultOf[T](ctx))
+	})
+}
+
+// HandleExtrinsic registers a handler of an extrinsic that writes into the result of
+// type T of the block, see OnExtrinsic and BlockResult
+func HandleExtrinsic[T any](h *BlockHandlers, name string, fn func(ctx *ParseContext, result *T) error) {
+	h.OnExtrinsic(name, func(ctx *ParseContext) error {
+		return fn(ctx, ResultOf[T](ctx))
+	})
+}
+
+// ResultOf returns the result of type T of the block being parsed, it is created
+// by the first call. The result of type BlockData is the parsed block itself.
+func ResultOf[T any](ctx *ParseContext) *T {
+	if data, ok := any(ctx.data).(*T); ok {
+		return data
+	}
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	if result, ok := ctx.data.results[typ]; ok {
+		return result.(*T)
+	}
+	if ctx.data.results == nil {
+		ctx.data.results = make(map[reflect.Type]any)
+	}
+	result := new(T)
+	ctx.data.results[typ] = result
+	return result
+}
+
+// BlockResult returns the result of type T written by the handlers of the block
+//   - data: the parsed block
+//
+// Return:
+//   - *T: the result, nil if no handler wrote into it
+func BlockResult[T any](data BlockData) *T {
+	result, _ := data.results[reflect.TypeOf((*T)(nil)).Elem()].(*T)
+	return result
+}
+
+// handleBlockEvents passes the events of the block to the handlers, the events of an
+// extrinsic are held until the extrinsic is completed and dropped if it failed.
+// The names of the extrinsics are resolved with callName, the extrinsics it cannot name are skipped.
+func handleBlockEvents(data *BlockData, block *types.SignedBlock, number uint64, events []*parser.Event, callName func(types.CallIndex) (string, bool, error), handlers []*BlockHandlers) error {
+	var pending []*parser.Event
+	for _, e := range events {
+		if !e.Phase.IsApplyExtrinsic {
+			ctx := &ParseContext{BlockNumber: number, BlockHash: data.BlockHash, ExtrinsicIndex: -1, data: data}
+			if err := dispatchEvent(ctx, e, handlers); err != nil {
+				return err
+			}
+			continue
+		}
+		if strings.Contains(e.Name, "MultiBlockMigrations.") {
+			continue
+		}
+		index := int(e.Phase.AsApplyExtrinsic)
+		if index >= len(block.Block.Extrinsics) || index >= len(data.Extrinsics) {
+			return errors.New("The number of extrinsics hashes does not equal the number of extrinsics")
+		}
+		pending = append(pending, e)
+		if e.Name != SystemExtrinsicSuccess && e.Name != SystemExtrinsicFailed {
+			continue
+		}
+		name, ok, err := callName(block.Block.Extrinsics[index].Method.CallIndex)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			pending = nil
+			continue
+		}
+
+		info := &data.Extrinsics[index]
+		info.Name = name
+		info.Result = e.Name == SystemExtrinsicSuccess
+		info.Signer, info.FeePaid = "", ""
+		info.Events = make([]string, len(pending))
+		for k, v := range pending {
+			info.Events[k] = v.Name
+			if v.Name == TransactionPaymentTransactionFeePaid || v.Name == EvmAccountMappingTransactionFeePaid {
+				signer, fee, err := parseSignerAndFeePaidFromEvent(v)
+				if err != nil {
+					return err
+				}
+				info.Signer, info.FeePaid = signer, fee
+			}
+		}
+
+		ctx := &ParseContext{
+			BlockNumber:    number,
+			BlockHash:      data.BlockHash,
+			ExtrinsicIndex: index,
+			Extrinsic:      info,
+			Call:           &block.Block.Extrinsics[index].Method,
+			Events:         pending,
+			data:           data,
+		}
+		if info.Result {
+			for _, v := range pending {
+				if err := dispatchEvent(ctx, v, handlers); err != nil {
+					return err
+				}
+			}
+		}
+		for _, h := range handlers {
+			for _, fn := range h.extrinsicHandlers(name) {
+				if err := fn(ctx); err != nil {
+					return fmt.Errorf("handle extrinsic %s: %w", name, err)
+				}
+			}
+		}
+		pending = nil
+	}
+	return nil
+}
+
+func dispatchEvent(ctx *ParseContext, e *parser.Event, handlers []*BlockHandlers) error {
+	for _, h := range handlers {
+		for _, fn := range h.eventHandlers(e.Name) {
+			if err := fn(ctx, e); err != nil {
+				return fmt.Errorf("handle event %s: %w", e.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/vedhavyas/go-subkey/v2/scale"
+)
+
+// defaultBlockHandlers fill the fields of BlockData, they run before the handlers
+// passed with WithHandlers
+var defaultBlockHandlers = newDefaultBlockHandlers()
+
+func newDefaultBlockHandlers() *BlockHandlers {
+	h := NewBlockHandlers()
+
+	// events outside extrinsics
+	onSystem := func(name string, fn func(ctx *ParseContext, e *parser.Event, data *BlockData) error) {
+		HandleEvent(h, name, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+			if ctx.Extrinsic != nil {
+				return nil
+			}
+			return fn(ctx, e, data)
+		})
+	}
+	// events of succeeded extrinsics
+	onExtrinsic := func(name string, fn func(ctx *ParseContext, e *parser.Event, data *BlockData) error) {
+		HandleEvent(h, name, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+			if ctx.Extrinsic == nil {
+				return nil
+			}
+			return fn(ctx, e, data)
+		})
+	}
+
+	onSystem(AnyName, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		data.SysEvents = append(data.SysEvents, e.Name)
+		return nil
+	})
+	onSystem(StakingStakersElected, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		data.IsNewEra = true
+		return nil
+	})
+	onSystem(AuditGenerateChallenge, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.GenChallenge = append(data.GenChallenge, acc)
+		return nil
+	})
+	onSystem(StakingEraPaid, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		eraIndex, validatorPayout, remainder, err := ParseStakingEraPaidFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.EraPaid = EraPaid{
+			HaveValue:       true,
+			EraIndex:        eraIndex,
+			ValidatorPayout: validatorPayout,
+			Remainder:       remainder,
+		}
+		return nil
+	})
+
+	HandleEvent(h, SystemNewAccount, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.NewAccounts = append(data.NewAccounts, acc)
+		return nil
+	})
+	HandleEvent(h, BalancesTransfer, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		from, to, amount, err := ParseTransferInfoFromEvent(e)
+		if err != nil {
+			return err
+		}
+		transfer := TransferInfo{From: from, To: to, Amount: amount, Result: true}
+		punishment := Punishment{From: from, To: to, Amount: amount}
+		if ctx.Extrinsic == nil {
+			data.TransferInfo = append(data.TransferInfo, transfer)
+			if to == TreasuryAccount {
+				data.Punishment = append(data.Punishment, punishment)
+			}
+			return nil
+		}
+		transfer.ExtrinsicName, transfer.ExtrinsicHash = ctx.Extrinsic.Name, ctx.Extrinsic.Hash
+		data.TransferInfo = append(data.TransferInfo, transfer)
+		if ctx.Extrinsic.Name == ExtName_Audit_submit_verify_service_result {
+			punishment.ExtrinsicName, punishment.ExtrinsicHash = ctx.Extrinsic.Name, ctx.Extrinsic.Hash
+			data.Punishment = append(data.Punishment, punishment)
+		}
+		return nil
+	})
+
+	onExtrinsic(SminerRegistered, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.MinerReg = append(data.MinerReg, MinerRegInfo{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Account:       acc,
+		})
+		return nil
+	})
+	onExtrinsic(FileBankUploadDeclaration, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		fid, err := ParseStringFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.UploadDecInfo = append(data.UploadDecInfo, UploadDecInfo{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Owner:         acc,
+			Fid:           fid,
+		})
+		return nil
+	})
+	onExtrinsic(FileBankDeleteFile, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		fid, err := ParseStringFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.DeleteFileInfo = append(data.DeleteFileInfo, DeleteFileInfo{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Owner:         acc,
+			Fid:           fid,
+		})
+		return nil
+	})
+	onExtrinsic(StorageHandlerMintTerritory, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		token, name, size, err := parseTerritoryInfoFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.MintTerritory = append(data.MintTerritory, MintTerritory{
+			ExtrinsicHash:  ctx.Extrinsic.Hash,
+			Account:        ctx.Extrinsic.Signer,
+			TerritoryToken: token,
+			TerritoryName:  name,
+			TerritorySize:  size,
+		})
+		return nil
+	})
+	onExtrinsic(AuditSubmitIdleProof, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.SubmitIdleProve = append(data.SubmitIdleProve, SubmitIdleProve{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Miner:         acc,
+		})
+		return nil
+	})
+	onExtrinsic(AuditSubmitServiceProof, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.SubmitServiceProve = append(data.SubmitServiceProve, SubmitServiceProve{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Miner:         acc,
+		})
+		return nil
+	})
+	onExtrinsic(AuditSubmitIdleVerifyResult, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, result, err := ParseChallResultFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.SubmitIdleResult = append(data.SubmitIdleResult, SubmitIdleResult{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Miner:         acc,
+			Result:        result,
+		})
+		return nil
+	})
+	onExtrinsic(AuditSubmitServiceVerifyResult, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, result, err := ParseChallResultFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.SubmitServiceResult = append(data.SubmitServiceResult, SubmitServiceResult{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Miner:         acc,
+			Result:        result,
+		})
+		return nil
+	})
+	onExtrinsic(SminerRegisterPoisKey, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.MinerRegPoiskeys = append(data.MinerRegPoiskeys, MinerRegPoiskey{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Miner:         acc,
+		})
+		return nil
+	})
+	onExtrinsic(OssOssRegister, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, err := ParseAccountFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.GatewayReg = append(data.GatewayReg, GatewayReg{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Account:       acc,
+		})
+		return nil
+	})
+	onExtrinsic(FileBankStorageCompleted, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		fid, err := ParseStringFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.StorageCompleted = append(data.StorageCompleted, fid)
+		return nil
+	})
+	onExtrinsic(StakingPayoutStarted, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		eraIndex, validatorStash, err := ParseStakingPayoutStartedFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.StakingPayouts = append(data.StakingPayouts, StakingPayout{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			EraIndex:      eraIndex,
+			ClaimedAcc:    validatorStash,
+		})
+		return nil
+	})
+	onExtrinsic(StakingRewarded, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, amount, err := ParseStakingRewardedFromEvent(e)
+		if err != nil {
+			return err
+		}
+		for i := 0; i < len(data.StakingPayouts); i++ {
+			if data.StakingPayouts[i].ClaimedAcc == acc && data.StakingPayouts[i].ExtrinsicHash == ctx.Extrinsic.Hash {
+				data.StakingPayouts[i].Amount = amount
+				break
+			}
+		}
+		return nil
+	})
+	onExtrinsic(StakingUnbonded, func(ctx *ParseContext, e *parser.Event, data *BlockData) error {
+		acc, amount, err := ParseStakingRewardedFromEvent(e)
+		if err != nil {
+			return err
+		}
+		data.Unbonded = append(data.Unbonded, Unbonded{
+			ExtrinsicHash: ctx.Extrinsic.Hash,
+			Account:       acc,
+			Amount:        amount,
+		})
+		return nil
+	})
+
+	HandleExtrinsic(h, ExtName_Timestamp_set, func(ctx *ParseContext, data *BlockData) error {
+		timestamp, err := scale.NewDecoder(bytes.NewReader(ctx.Call.Args)).DecodeUintCompact()
+		if err != nil {
+			return err
+		}
+		data.Timestamp = timestamp.Int64()
+		return nil
+	})
+	// the fees of failed extrinsics are paid too
+	HandleExtrinsic(h, AnyName, func(ctx *ParseContext, data *BlockData) error {
+		fee, ok := new(big.Int).SetString(ctx.Extrinsic.FeePaid, 10)
+		if !ok {
+			return nil
+		}
+		total, ok := new(big.Int).SetString(data.AllGasFee, 10)
+		if !ok {
+			total = new(big.Int)
+		}
+		data.AllGasFee = total.Add(total, fee).String()
+		return nil
+	})
+	return h
+}
@@ -0,0 +1,135 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAuthorizations struct {
+	Hashes []string
+	Failed int
+}
+
+type testWithdrawals struct {
+	Events     int
+	Extrinsics []string
+}
+
+func TestHandleBlockEvents(t *testing.T) {
+	extrinsicsNameLock.Lock()
+	names := ExtrinsicsName
+	ExtrinsicsName = map[types.CallIndex]string{
+		{SectionIndex: 0, MethodIndex: 0}: ExtName_Timestamp_set,
+		{SectionIndex: 1, MethodIndex: 0}: ExtName_Oss_authorize,
+	}
+	extrinsicsNameLock.Unlock()
+	defer func() {
+		extrinsicsNameLock.Lock()
+		ExtrinsicsName = names
+		extrinsicsNameLock.Unlock()
+	}()
+
+	timestamp, err := codec.Encode(types.NewUCompactFromUInt(1000))
+	require.NoError(t, err)
+	authorize := types.Extrinsic{Method: types.Call{CallIndex: types.CallIndex{SectionIndex: 1}}}
+	// Balances.transfer_keep_alive is not in the extrinsic names
+	transfer := types.Extrinsic{Method: types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 3}}}
+	unknown := types.Extrinsic{Method: types.Call{CallIndex: types.CallIndex{SectionIndex: 9, MethodIndex: 9}}}
+	block := &types.SignedBlock{Block: types.Block{Extrinsics: []types.Extrinsic{
+		{Method: types.Call{Args: timestamp}},
+		authorize,
+		authorize,
+		transfer,
+		unknown,
+	}}}
+	decoder, err := newCallDecoder(testCallMetadata())
+	require.NoError(t, err)
+	callName := func(index types.CallIndex) (string, bool, error) {
+		name, ok := decoder.extrinsicName(index, 0)
+		return name, ok, nil
+	}
+	event := func(name string, phase types.Phase) *parser.Event {
+		return &parser.Event{Name: name, Phase: &phase}
+	}
+	apply := func(index uint32) types.Phase {
+		return types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: index}
+	}
+	events := []*parser.Event{
+		event(StakingStakersElected, types.Phase{IsInitialization: true}),
+		event(SystemExtrinsicSuccess, apply(0)),
+		event(OssAuthorize, apply(1)),
+		event(SystemExtrinsicSuccess, apply(1)),
+		event(OssAuthorize, apply(2)),
+		event(SystemExtrinsicFailed, apply(2)),
+		event(BalancesWithdraw, apply(3)),
+		event(SystemExtrinsicSuccess, apply(3)),
+		event(BalancesWithdraw, apply(4)),
+		event(SystemExtrinsicSuccess, apply(4)),
+	}
+
+	h := NewBlockHandlers()
+	HandleEvent(h, OssAuthorize, func(ctx *ParseContext, e *parser.Event, result *testAuthorizations) error {
+		result.Hashes = append(result.Hashes, ctx.Extrinsic.Hash)
+		return nil
+	})
+	HandleExtrinsic(h, ExtName_Oss_authorize, func(ctx *ParseContext, result *testAuthorizations) error {
+		if !ctx.Extrinsic.Result {
+			result.Failed++
+		}
+		return nil
+	})
+	HandleEvent(h, BalancesWithdraw, func(ctx *ParseContext, e *parser.Event, result *testWithdrawals) error {
+		result.Events++
+		return nil
+	})
+	HandleExtrinsic(h, "Balances.transfer_keep_alive", func(ctx *ParseContext, result *testWithdrawals) error {
+		result.Extrinsics = append(result.Extrinsics, ctx.Extrinsic.Hash)
+		return nil
+	})
+	data := BlockData{AllGasFee: "0", Extrinsics: []ExtrinsicsInfo{{Hash: "0x0"}, {Hash: "0x1"}, {Hash: "0x2"}, {Hash: "0x3"}, {Hash: "0x4"}}}
+	require.NoError(t, handleBlockEvents(&data, block, 1, events, callName, []*BlockHandlers{defaultBlockHandlers, h}))
+
+	assert.True(t, data.IsNewEra)
+	assert.Equal(t, []string{StakingStakersElected}, data.SysEvents)
+	assert.Equal(t, int64(1000), data.Timestamp)
+	assert.Equal(t, "0", data.AllGasFee)
+	assert.Equal(t, ExtrinsicsInfo{Name: ExtName_Timestamp_set, Hash: "0x0", Result: true, Events: []string{SystemExtrinsicSuccess}}, data.Extrinsics[0])
+	assert.Equal(t, ExtrinsicsInfo{Name: ExtName_Oss_authorize, Hash: "0x2", Events: []string{OssAuthorize, SystemExtrinsicFailed}}, data.Extrinsics[2])
+
+	// the events of the failed extrinsic are dropped
+	result := BlockResult[testAuthorizations](data)
+	require.NotNil(t, result)
+	assert.Equal(t, []string{"0x1"}, result.Hashes)
+	assert.Equal(t, 1, result.Failed)
+	assert.Nil(t, BlockResult[EraPaid](data))
+
+	// the extrinsic missing from the extrinsic names is named after the metadata
+	assert.Equal(t, ExtrinsicsInfo{Name: "Balances.transfer_keep_alive", Hash: "0x3", Result: true, Events: []string{BalancesWithdraw, SystemExtrinsicSuccess}}, data.Extrinsics[3])
+	withdrawals := BlockResult[testWithdrawals](data)
+	require.NotNil(t, withdrawals)
+	assert.Equal(t, testWithdrawals{Events: 1, Extrinsics: []string{"0x3"}}, *withdrawals)
+	// the extrinsic missing from the metadata is skipped with its events
+	assert.Equal(t, ExtrinsicsInfo{Hash: "0x4"}, data.Extrinsics[4])
+
+	// the errors of the handlers are returned
+	failing := NewBlockHandlers()
+	failing.OnEvent(AnyName, func(ctx *ParseContext, e *parser.Event) error {
+		return errors.New("handler failed")
+	})
+	data = BlockData{Extrinsics: make([]ExtrinsicsInfo, 5)}
+	err = handleBlockEvents(&data, block, 1, events, callName, []*BlockHandlers{failing})
+	assert.ErrorContains(t, err, "handler failed")
+}
@@ -0,0 +1,167 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+)
+
+type blockRefKind uint8
+
+const (
+	blockRefLatest blockRefKind = iota
+	blockRefFinalized
+	blockRefNumber
+	blockRefHash
+)
+
+// BlockRef refers to a block of the chain by its number, its hash,
+// or as the latest or the finalized block, the zero value is the latest block.
+// The queries read at the BlockRef they are called with, a block hash is read
+// without resolving it, the other blocks cost a request to resolve their hash.
+type BlockRef struct {
+	kind   blockRefKind
+	number uint64
+	hash   types.Hash
+}
+
+// LatestBlock refers to the best block of the node
+func LatestBlock() BlockRef {
+	return BlockRef{kind: blockRefLatest}
+}
+
+// FinalizedBlock refers to the last finalized block
+func FinalizedBlock() BlockRef {
+	return BlockRef{kind: blockRefFinalized}
+}
+
+// BlockNumber refers to the block of the canonical chain at the height
+func BlockNumber(number uint64) BlockRef {
+	return BlockRef{kind: blockRefNumber, number: number}
+}
+
+// BlockHash refers to the block with the hash, it may be a block of a fork
+func BlockHash(hash types.Hash) BlockRef {
+	return BlockRef{kind: blockRefHash, hash: hash}
+}
+
+func (r BlockRef) String() string {
+	switch r.kind {
+	case blockRefFinalized:
+		return "finalized"
+	case blockRefNumber:
+		return fmt.Sprint(r.number)
+	case blockRefHash:
+		return r.hash.Hex()
+	}
+	return "latest"
+}
+
+// ResolveBlock returns the hash of the block, a block hash is returned as it is
+//   - ref: the block
+//
+// Return:
+//   - types.Hash: hash of the block
+//   - error: error message
+func (c *ChainClient) ResolveBlock(ref BlockRef) (types.Hash, error) {
+	if ref.kind == blockRefHash {
+		return ref.hash, nil
+	}
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return types.Hash{}, fmt.Errorf("rpc err: [%s] resolve block %s: %s", c.GetCurrentRpcAddr(), ref, ERR_RPC_CONNECTION.Error())
+		}
+	}
+
+	var (
+		hash types.Hash
+		err  error
+	)
+	switch ref.kind {
+	case blockRefFinalized:
+		hash, err = c.api.RPC.Chain.GetFinalizedHead()
+	case blockRefNumber:
+		hash, err = c.api.RPC.Chain.GetBlockHash(ref.number)
+	default:
+		hash, err = c.api.RPC.Chain.GetBlockHashLatest()
+	}
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return types.Hash{}, fmt.Errorf("rpc err: [%s] resolve block %s: %v", c.GetCurrentRpcAddr(), ref, err)
+	}
+	if hash == (types.Hash{}) {
+		return types.Hash{}, fmt.Errorf("rpc err: [%s] resolve block %s: block not found", c.GetCurrentRpcAddr(), ref)
+	}
+	return hash, nil
+}
+
+// At returns a chain client that shares the connection and account of c,
+// but whose queries read the chain at the block instead of the latest block.
+// The block is resolved once, so all queries of the returned client see the same state.
+//   - ref: the block to read at
+//
+// Return:
+//   - Chainer: chain client reading at the block
+//   - error: error message
+//
+// Note:
+//   - the queries of the returned client called with LatestBlock() read at the block,
+//     the queries called with another BlockRef still read at that block
+//   - the returned client cannot submit transactions, they fail with ERR_PinnedClient
+func (c *ChainClient) At(ref BlockRef) (Chainer, error) {
+	hash, err := c.ResolveBlock(ref)
+	if err != nil {
+		return nil, err
+	}
+	return &ChainClient{
+		clientState: c.clientState,
+		ctx:         c.ctx,
+		api:         newSubstrateAPIAt(c.ctx, c.clientState, &hash),
+		at:          &hash,
+	}, nil
+}
+
+// PinnedBlock returns the block the queries of the client read at
+//
+// Return:
+//   - types.Hash: hash of the block
+//   - bool: false if the queries read the latest block
+func (c *ChainClient) PinnedBlock() (types.Hash, bool) {
+	if c.at == nil {
+		return types.Hash{}, false
+	}
+	return *c.at, true
+}
+
+// pinnedMethods are the state reads whose last optional parameter is
+// a block hash, mapped to the number of their other parameters
+var pinnedMethods = map[string]int{
+	"state_getStorage":     1,
+	"state_getStorageHash": 1,
+	"state_getStorageSize": 1,
+	"state_getKeys":        1,
+	"state_queryStorageAt": 1,
+	RPC_State_getKeysPaged: 3,
+	RPC_State_call:         2,
+}
+
+// pinBlock appends the block hash to the parameters of a state read of the latest block
+func pinBlock(at *types.Hash, method string, args []interface{}) []interface{} {
+	if at == nil {
+		return args
+	}
+	n, ok := pinnedMethods[method]
+	if !ok || len(args) != n {
+		return args
+	}
+	return append(args[:n:n], at.Hex())
+}
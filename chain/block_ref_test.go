@@ -0,0 +1,90 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPinBlock(t *testing.T) {
+	at := types.NewHash(bytes.Repeat([]byte{1}, 32))
+	args := []interface{}{"0x01"}
+	assert.Equal(t, args, pinBlock(nil, "state_getStorage", args))
+	assert.Equal(t, []interface{}{"0x01", at.Hex()}, pinBlock(&at, "state_getStorage", args))
+	assert.Equal(t, []interface{}{"0x01"}, args)
+	// the block hash is already given
+	assert.Equal(t, []interface{}{"0x01", "0x02"}, pinBlock(&at, "state_getStorage", []interface{}{"0x01", "0x02"}))
+	assert.Equal(t, args, pinBlock(&at, "chain_getHeader", args))
+
+	assert.Equal(t, "latest", BlockRef{}.String())
+	assert.Equal(t, "finalized", FinalizedBlock().String())
+	assert.Equal(t, "4294967296", BlockNumber(1<<32).String())
+	assert.Equal(t, at.Hex(), BlockHash(at).String())
+}
+
+func TestAt(t *testing.T) {
+	entry, err := storageEntry(testStorageMetadata(), System, Account)
+	require.NoError(t, err)
+	account := bytes.Repeat([]byte{1}, 32)
+	key, err := entryKey(System, Account, entry, [][]byte{account})
+	require.NoError(t, err)
+	value, err := codec.Encode(types.NewU32(7))
+	require.NoError(t, err)
+
+	c, state := newStorageTestClient(t, map[string]string{key.Hex(): codec.HexEncodeToString(value)})
+	genesis := "0x" + strings.Repeat("01", 32)
+
+	_, err = QueryStorage[types.U32](nil, c, System, Account, LatestBlock(), account)
+	require.NoError(t, err)
+	assert.Equal(t, "", state.lastAt.Load())
+
+	pinned, err := c.At(BlockNumber(5))
+	require.NoError(t, err)
+	hash, ok := pinned.PinnedBlock()
+	assert.True(t, ok)
+	assert.Equal(t, genesis, hash.Hex())
+
+	v, err := QueryStorage[types.U32](nil, pinned, System, Account, LatestBlock(), account)
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(7), v)
+	assert.Equal(t, genesis, state.lastAt.Load())
+
+	// the pin is kept by a client with another context
+	_, err = QueryStorage[types.U32](nil, pinned.WithContext(nil), System, Account, LatestBlock(), account)
+	require.NoError(t, err)
+	assert.Equal(t, genesis, state.lastAt.Load())
+
+	other := types.NewHash(bytes.Repeat([]byte{2}, 32))
+	resolved, err := c.ResolveBlock(BlockHash(other))
+	require.NoError(t, err)
+	assert.Equal(t, other, resolved)
+
+	// a block hash is read as it is, also by a pinned client
+	_, err = QueryStorage[types.U32](nil, pinned, System, Account, BlockHash(other), account)
+	require.NoError(t, err)
+	assert.Equal(t, other.Hex(), state.lastAt.Load())
+	// a number beyond int32 is resolved
+	_, err = QueryStorage[types.U32](nil, c, System, Account, BlockNumber(1<<32), account)
+	require.NoError(t, err)
+	assert.Equal(t, genesis, state.lastAt.Load())
+
+	// the pinned client does not build or submit transactions
+	_, err = pinned.(*ChainClient).SubmitExtrinsic(types.Call{}, ExtName_Balances_transferKeepAlive)
+	assert.ErrorIs(t, err, ERR_PinnedClient)
+	_, err = pinned.BuildUnsignedTx(account, types.Call{}, ExtName_Balances_transferKeepAlive)
+	assert.ErrorIs(t, err, ERR_PinnedClient)
+	_, err = pinned.SubmitSignedTx(SignedTx{})
+	assert.ErrorIs(t, err, ERR_PinnedClient)
+}
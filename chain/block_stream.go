@@ -0,0 +1,312 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"golang.org/x/crypto/blake2b"
+)
+
+// BlockHead is a block delivered by a block stream
+type BlockHead struct {
+	Number uint64
+	Hash   types.Hash
+	Header types.Header
+	// whether the block was not notified but filled in between two notified blocks
+	Filled bool
+	// parsed block, only set if the stream was created WithBlockData
+	Data *BlockData
+	// error of parsing the block, the block is delivered without its data
+	Err error
+}
+
+// HeadOptions configures a block stream
+type HeadOptions struct {
+	// number of the first block to deliver, the first notified block if nil
+	From *uint64
+	// deliver each block with its parsed data
+	Parse bool
+	// options of ParseBlockData
+	ParseOpts []ParseOption
+}
+
+// HeadOption sets an option of a block stream
+type HeadOption func(o *HeadOptions)
+
+// StartFrom delivers the blocks from the number on, the blocks
+// before the first notified block are filled in
+func StartFrom(number uint64) HeadOption {
+	return func(o *HeadOptions) {
+		o.From = &number
+	}
+}
+
+// WithBlockData delivers each block with its data parsed by ParseBlockDataByHash
+//   - opts: options of ParseBlockDataByHash, e.g. WithCallArgs
+func WithBlockData(opts ...ParseOption) HeadOption {
+	return func(o *HeadOptions) {
+		o.Parse = true
+		o.ParseOpts = opts
+	}
+}
+
+// maxFilledBlocks is the number of skipped blocks fetched before they are delivered
+const maxFilledBlocks = 64
+
+// headSubscription is a subscription to new or finalized heads
+type headSubscription interface {
+	Chan() <-chan types.Header
+	Err() <-chan error
+	Unsubscribe()
+}
+
+// SubscribeNewHeads delivers the new best blocks of the chain in order, the block numbers
+// skipped between two notifications are filled in with the ancestors of the new best block.
+// A block of a fork is delivered when it becomes the best block, even if its number was delivered before.
+// The subscription is renewed if the connection is lost and the stream continues after the last block delivered.
+// The channel is closed when ctx is done.
+//   - ctx: context of the stream, nil uses the context of the client
+//   - opts: options of the stream
+//
+// Return:
+//   - <-chan BlockHead: the blocks
+//   - error: error message
+func (c *ChainClient) SubscribeNewHeads(ctx context.Context, opts ...HeadOption) (<-chan BlockHead, error) {
+	return c.subscribeHeads(ctx, false, opts, func(c *ChainClient) (headSubscription, error) {
+		return c.api.RPC.Chain.SubscribeNewHeads()
+	})
+}
+
+// SubscribeFinalizedHeads delivers the finalized blocks of the chain in order, the block numbers
+// skipped between two notifications are filled in.
+// The subscription is renewed if the connection is lost and the stream continues after the last block delivered.
+// The channel is closed when ctx is done.
+//   - ctx: context of the stream, nil uses the context of the client
+//   - opts: options of the stream
+//
+// Return:
+//   - <-chan BlockHead: the blocks
+//   - error: error message
+func (c *ChainClient) SubscribeFinalizedHeads(ctx context.Context, opts ...HeadOption) (<-chan BlockHead, error) {
+	return c.subscribeHeads(ctx, true, opts, func(c *ChainClient) (headSubscription, error) {
+		return c.api.RPC.Chain.SubscribeFinalizedHeads()
+	})
+}
+
+func (c *ChainClient) subscribeHeads(ctx context.Context, finalized bool, opts []HeadOption, subscribe func(*ChainClient) (headSubscription, error)) (<-chan BlockHead, error) {
+	c = c.withQueryContext(ctx)
+	var o HeadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return nil, fmt.Errorf("rpc err: [%s] [sub] [chain] %s", c.GetCurrentRpcAddr(), ERR_RPC_CONNECTION.Error())
+		}
+	}
+	sub, err := subscribe(c)
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return nil, fmt.Errorf("rpc err: [%s] [sub] [chain] %v", c.GetCurrentRpcAddr(), err)
+	}
+
+	f := &headFollower{finalized: finalized, fetch: c.fetchHeader, fetchByHash: c.fetchHeaderByHash, lastFinalized: c.lastFinalizedNumber}
+	if o.From != nil {
+		f.next, f.started = *o.From, true
+	}
+	heads := make(chan BlockHead)
+	go c.followHeads(sub, subscribe, f, o, heads)
+	return heads, nil
+}
+
+// followHeads delivers the blocks notified by the subscription until the context
+// of the client is done, it subscribes again when the subscription fails
+func (c *ChainClient) followHeads(sub headSubscription, subscribe func(*ChainClient) (headSubscription, error), f *headFollower, o HeadOptions, heads chan<- BlockHead) {
+	defer close(heads)
+	defer func() {
+		if sub != nil {
+			sub.Unsubscribe()
+		}
+	}()
+	for {
+		if sub == nil {
+			var err error
+			if sub, err = resubscribe(c.ctx, func() (headSubscription, error) { return subscribe(c) }); err != nil {
+				return
+			}
+		}
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-sub.Err():
+			sub.Unsubscribe()
+			sub = nil
+		case header, ok := <-sub.Chan():
+			if !ok {
+				sub = nil
+				continue
+			}
+			for {
+				blocks, done, err := f.follow(header, maxFilledBlocks)
+				for _, block := range blocks {
+					if o.Parse {
+						data, err := c.ParseBlockDataByHash(block.Hash, o.ParseOpts...)
+						if err != nil {
+							block.Err = err
+						} else {
+							block.Data = &data
+						}
+					}
+					select {
+					case heads <- block:
+					case <-c.ctx.Done():
+						return
+					}
+				}
+				if err != nil {
+					// the blocks not filled in are fetched again after the next notification
+					sub.Unsubscribe()
+					sub = nil
+					break
+				}
+				if done {
+					break
+				}
+			}
+		}
+	}
+}
+
+// fetchHeader returns the header of the block of the canonical chain at the number
+func (c *ChainClient) fetchHeader(number uint64) (types.Header, types.Hash, error) {
+	hash, err := c.api.RPC.Chain.GetBlockHash(number)
+	if err != nil {
+		return types.Header{}, types.Hash{}, err
+	}
+	header, err := c.fetchHeaderByHash(hash)
+	if err != nil {
+		return types.Header{}, types.Hash{}, err
+	}
+	return header, hash, nil
+}
+
+// fetchHeaderByHash returns the header of the block of the hash
+func (c *ChainClient) fetchHeaderByHash(hash types.Hash) (types.Header, error) {
+	header, err := c.api.RPC.Chain.GetHeader(hash)
+	if err != nil {
+		return types.Header{}, err
+	}
+	return *header, nil
+}
+
+// lastFinalizedNumber returns the number of the last finalized block
+func (c *ChainClient) lastFinalizedNumber() (uint64, error) {
+	hash, err := c.api.RPC.Chain.GetFinalizedHead()
+	if err != nil {
+		return 0, err
+	}
+	header, err := c.fetchHeaderByHash(hash)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(header.Number), nil
+}
+
+// headFollower turns the notified heads into a stream of blocks without gaps
+type headFollower struct {
+	finalized bool
+	// number of the next block to deliver
+	next    uint64
+	started bool
+	// fetch returns the block of the canonical chain at the number, only used for finalized blocks
+	fetch func(number uint64) (types.Header, types.Hash, error)
+	// fetchByHash returns the header of the block, used to walk back from a new head
+	fetchByHash func(hash types.Hash) (types.Header, error)
+	// lastFinalized returns the number of the last finalized block, not used by finalized streams
+	lastFinalized func() (uint64, error)
+}
+
+// follow returns the next blocks to deliver up to the notified head, at most limit
+// finalized blocks are filled in per call and done is false until the head is returned.
+// The skipped blocks that are not finalized are the ancestors of the head, they are
+// found by walking back its parents and returned at once with the head.
+// The blocks returned with an error are kept, the others are returned by the next call.
+func (f *headFollower) follow(header types.Header, limit int) (blocks []BlockHead, done bool, err error) {
+	number := uint64(header.Number)
+	if !f.started {
+		f.next, f.started = number, true
+	}
+	if number < f.next && f.finalized {
+		return nil, true, nil
+	}
+
+	// the finalized blocks are the same on all forks and filled in by number
+	end := number
+	if !f.finalized && f.next < number {
+		finalized, err := f.lastFinalized()
+		if err != nil {
+			return nil, false, err
+		}
+		end = min(end, max(f.next, finalized+1))
+	}
+	for ; f.next < end; f.next++ {
+		if len(blocks) == limit {
+			return blocks, false, nil
+		}
+		h, hash, err := f.fetch(f.next)
+		if err != nil {
+			return blocks, false, err
+		}
+		blocks = append(blocks, BlockHead{Number: f.next, Hash: hash, Header: h, Filled: true})
+	}
+	if f.next < number {
+		ancestors, err := f.ancestors(header, number-f.next)
+		if err != nil {
+			return blocks, false, err
+		}
+		blocks = append(blocks, ancestors...)
+	}
+	hash, err := headerHash(header)
+	if err != nil {
+		return blocks, false, err
+	}
+	blocks = append(blocks, BlockHead{Number: number, Hash: hash, Header: header})
+	f.next = number + 1
+	return blocks, true, nil
+}
+
+// ancestors returns the n blocks before the header in order, walking back its parents
+func (f *headFollower) ancestors(header types.Header, n uint64) ([]BlockHead, error) {
+	blocks := make([]BlockHead, n)
+	parent := header.ParentHash
+	for i := len(blocks) - 1; i >= 0; i-- {
+		h, err := f.fetchByHash(parent)
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = BlockHead{Number: uint64(h.Number), Hash: parent, Header: h, Filled: true}
+		parent = h.ParentHash
+	}
+	return blocks, nil
+}
+
+// headerHash returns the hash of the block of the header
+func headerHash(header types.Header) (types.Hash, error) {
+	encoded, err := codec.Encode(header)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	hash := blake2b.Sum256(encoded)
+	return types.NewHash(hash[:]), nil
+}
@@ -0,0 +1,114 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func numbers(blocks []BlockHead) []uint64 {
+	var result []uint64
+	for _, b := range blocks {
+		result = append(result, b.Number)
+	}
+	return result
+}
+
+func TestHeadFollower(t *testing.T) {
+	var failAt uint64
+	fetch := func(n uint64) (types.Header, types.Hash, error) {
+		if n == failAt {
+			return types.Header{}, types.Hash{}, errors.New("connection lost")
+		}
+		return types.Header{Number: types.BlockNumber(n)}, types.Hash{byte(n)}, nil
+	}
+	head := func(n uint64) types.Header {
+		return types.Header{Number: types.BlockNumber(n)}
+	}
+
+	f := &headFollower{finalized: true, fetch: fetch}
+	blocks, done, err := f.follow(head(10), maxFilledBlocks)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []uint64{10}, numbers(blocks))
+	assert.False(t, blocks[0].Filled)
+	hash, err := headerHash(head(10))
+	require.NoError(t, err)
+	assert.Equal(t, hash, blocks[0].Hash)
+
+	// the skipped blocks are filled in
+	blocks, done, err = f.follow(head(13), maxFilledBlocks)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []uint64{11, 12, 13}, numbers(blocks))
+	assert.True(t, blocks[0].Filled)
+	assert.Equal(t, types.Hash{11}, blocks[0].Hash)
+
+	// a finalized block is not delivered twice
+	blocks, done, err = f.follow(head(12), maxFilledBlocks)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Empty(t, blocks)
+
+	// the filling is resumed after an error
+	failAt = 16
+	blocks, done, err = f.follow(head(18), maxFilledBlocks)
+	assert.Error(t, err)
+	assert.False(t, done)
+	assert.Equal(t, []uint64{14, 15}, numbers(blocks))
+	failAt = 0
+	blocks, done, err = f.follow(head(18), 1)
+	require.NoError(t, err)
+	assert.False(t, done)
+	assert.Equal(t, []uint64{16}, numbers(blocks))
+	blocks, done, err = f.follow(head(18), 1)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []uint64{17, 18}, numbers(blocks))
+
+	// the blocks of a fork are found by their hash, the hash of block n of the fork is {n, 0xf}
+	fork := func(n uint64) types.Hash {
+		return types.Hash{byte(n), 0xf}
+	}
+	var finalized uint64 = 3
+	fetchByHash := func(hash types.Hash) (types.Header, error) {
+		n := uint64(hash[0])
+		parent := fork(n - 1)
+		if n-1 <= finalized {
+			parent = types.Hash{byte(n - 1)}
+		}
+		return types.Header{Number: types.BlockNumber(n), ParentHash: parent}, nil
+	}
+	lastFinalized := func() (uint64, error) {
+		return finalized, nil
+	}
+	forkHead := func(n uint64) types.Header {
+		return types.Header{Number: types.BlockNumber(n), ParentHash: fork(n - 1)}
+	}
+
+	// the finalized blocks are filled in by number, the others are the ancestors of the head
+	f = &headFollower{fetch: fetch, fetchByHash: fetchByHash, lastFinalized: lastFinalized, next: 2, started: true}
+	blocks, done, err = f.follow(forkHead(6), maxFilledBlocks)
+	require.NoError(t, err)
+	assert.True(t, done)
+	assert.Equal(t, []uint64{2, 3, 4, 5, 6}, numbers(blocks))
+	assert.Equal(t, types.Hash{3}, blocks[1].Hash)
+	assert.Equal(t, fork(4), blocks[2].Hash)
+	assert.Equal(t, fork(5), blocks[3].Hash)
+	assert.True(t, blocks[3].Filled)
+
+	// a new best block of a fork may have a number delivered before
+	blocks, _, err = f.follow(forkHead(6), maxFilledBlocks)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{6}, numbers(blocks))
+}
@@ -0,0 +1,466 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/scale"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// DecodedCall is a call of an extrinsic decoded with the runtime metadata
+type DecodedCall struct {
+	// name of the call, e.g. FileBank.upload_declaration
+	Name string
+	Args []CallArg
+	// calls nested in the arguments, e.g. the calls of Utility.batch, Proxy.proxy or Multisig.as_multi
+	Calls []*DecodedCall
+	// arguments of the CESS calls submitted by the sdk, e.g. *Call_UploadDeclaration,
+	// nil for the other calls or if the arguments do not match the struct
+	Typed any
+}
+
+// CallArg is an argument of a decoded call, its value is one of:
+//   - bool, string, types.U8 to types.U256, types.I8 to types.I256 or types.UCompact
+//   - types.Bytes for byte sequences and byte arrays
+//   - []any for the other sequences, arrays and tuples
+//   - registry.DecodedFields for structs, a struct with a single unnamed field is replaced by its value
+//   - *CallVariant for enums, nil or the value for options
+//   - *DecodedCall for calls
+type CallArg struct {
+	Name string
+	// type name of the argument in the runtime, e.g. AccountIdLookupOf<T>
+	Type  string
+	Value any
+}
+
+// CallVariant is a value of an enum
+type CallVariant struct {
+	Name   string
+	Index  uint8
+	Fields registry.DecodedFields
+}
+
+// Arg returns the value of the argument
+//   - name: name of the argument, e.g. dest
+//
+// Return:
+//   - any: value of the argument
+//   - bool: false if the call has no such argument
+func (c *DecodedCall) Arg(name string) (any, bool) {
+	for _, arg := range c.Args {
+		if arg.Name == name {
+			return arg.Value, true
+		}
+	}
+	return nil, false
+}
+
+// callTypes maps the name of each CESS call submitted by the sdk to the struct of its arguments
+var callTypes = map[string]reflect.Type{
+	// Audit
+	ExtName_Audit_submit_idle_proof:            reflect.TypeOf(Call_SubmitIdleProof{}),
+	ExtName_Audit_submit_service_proof:         reflect.TypeOf(Call_SubmitServiceProof{}),
+	ExtName_Audit_submit_verify_idle_result:    reflect.TypeOf(Call_SubmitVerifyIdleResult{}),
+	ExtName_Audit_submit_verify_service_result: reflect.TypeOf(Call_SubmitVerifyServiceResult{}),
+
+	// Balances
+	ExtName_Balances_transferKeepAlive: reflect.TypeOf(Call_TransferKeepAlive{}),
+
+	// FileBank
+	ExtName_FileBank_upload_declaration:           reflect.TypeOf(Call_UploadDeclaration{}),
+	ExtName_FileBank_delete_file:                  reflect.TypeOf(Call_DeleteFile{}),
+	ExtName_FileBank_transfer_report:              reflect.TypeOf(Call_TransferReport{}),
+	ExtName_FileBank_generate_restoral_order:      reflect.TypeOf(Call_GenerateRestoralOrder{}),
+	ExtName_FileBank_claim_restoral_order:         reflect.TypeOf(Call_ClaimRestoralOrder{}),
+	ExtName_FileBank_claim_restoral_noexist_order: reflect.TypeOf(Call_ClaimRestoralNoExistOrder{}),
+	ExtName_FileBank_restoral_order_complete:      reflect.TypeOf(Call_RestoralOrderComplete{}),
+	ExtName_FileBank_cert_idle_space:              reflect.TypeOf(Call_CertIdleSpace{}),
+	ExtName_FileBank_replace_idle_space:           reflect.TypeOf(Call_ReplaceIdleSpace{}),
+	ExtName_FileBank_calculate_report:             reflect.TypeOf(Call_CalculateReport{}),
+	ExtName_FileBank_territory_file_delivery:      reflect.TypeOf(Call_TerritoryFileDelivery{}),
+
+	// Oss
+	ExtName_Oss_authorize:        reflect.TypeOf(Call_Authorize{}),
+	ExtName_Oss_cancel_authorize: reflect.TypeOf(Call_CancelAuthorize{}),
+	ExtName_Oss_register:         reflect.TypeOf(Call_OssRegister{}),
+	ExtName_Oss_update:           reflect.TypeOf(Call_OssUpdate{}),
+
+	// Sminer
+	ExtName_Sminer_regnstk:                    reflect.TypeOf(Call_Regnstk{}),
+	ExtName_Sminer_regnstk_assign_staking:     reflect.TypeOf(Call_RegnstkAssignStaking{}),
+	ExtName_Sminer_increase_collateral:        reflect.TypeOf(Call_IncreaseCollateral{}),
+	ExtName_Sminer_increase_declaration_space: reflect.TypeOf(Call_IncreaseDeclarationSpace{}),
+	ExtName_Sminer_miner_exit:                 reflect.TypeOf(Call_MinerExit{}),
+	ExtName_Sminer_update_beneficiary:         reflect.TypeOf(Call_UpdateBeneficiary{}),
+	ExtName_Sminer_update_endpoint:            reflect.TypeOf(Call_UpdateEndpoint{}),
+	ExtName_Sminer_register_pois_key:          reflect.TypeOf(Call_RegisterPoisKey{}),
+
+	// StorageHandler
+	ExtName_StorageHandler_mint_territory:         reflect.TypeOf(Call_MintTerritory{}),
+	ExtName_StorageHandler_expanding_territory:    reflect.TypeOf(Call_ExpandingTerritory{}),
+	ExtName_StorageHandler_renewal_territory:      reflect.TypeOf(Call_RenewalTerritory{}),
+	ExtName_StorageHandler_reactivate_territory:   reflect.TypeOf(Call_ReactivateTerritory{}),
+	ExtName_StorageHandler_territory_consignment:  reflect.TypeOf(Call_TerritoryConsignment{}),
+	ExtName_StorageHandler_cancel_consignment:     reflect.TypeOf(Call_CancelConsignment{}),
+	ExtName_StorageHandler_buy_consignment:        reflect.TypeOf(Call_BuyConsignment{}),
+	ExtName_StorageHandler_cancel_purchase_action: reflect.TypeOf(Call_CancelPurchaseAction{}),
+}
+
+// maxDecodeLen is the largest sequence accepted when decoding a call
+const maxDecodeLen = 1 << 24
+
+// DecodeCall decodes the arguments of the call with the metadata
+//   - meta: runtime metadata the call was built with
+//   - call: the call, e.g. the Method of an extrinsic
+//
+// Return:
+//   - *DecodedCall: the decoded call
+//   - error: error message
+func DecodeCall(meta *types.Metadata, call types.Call) (*DecodedCall, error) {
+	d, err := newCallDecoder(meta)
+	if err != nil {
+		return nil, err
+	}
+	return d.decode(call)
+}
+
+type callVariant struct {
+	name   string
+	fields []types.Si1Field
+}
+
+// callDecoder decodes calls with the types of the metadata
+type callDecoder struct {
+	lookup map[int64]*types.Si1Type
+	calls  map[types.CallIndex]callVariant
+	// types of the enum of all calls of the runtime
+	runtimeCalls map[int64]bool
+	// spec version of the runtime of the metadata
+	spec uint32
+}
+
+func newCallDecoder(meta *types.Metadata) (*callDecoder, error) {
+	if meta == nil {
+		return nil, errors.New("metadata is nil")
+	}
+	d := &callDecoder{
+		lookup:       meta.AsMetadataV14.EfficientLookup,
+		calls:        make(map[types.CallIndex]callVariant),
+		runtimeCalls: make(map[int64]bool),
+	}
+	palletCalls := make(map[int64]bool)
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasCalls {
+			continue
+		}
+		calls, ok := d.lookup[pallet.Calls.Type.Int64()]
+		if !ok || !calls.Def.IsVariant {
+			return nil, fmt.Errorf("calls type of %s not found", pallet.Name)
+		}
+		palletCalls[pallet.Calls.Type.Int64()] = true
+		for _, v := range calls.Def.Variant.Variants {
+			d.calls[types.CallIndex{SectionIndex: uint8(pallet.Index), MethodIndex: uint8(v.Index)}] = callVariant{
+				name:   fmt.Sprintf("%s.%s", pallet.Name, v.Name),
+				fields: v.Fields,
+			}
+		}
+	}
+	// the runtime call enum has a variant per pallet holding the calls of the pallet
+	for id, typ := range d.lookup {
+		if !typ.Def.IsVariant || len(typ.Def.Variant.Variants) == 0 {
+			continue
+		}
+		runtimeCall := true
+		for _, v := range typ.Def.Variant.Variants {
+			if len(v.Fields) != 1 || !palletCalls[v.Fields[0].Type.Int64()] {
+				runtimeCall = false
+				break
+			}
+		}
+		if runtimeCall {
+			d.runtimeCalls[id] = true
+		}
+	}
+	return d, nil
+}
+
+// callName returns the name of the call, e.g. FileBank.upload_declaration
+func (d *callDecoder) callName(index types.CallIndex) (string, bool) {
+	v, ok := d.calls[index]
+	return v.name, ok
+}
+
+// extrinsicName returns the name of the call, the extrinsic names are used if the
+// decoder is of the current runtime, otherwise they may name another call of the index
+func (d *callDecoder) extrinsicName(index types.CallIndex, currentSpec uint32) (string, bool) {
+	if d.spec == currentSpec {
+		if name, ok := LookupExtrinsicName(index); ok {
+			return name, true
+		}
+	}
+	return d.callName(index)
+}
+
+// callDecoderAt returns the call decoder of the runtime of the block, the metadata
+// at the block is loaded when its spec version differs from the current runtime.
+// The decoders are kept by spec version.
+func (c *ChainClient) callDecoderAt(blockhash types.Hash) (*callDecoder, error) {
+	version, err := c.api.RPC.State.GetRuntimeVersion(blockhash)
+	if err != nil {
+		return nil, errors.Wrap(err, "[GetRuntimeVersion]")
+	}
+	spec := uint32(version.SpecVersion)
+	if d, ok := c.callDecoders.Load(spec); ok {
+		return d.(*callDecoder), nil
+	}
+	rt := c.runtime.Load()
+	meta := rt.metadata
+	if spec != uint32(rt.version.SpecVersion) {
+		if meta, err = c.api.RPC.State.GetMetadata(blockhash); err != nil {
+			return nil, errors.Wrap(err, "[GetMetadata]")
+		}
+	}
+	d, err := newCallDecoder(meta)
+	if err != nil {
+		return nil, err
+	}
+	d.spec = spec
+	c.callDecoders.Store(spec, d)
+	return d, nil
+}
+
+func (d *callDecoder) decode(call types.Call) (*DecodedCall, error) {
+	data := append([]byte{call.CallIndex.SectionIndex, call.CallIndex.MethodIndex}, call.Args...)
+	r := bytes.NewReader(data)
+	decoded, err := d.decodeCall(scale.NewDecoder(r))
+	if err != nil {
+		return nil, err
+	}
+	if r.Len() > 0 {
+		return nil, fmt.Errorf("decode call %s: %d bytes left", decoded.Name, r.Len())
+	}
+	return decoded, nil
+}
+
+func (d *callDecoder) decodeCall(decoder *scale.Decoder) (*DecodedCall, error) {
+	var index types.CallIndex
+	var err error
+	if index.SectionIndex, err = decoder.ReadOneByte(); err != nil {
+		return nil, err
+	}
+	if index.MethodIndex, err = decoder.ReadOneByte(); err != nil {
+		return nil, err
+	}
+	variant, ok := d.calls[index]
+	if !ok {
+		return nil, fmt.Errorf("call %d.%d not found in metadata", index.SectionIndex, index.MethodIndex)
+	}
+
+	call := &DecodedCall{Name: variant.name, Args: make([]CallArg, len(variant.fields))}
+	for k, field := range variant.fields {
+		value, err := d.decodeValue(decoder, field.Type.Int64())
+		if err != nil {
+			return nil, fmt.Errorf("decode call %s: argument %s: %v", variant.name, field.Name, err)
+		}
+		call.Args[k] = CallArg{Name: string(field.Name), Type: string(field.TypeName), Value: value}
+		call.Calls = appendNestedCalls(call.Calls, value)
+	}
+	if typ, ok := callTypes[call.Name]; ok {
+		call.Typed = typedCall(typ, call.Args)
+	}
+	return call, nil
+}
+
+// typedCall assigns the arguments to a new struct of the type, nil if they do not match
+func typedCall(typ reflect.Type, args []CallArg) any {
+	if typ.NumField() != len(args) {
+		return nil
+	}
+	v := reflect.New(typ)
+	for i, arg := range args {
+		if err := assignDecoded(v.Elem().Field(i), arg.Value); err != nil {
+			return nil
+		}
+	}
+	return v.Interface()
+}
+
+// appendNestedCalls appends the calls found in the value
+func appendNestedCalls(calls []*DecodedCall, value any) []*DecodedCall {
+	switch v := value.(type) {
+	case *DecodedCall:
+		return append(calls, v)
+	case []any:
+		for _, item := range v {
+			calls = appendNestedCalls(calls, item)
+		}
+	case registry.DecodedFields:
+		for _, field := range v {
+			calls = appendNestedCalls(calls, field.Value)
+		}
+	case *CallVariant:
+		for _, field := range v.Fields {
+			calls = appendNestedCalls(calls, field.Value)
+		}
+	}
+	return calls
+}
+
+func (d *callDecoder) decodeValue(decoder *scale.Decoder, id int64) (any, error) {
+	if d.runtimeCalls[id] {
+		return d.decodeCall(decoder)
+	}
+	typ, ok := d.lookup[id]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found", id)
+	}
+	def := typ.Def
+	switch {
+	case def.IsPrimitive:
+		return decodePrimitive(decoder, def.Primitive.Si0TypeDefPrimitive)
+	case def.IsCompact:
+		n, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		return types.NewUCompact(n), nil
+	case def.IsSequence:
+		n, err := decoder.DecodeUintCompact()
+		if err != nil {
+			return nil, err
+		}
+		if !n.IsUint64() || n.Uint64() > maxDecodeLen {
+			return nil, fmt.Errorf("sequence of %v items is too long", n)
+		}
+		return d.decodeItems(decoder, def.Sequence.Type.Int64(), int(n.Uint64()))
+	case def.IsArray:
+		return d.decodeItems(decoder, def.Array.Type.Int64(), int(def.Array.Len))
+	case def.IsTuple:
+		items := make([]any, len(def.Tuple))
+		for i, item := range def.Tuple {
+			value, err := d.decodeValue(decoder, item.Int64())
+			if err != nil {
+				return nil, err
+			}
+			items[i] = value
+		}
+		return items, nil
+	case def.IsComposite:
+		fields, err := d.decodeFields(decoder, def.Composite.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) == 1 && !def.Composite.Fields[0].HasName {
+			return fields[0].Value, nil
+		}
+		return fields, nil
+	case def.IsVariant:
+		index, err := decoder.ReadOneByte()
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range def.Variant.Variants {
+			if uint8(v.Index) != index {
+				continue
+			}
+			fields, err := d.decodeFields(decoder, v.Fields)
+			if err != nil {
+				return nil, err
+			}
+			if len(typ.Path) > 0 && typ.Path[len(typ.Path)-1] == "Option" {
+				if len(fields) == 0 {
+					return nil, nil
+				}
+				return fields[0].Value, nil
+			}
+			return &CallVariant{Name: string(v.Name), Index: index, Fields: fields}, nil
+		}
+		return nil, fmt.Errorf("variant %d of type %d not found", index, id)
+	}
+	return nil, fmt.Errorf("type %d is not supported", id)
+}
+
+func (d *callDecoder) decodeFields(decoder *scale.Decoder, fields []types.Si1Field) (registry.DecodedFields, error) {
+	decoded := make(registry.DecodedFields, len(fields))
+	for k, field := range fields {
+		value, err := d.decodeValue(decoder, field.Type.Int64())
+		if err != nil {
+			return nil, err
+		}
+		decoded[k] = &registry.DecodedField{Name: string(field.Name), Value: value, LookupIndex: field.Type.Int64()}
+	}
+	return decoded, nil
+}
+
+// decodeItems decodes the items of a sequence or an array, bytes are returned as types.Bytes
+func (d *callDecoder) decodeItems(decoder *scale.Decoder, id int64, n int) (any, error) {
+	if item, ok := d.lookup[id]; ok && item.Def.IsPrimitive && item.Def.Primitive.Si0TypeDefPrimitive == types.IsU8 {
+		data := make([]byte, n)
+		if err := decoder.Read(data); err != nil {
+			return nil, err
+		}
+		return types.NewBytes(data), nil
+	}
+	items := make([]any, n)
+	for i := range items {
+		value, err := d.decodeValue(decoder, id)
+		if err != nil {
+			return nil, err
+		}
+		items[i] = value
+	}
+	return items, nil
+}
+
+func decodePrimitive(decoder *scale.Decoder, p types.Si0TypeDefPrimitive) (any, error) {
+	var target any
+	switch p {
+	case types.IsBool:
+		target = new(bool)
+	case types.IsChar:
+		target = new(byte)
+	case types.IsStr:
+		target = new(string)
+	case types.IsU8:
+		target = new(types.U8)
+	case types.IsU16:
+		target = new(types.U16)
+	case types.IsU32:
+		target = new(types.U32)
+	case types.IsU64:
+		target = new(types.U64)
+	case types.IsU128:
+		target = new(types.U128)
+	case types.IsU256:
+		target = new(types.U256)
+	case types.IsI8:
+		target = new(types.I8)
+	case types.IsI16:
+		target = new(types.I16)
+	case types.IsI32:
+		target = new(types.I32)
+	case types.IsI64:
+		target = new(types.I64)
+	case types.IsI128:
+		target = new(types.I128)
+	case types.IsI256:
+		target = new(types.I256)
+	default:
+		return nil, fmt.Errorf("primitive type %d is not supported", p)
+	}
+	if err := decoder.Decode(target); err != nil {
+		return nil, err
+	}
+	return reflect.ValueOf(target).Elem().Interface(), nil
+}
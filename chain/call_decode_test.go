@@ -0,0 +1,204 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCallMetadata builds a runtime with the pallets Utility (1), Balances (5) and StorageHandler (9)
+//   - 0: u8
+//   - 1: u32
+//   - 2: Vec<u8>
+//   - 3: BoundedVec<u8>
+//   - 4: [u8; 32]
+//   - 5: AccountId32
+//   - 6: Compact<u128>
+//   - 7: u128
+//   - 8: MultiAddress
+//   - 9: Balances calls
+//   - 10: StorageHandler calls
+//   - 11: Vec<RuntimeCall>
+//   - 12: RuntimeCall
+//   - 13: Utility calls
+func testCallMetadata() *types.Metadata {
+	id := types.NewSi1LookupTypeIDFromUInt
+	field := func(name string, typ uint64) types.Si1Field {
+		return types.Si1Field{HasName: name != "", Name: types.Text(name), Type: id(typ)}
+	}
+	variant := func(name string, index uint8, fields ...types.Si1Field) types.Si1Variant {
+		return types.Si1Variant{Name: types.Text(name), Index: types.U8(index), Fields: fields}
+	}
+	variants := func(v ...types.Si1Variant) types.Si1TypeDef {
+		return types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: v}}
+	}
+	composite := func(fields ...types.Si1Field) types.Si1TypeDef {
+		return types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: fields}}
+	}
+	sequence := func(typ uint64) types.Si1TypeDef {
+		return types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: id(typ)}}
+	}
+	primitive := func(p types.Si0TypeDefPrimitive) types.Si1TypeDef {
+		return types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}
+	}
+	lookup := map[int64]*types.Si1Type{
+		0:  {Def: primitive(types.IsU8)},
+		1:  {Def: primitive(types.IsU32)},
+		2:  {Def: sequence(0)},
+		3:  {Def: composite(field("", 2))},
+		4:  {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 32, Type: id(0)}}},
+		5:  {Def: composite(field("", 4))},
+		6:  {Def: types.Si1TypeDef{IsCompact: true, Compact: types.Si1TypeDefCompact{Type: id(7)}}},
+		7:  {Def: primitive(types.IsU128)},
+		8:  {Def: variants(variant("Id", 0, field("", 5)), variant("Index", 1, field("", 6)))},
+		9:  {Def: variants(variant("transfer_keep_alive", 3, field("dest", 8), field("value", 6)))},
+		10: {Def: variants(variant("mint_territory", 0, field("gib_count", 1), field("territory_name", 3), field("days", 1)))},
+		11: {Def: sequence(12)},
+		12: {Def: variants(variant("Utility", 1, field("", 13)), variant("Balances", 5, field("", 9)), variant("StorageHandler", 9, field("", 10)))},
+		13: {Def: variants(variant("batch", 0, field("calls", 11)))},
+	}
+	pallet := func(name string, index uint8, calls uint64) types.PalletMetadataV14 {
+		return types.PalletMetadataV14{Name: types.Text(name), Index: types.U8(index), HasCalls: true, Calls: types.FunctionMetadataV14{Type: id(calls)}}
+	}
+	return &types.Metadata{AsMetadataV14: types.MetadataV14{
+		Pallets:         []types.PalletMetadataV14{pallet("Utility", 1, 13), pallet(Balances, 5, 9), pallet(StorageHandler, 9, 10)},
+		EfficientLookup: lookup,
+	}}
+}
+
+func TestDecodeCall(t *testing.T) {
+	meta := testCallMetadata()
+	acc := bytes.Repeat([]byte{7}, 32)
+	value, err := codec.Encode(types.NewUCompactFromUInt(1000))
+	require.NoError(t, err)
+
+	transfer := append(append([]byte{0}, acc...), value...)
+	mint := []byte{10, 0, 0, 0, 3 << 2, 'a', 'b', 'c', 30, 0, 0, 0}
+	batch := append([]byte{2 << 2, 5, 3}, transfer...)
+	batch = append(append(batch, 9, 0), mint...)
+
+	call, err := DecodeCall(meta, types.Call{CallIndex: types.CallIndex{SectionIndex: 1, MethodIndex: 0}, Args: batch})
+	require.NoError(t, err)
+	assert.Equal(t, ExtName_Utility_batch, call.Name)
+	assert.Nil(t, call.Typed)
+	require.Len(t, call.Calls, 2)
+
+	transferCall := call.Calls[0]
+	assert.Equal(t, ExtName_Balances_transferKeepAlive, transferCall.Name)
+	dest, ok := transferCall.Arg("dest")
+	require.True(t, ok)
+	variant, ok := dest.(*CallVariant)
+	require.True(t, ok)
+	assert.Equal(t, "Id", variant.Name)
+	assert.Equal(t, types.NewBytes(acc), variant.Fields[0].Value)
+	typedTransfer, ok := transferCall.Typed.(*Call_TransferKeepAlive)
+	require.True(t, ok)
+	assert.Equal(t, acc, typedTransfer.Dest.ToBytes())
+	assert.Equal(t, types.NewUCompactFromUInt(1000), typedTransfer.Value)
+
+	mintCall := call.Calls[1]
+	assert.Equal(t, ExtName_StorageHandler_mint_territory, mintCall.Name)
+	name, ok := mintCall.Arg("territory_name")
+	require.True(t, ok)
+	assert.Equal(t, types.NewBytes([]byte("abc")), name)
+	_, ok = mintCall.Arg("unknown")
+	assert.False(t, ok)
+	assert.Equal(t, &Call_MintTerritory{GibCount: 10, TerritoryName: types.NewBytes([]byte("abc")), Days: 30}, mintCall.Typed)
+
+	// an unknown call or left bytes
+	_, err = DecodeCall(meta, types.Call{CallIndex: types.CallIndex{SectionIndex: 2, MethodIndex: 0}})
+	assert.Error(t, err)
+	_, err = DecodeCall(meta, types.Call{CallIndex: types.CallIndex{SectionIndex: 9, MethodIndex: 0}, Args: append(mint, 0)})
+	assert.Error(t, err)
+	_, err = DecodeCall(meta, types.Call{CallIndex: types.CallIndex{SectionIndex: 9, MethodIndex: 0}, Args: mint[:6]})
+	assert.Error(t, err)
+}
+
+// fakeRuntimeService serves the runtime version of the blocks, the metadata of past runtimes is pruned
+type fakeRuntimeService struct {
+	spec          atomic.Uint32
+	metadataCalls atomic.Int32
+}
+
+func (f *fakeRuntimeService) GetRuntimeVersion(blockhash string) map[string]interface{} {
+	return map[string]interface{}{"specName": "cess-node", "specVersion": f.spec.Load(), "transactionVersion": 1, "apis": []interface{}{}}
+}
+
+func (f *fakeRuntimeService) GetMetadata(blockhash string) (string, error) {
+	f.metadataCalls.Add(1)
+	return "", errors.New("state already discarded")
+}
+
+func TestCallDecoderAt(t *testing.T) {
+	node := newFakeNode(t, 1, 100)
+	state := &fakeRuntimeService{}
+	state.spec.Store(100)
+	require.NoError(t, node.rpc.RegisterName("state", state))
+
+	st := &clientState{pool: newEndpointPool([]string{node.url}, time.Second, 3), callDecoders: new(sync.Map)}
+	defer st.pool.close()
+	st.pool.checkAll(context.Background())
+	st.runtime.Store(&runtimeState{metadata: testCallMetadata(), version: &types.RuntimeVersion{SpecVersion: 100}})
+	c := &ChainClient{clientState: st, api: newSubstrateAPI(context.Background(), st)}
+
+	// a block of the current runtime is decoded with the current metadata
+	d, err := c.callDecoderAt(types.NewHash([]byte{1}))
+	require.NoError(t, err)
+	assert.Contains(t, d.calls, types.CallIndex{SectionIndex: 5, MethodIndex: 3})
+	cached, err := c.callDecoderAt(types.NewHash([]byte{2}))
+	require.NoError(t, err)
+	assert.Same(t, d, cached)
+	assert.Equal(t, uint32(100), d.spec)
+	assert.Equal(t, int32(0), state.metadataCalls.Load())
+
+	// a block of another runtime needs the metadata at the block
+	state.spec.Store(99)
+	_, err = c.callDecoderAt(types.NewHash([]byte{3}))
+	assert.ErrorContains(t, err, "[GetMetadata]")
+	assert.Equal(t, int32(1), state.metadataCalls.Load())
+}
+
+func TestExtrinsicName(t *testing.T) {
+	index := types.CallIndex{SectionIndex: 5, MethodIndex: 3}
+	extrinsicsNameLock.Lock()
+	names := ExtrinsicsName
+	// the current runtime has another call at the index
+	ExtrinsicsName = map[types.CallIndex]string{index: ExtName_Oss_authorize}
+	extrinsicsNameLock.Unlock()
+	defer func() {
+		extrinsicsNameLock.Lock()
+		ExtrinsicsName = names
+		extrinsicsNameLock.Unlock()
+	}()
+
+	d, err := newCallDecoder(testCallMetadata())
+	require.NoError(t, err)
+	d.spec = 99
+
+	name, ok := d.extrinsicName(index, 99)
+	assert.True(t, ok)
+	assert.Equal(t, ExtName_Oss_authorize, name)
+	// a block of an older runtime is named after its own metadata
+	name, ok = d.extrinsicName(index, 100)
+	assert.True(t, ok)
+	assert.Equal(t, "Balances.transfer_keep_alive", name)
+
+	_, ok = d.extrinsicName(types.CallIndex{SectionIndex: 9, MethodIndex: 9}, 100)
+	assert.False(t, ok)
+}
@@ -0,0 +1,211 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+)
+
+// ******************************************************
+// arguments of the calls submitted by the sdk
+// ******************************************************
+
+// ------------------------Audit-------------------------
+type Call_SubmitIdleProof struct {
+	IdleProve []types.U8
+}
+
+type Call_SubmitServiceProof struct {
+	ServiceProve []types.U8
+}
+
+type Call_SubmitVerifyIdleResult struct {
+	TotalProveHash []types.U8
+	Front          types.U64
+	Rear           types.U64
+	Accumulator    Accumulator
+	IdleResult     types.Bool
+	Signature      types.Bytes
+	TeePuk         WorkerPublicKey
+}
+
+type Call_SubmitVerifyServiceResult struct {
+	ServiceResult      types.Bool
+	Signature          types.Bytes
+	ServiceBloomFilter BloomFilter
+	TeePuk             WorkerPublicKey
+}
+
+// ------------------------Balances----------------------
+type Call_TransferKeepAlive struct {
+	Dest  types.AccountID
+	Value types.UCompact
+}
+
+// ------------------------FileBank----------------------
+type Call_UploadDeclaration struct {
+	FileHash  FileHash
+	DealInfo  []SegmentList
+	UserBrief UserBrief
+	FileSize  types.U128
+}
+
+type Call_DeleteFile struct {
+	Owner    types.AccountID
+	FileHash FileHash
+}
+
+type Call_TransferReport struct {
+	Index    types.U8
+	DealHash FileHash
+}
+
+type Call_GenerateRestoralOrder struct {
+	FileHash         FileHash
+	RestoralFragment FileHash
+}
+
+type Call_ClaimRestoralOrder struct {
+	RestoralFragment FileHash
+}
+
+type Call_ClaimRestoralNoExistOrder struct {
+	Miner            types.AccountID
+	FileHash         FileHash
+	RestoralFragment FileHash
+}
+
+type Call_RestoralOrderComplete struct {
+	FragmentHash FileHash
+}
+
+type Call_CertIdleSpace struct {
+	IdleSignInfo   SpaceProofInfo
+	TeeSignWithAcc types.Bytes
+	TeeSign        types.Bytes
+	TeePuk         WorkerPublicKey
+}
+
+type Call_ReplaceIdleSpace struct {
+	IdleSignInfo   SpaceProofInfo
+	TeeSignWithAcc types.Bytes
+	TeeSign        types.Bytes
+	TeePuk         WorkerPublicKey
+}
+
+type Call_CalculateReport struct {
+	TeeSig     types.Bytes
+	TagSigInfo TagSigInfo
+}
+
+type Call_TerritoryFileDelivery struct {
+	User            types.AccountID
+	FileHash        types.Bytes
+	TargetTerritory types.Bytes
+}
+
+// ------------------------Oss---------------------------
+type Call_Authorize struct {
+	Operator types.AccountID
+}
+
+type Call_CancelAuthorize struct {
+	Oss types.AccountID
+}
+
+type Call_OssRegister struct {
+	Endpoint PeerId
+	Domain   types.Bytes
+}
+
+type Call_OssUpdate struct {
+	Endpoint PeerId
+	Domain   types.Bytes
+}
+
+// ------------------------Sminer------------------------
+type Call_Regnstk struct {
+	Beneficiary types.AccountID
+	Endpoint    types.Bytes
+	Staking     types.U128
+	TibCount    types.U32
+}
+
+type Call_RegnstkAssignStaking struct {
+	Beneficiary types.AccountID
+	Endpoint    types.Bytes
+	StakingAcc  types.AccountID
+	TibCount    types.U32
+}
+
+type Call_IncreaseCollateral struct {
+	Miner       types.AccountID
+	Collaterals types.UCompact
+}
+
+type Call_IncreaseDeclarationSpace struct {
+	TibCount types.U32
+}
+
+type Call_MinerExit struct {
+	Miner types.AccountID
+}
+
+type Call_UpdateBeneficiary struct {
+	Beneficiary types.AccountID
+}
+
+type Call_UpdateEndpoint struct {
+	Endpoint types.Bytes
+}
+
+type Call_RegisterPoisKey struct {
+	PoisKey        PoISKeyInfo
+	TeeSignWithAcc types.Bytes
+	TeeSign        types.Bytes
+	TeePuk         WorkerPublicKey
+}
+
+// ------------------------StorageHandler----------------
+type Call_MintTerritory struct {
+	GibCount      types.U32
+	TerritoryName types.Bytes
+	Days          types.U32
+}
+
+type Call_ExpandingTerritory struct {
+	TerritoryName types.Bytes
+	GibCount      types.U32
+}
+
+type Call_RenewalTerritory struct {
+	TerritoryName types.Bytes
+	DaysCount     types.U32
+}
+
+type Call_ReactivateTerritory struct {
+	TerritoryName types.Bytes
+	DaysCount     types.U32
+}
+
+type Call_TerritoryConsignment struct {
+	TerritoryName types.Bytes
+}
+
+type Call_CancelConsignment struct {
+	TerritoryName types.Bytes
+}
+
+type Call_BuyConsignment struct {
+	Token         types.H256
+	TerritoryName types.Bytes
+}
+
+type Call_CancelPurchaseAction struct {
+	Token types.H256
+}
@@ -17,144 +17,155 @@ import (
 	"math/big"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	gsrpc "github.com/AstaFrode/go-substrate-rpc-client/v4"
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/retriever"
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/state"
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/signature"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/author"
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/xxhash"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 	"github.com/CESSProject/cess-go-sdk/utils"
 )
 
+// ChainClient is the client to interact with the cess chain.
+// All clients derived from the same connection with WithContext share
+// its rpc connection, metadata and account state.
 type ChainClient struct {
+	*clientState
+	ctx context.Context
+	api *gsrpc.SubstrateAPI
+	// block the queries read at instead of the latest block, see At
+	at *types.Hash
+}
+
+// clientState is the connection and account state shared by a chain client
+// and every client derived from it
+type clientState struct {
 	chainLock      *sync.Mutex
 	chainStLock    *sync.Mutex
-	api            *gsrpc.SubstrateAPI
-	metadata       *types.Metadata
-	runtimeVersion *types.RuntimeVersion
-	eventRetriever retriever.EventRetriever
-	genesisHash    types.Hash
-	keyring        signature.KeyringPair
-	rpcAddr        []string
-	currentRpcAddr string
-	tokenSymbol    string
-	networkEnv     string
-	signatureAcc   string
-	name           string
-	balance        uint64
-	packingTime    time.Duration
-	tradeCh        chan bool
-	rpcState       bool
+	baseCtx        context.Context
+	pool           *endpointPool
+	healthOnce     *sync.Once
+	healthInterval time.Duration
+	maxBlockLag    uint32
+	runtime        atomic.Pointer[runtimeState]
+	runtimeLock    *sync.Mutex
+	listeners      *runtimeListeners
+	// rebuilds the transaction names after a runtime upgrade
+	extrinsicsNameInit func(*ChainClient) error
+	genesisHash        types.Hash
+	signer             signer.Signer
+	accountID          []byte
+	keystore           *signer.Keystore
+	rpcAddr            []string
+	tokenSymbol        string
+	networkEnv         string
+	signatureAcc       string
+	name               string
+	balance            uint64
+	packingTime        time.Duration
+	tip                *big.Int
+	txLifetime         uint32
+	nonces             *nonceManager
+	// call decoders by spec version of the runtime
+	callDecoders *sync.Map
 }
 
 var _ Chainer = (*ChainClient)(nil)
 
-// NewChainClientUnconnectedRpc creates a chainclient unconnected rpc
-//   - ctx: context
-//   - name: customised name, can be empty
-//   - rpcs: rpc addresses
-//   - mnemonic: account mnemonic, can be empty
-//   - t: waiting time for transaction packing, default is 30 seconds
-//
-// Return:
-//   - *ChainClient: chain client
-//   - error: error message
-func NewChainClientUnconnectedRpc(ctx context.Context, name string, rpcs []string, mnemonic string, t time.Duration) (Chainer, error) {
+func newChainClient(ctx context.Context, name string, rpcs []string, mnemonic string, t time.Duration, opts []ClientOption) (*ChainClient, error) {
 	var err error
-	var chainClient = &ChainClient{
-		chainLock:   new(sync.Mutex),
-		chainStLock: new(sync.Mutex),
-		tradeCh:     make(chan bool, 1),
-		rpcAddr:     rpcs,
-		packingTime: t,
-		name:        name,
-	}
-	chainClient.tradeCh <- true
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	st := &clientState{
+		chainLock:      new(sync.Mutex),
+		chainStLock:    new(sync.Mutex),
+		runtimeLock:    new(sync.Mutex),
+		listeners:      newRuntimeListeners(),
+		baseCtx:        ctx,
+		healthOnce:     new(sync.Once),
+		healthInterval: DefaultHealthCheckInterval,
+		maxBlockLag:    DefaultMaxBlockLag,
+		nonces:         newNonceManager(),
+		callDecoders:   new(sync.Map),
+		rpcAddr:        rpcs,
+		packingTime:    t,
+		txLifetime:     lifetimeOf(t),
+		name:           name,
+	}
+	chainClient := &ChainClient{
+		clientState: st,
+		ctx:         ctx,
+		api:         newSubstrateAPI(ctx, st),
+	}
 	if mnemonic != "" {
-		chainClient.keyring, err = signature.KeyringPairFromSecret(mnemonic, 0)
+		s, err := signer.NewKeyringSigner(signer.Sr25519, mnemonic)
 		if err != nil {
 			return nil, err
 		}
-		chainClient.signatureAcc, err = utils.EncodePublicKeyAsCessAccount(chainClient.keyring.PublicKey)
-		if err != nil {
+		if err = chainClient.setSigner(s); err != nil {
 			return nil, err
 		}
 	}
-	chainClient.SetRpcState(false)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err = opt(chainClient); err != nil {
+			return nil, err
+		}
+	}
+	st.pool = newEndpointPool(rpcs, st.healthInterval, st.maxBlockLag)
+	st.pool.onSwitch = st.nonces.resync
 	return chainClient, nil
 }
 
-// NewChainClient creates a chainclient
-//   - ctx: context
+// NewChainClientUnconnectedRpc creates a chainclient unconnected rpc
+//   - ctx: context, used as the default context of all requests
 //   - name: customised name, can be empty
 //   - rpcs: rpc addresses
-//   - mnemonic: account mnemonic, can be empty
+//   - mnemonic: account mnemonic, can be empty, use WithSigner to sign without a mnemonic
 //   - t: waiting time for transaction packing, default is 30 seconds
+//   - opts: client options
 //
 // Return:
 //   - *ChainClient: chain client
 //   - error: error message
-func NewChainClient(ctx context.Context, name string, rpcs []string, mnemonic string, t time.Duration) (Chainer, error) {
-	var (
-		err         error
-		chainClient = &ChainClient{
-			chainLock:   new(sync.Mutex),
-			chainStLock: new(sync.Mutex),
-			tradeCh:     make(chan bool, 1),
-			rpcAddr:     rpcs,
-			packingTime: t,
-			name:        name,
-		}
-	)
-	chainClient.tradeCh <- true
+func NewChainClientUnconnectedRpc(ctx context.Context, name string, rpcs []string, mnemonic string, t time.Duration, opts ...ClientOption) (Chainer, error) {
+	return newChainClient(ctx, name, rpcs, mnemonic, t, opts)
+}
 
-	log.SetOutput(io.Discard)
-	for i := 0; i < len(rpcs); i++ {
-		chainClient.api, err = gsrpc.NewSubstrateAPI(rpcs[i])
-		if err == nil {
-			chainClient.currentRpcAddr = rpcs[i]
-			break
-		}
-	}
-	log.SetOutput(os.Stdout)
+// NewChainClient creates a chainclient
+//   - ctx: context, it bounds the connection process and is used as
+//     the default context of all requests
+//   - name: customised name, can be empty
+//   - rpcs: rpc addresses
+//   - mnemonic: account mnemonic, can be empty, use WithSigner to sign without a mnemonic
+//   - t: waiting time for transaction packing, default is 30 seconds
+//   - opts: client options
+//
+// Return:
+//   - *ChainClient: chain client
+//   - error: error message
+//
+// Note:
+//   - all rpc addresses are connected and checked periodically in the background,
+//     requests are routed to the healthy endpoint with the lowest latency
+func NewChainClient(ctx context.Context, name string, rpcs []string, mnemonic string, t time.Duration, opts ...ClientOption) (Chainer, error) {
+	chainClient, err := newChainClient(ctx, name, rpcs, mnemonic, t, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	if chainClient.api == nil {
-		return nil, ERR_RPC_CONNECTION
-	}
-
-	chainClient.SetRpcState(true)
-
-	chainClient.metadata, err = chainClient.api.RPC.State.GetMetadataLatest()
-	if err != nil {
+	if err = chainClient.connectRpc(); err != nil {
+		chainClient.Close()
 		return nil, err
 	}
-	chainClient.genesisHash, err = chainClient.api.RPC.Chain.GetBlockHash(0)
-	if err != nil {
-		return nil, err
-	}
-	chainClient.runtimeVersion, err = chainClient.api.RPC.State.GetRuntimeVersionLatest()
-	if err != nil {
-		return nil, err
-	}
-	chainClient.eventRetriever, err = retriever.NewDefaultEventRetriever(state.NewEventProvider(chainClient.api.RPC.State), chainClient.api.RPC.State)
-	if err != nil {
-		return nil, err
-	}
-	if mnemonic != "" {
-		chainClient.keyring, err = signature.KeyringPairFromSecret(mnemonic, 0)
-		if err != nil {
-			return nil, err
-		}
-		chainClient.signatureAcc, err = utils.EncodePublicKeyAsCessAccount(chainClient.keyring.PublicKey)
-		if err != nil {
-			return nil, err
-		}
-		accInfo, err := chainClient.QueryAccountInfoByAccountID(chainClient.keyring.PublicKey, -1)
+
+	if chainClient.signer != nil {
+		accInfo, err := chainClient.QueryAccountInfoByAccountID(chainClient.accountID, LatestBlock())
 		if err != nil {
 			if !errors.Is(err, ERR_RPC_EMPTY_VALUE) {
 				return nil, err
@@ -189,6 +200,67 @@ func NewChainClient(ctx context.Context, name string, rpcs []string, mnemonic st
 	return chainClient, nil
 }
 
+// WithContext returns a chain client that shares the connection and
+// account of c, but binds all its rpc requests, transaction waits and
+// reconnections to ctx.
+func (c *ChainClient) WithContext(ctx context.Context) Chainer {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return &ChainClient{
+		clientState: c.clientState,
+		ctx:         ctx,
+		api:         newSubstrateAPIAt(ctx, c.clientState, c.at),
+		at:          c.at,
+	}
+}
+
+// Context returns the context bound to the chain client
+func (c *ChainClient) Context() context.Context {
+	return c.ctx
+}
+
+// connectRpc checks all rpc endpoints, loads the chain information
+// needed for signing and decoding and starts the background health checks
+func (c *ChainClient) connectRpc() error {
+	log.SetOutput(io.Discard)
+	c.pool.checkAll(c.ctx)
+	log.SetOutput(os.Stdout)
+	if c.ctx.Err() != nil {
+		return c.ctx.Err()
+	}
+	if !c.pool.available() {
+		return ERR_RPC_CONNECTION
+	}
+
+	genesisHash, err := c.api.RPC.Chain.GetBlockHash(0)
+	if err != nil {
+		return err
+	}
+	runtimeVersion, err := c.api.RPC.State.GetRuntimeVersionLatest()
+	if err != nil {
+		return err
+	}
+	if err = c.reloadRuntime(*runtimeVersion); err != nil {
+		return err
+	}
+
+	c.genesisHash = genesisHash
+	c.pool.setGenesis(genesisHash)
+	c.nonces.resync()
+
+	c.healthOnce.Do(func() {
+		go c.pool.run(c.baseCtx)
+		go c.watchRuntime(c.baseCtx)
+	})
+	return nil
+}
+
+// GetRpcEndpointStatus get the health status of all configured rpc endpoints
+func (c *ChainClient) GetRpcEndpointStatus() []RpcEndpointStatus {
+	return c.pool.status()
+}
+
 // GetSDKName get sdk name
 func (c *ChainClient) GetSDKName() string {
 	return c.name
@@ -196,26 +268,29 @@ func (c *ChainClient) GetSDKName() string {
 
 // GetCurrentRpcAddr get the current rpc address being used
 func (c *ChainClient) GetCurrentRpcAddr() string {
-	return c.currentRpcAddr
+	return c.pool.currentAddr()
 }
 
 // SetChainState set the rpc connection status flag,
 // when the rpc connection is normal, set it to true,
 // otherwise set it to false.
+//
+// Note:
+//   - setting it to false takes the current endpoint out of rotation
+//     until its next successful health check
 func (c *ChainClient) SetRpcState(state bool) {
-	c.chainStLock.Lock()
-	c.rpcState = state
-	c.chainStLock.Unlock()
+	if state {
+		c.pool.electCurrent()
+		return
+	}
+	c.pool.suspend()
 }
 
 // GetRpcState get the rpc connection status flag
-//   - true: connection is normal
+//   - true: at least one healthy endpoint is available
 //   - false: connection failed
 func (c *ChainClient) GetRpcState() bool {
-	c.chainStLock.Lock()
-	st := c.rpcState
-	c.chainStLock.Unlock()
-	return st
+	return c.pool.available()
 }
 
 // GetSignatureAcc get your current account address
@@ -226,12 +301,54 @@ func (c *ChainClient) GetSignatureAcc() string {
 	return c.signatureAcc
 }
 
-// GetSignatureAccPulickey get your current account public key
+// GetSignatureAccPulickey get your current account id,
+// it is the public key of sr25519 and ed25519 accounts
 //
 // Note:
-//   - make sure you fill in mnemonic when you create the sdk client
+//   - make sure you fill in mnemonic or a signer when you create the sdk client
 func (c *ChainClient) GetSignatureAccPulickey() []byte {
-	return c.keyring.PublicKey
+	return c.accountID
+}
+
+// GetSigner get the signer of your current account, nil if there is none
+func (c *ChainClient) GetSigner() signer.Signer {
+	return c.signer
+}
+
+// GetKeystore get the keystore of the client, nil if there is none
+func (c *ChainClient) GetKeystore() *signer.Keystore {
+	return c.keystore
+}
+
+// txSigner returns the signer of a transaction, the account chosen
+// by the options or the signer of the client
+func (c *ChainClient) txSigner(o TxOptions) (signer.Signer, error) {
+	if o.signer != nil {
+		return o.signer, nil
+	}
+	if o.account != "" {
+		if c.keystore == nil {
+			return nil, errors.New("no keystore configured")
+		}
+		return c.keystore.Get(o.account)
+	}
+	if c.signer == nil {
+		return nil, ERR_NoSigner
+	}
+	return c.signer, nil
+}
+
+// setSigner sets the signer of the signature account
+func (c *ChainClient) setSigner(s signer.Signer) error {
+	accountID := signer.AccountID(s)
+	acc, err := utils.EncodePublicKeyAsCessAccount(accountID)
+	if err != nil {
+		return err
+	}
+	c.signer = s
+	c.accountID = accountID
+	c.signatureAcc = acc
+	return nil
 }
 
 // GetSubstrateAPI get substrate api
@@ -239,9 +356,13 @@ func (c *ChainClient) GetSubstrateAPI() *gsrpc.SubstrateAPI {
 	return c.api
 }
 
-// GetMetadata get chain metadata
+// GetMetadata get chain metadata, it is replaced after a runtime upgrade
 func (c *ChainClient) GetMetadata() *types.Metadata {
-	return c.metadata
+	rt := c.runtime.Load()
+	if rt == nil {
+		return nil
+	}
+	return rt.metadata
 }
 
 // GetTokenSymbol get token symbol
@@ -254,11 +375,6 @@ func (c *ChainClient) GetNetworkEnv() string {
 	return c.networkEnv
 }
 
-// GetURI get the mnemonic for your current account
-func (c *ChainClient) GetURI() string {
-	return c.keyring.URI
-}
-
 // GetBalances get current account balance, the unit is CESS
 func (c *ChainClient) GetBalances() uint64 {
 	return c.balance
@@ -269,209 +385,310 @@ func (c *ChainClient) SetBalances(balance uint64) {
 	c.balance = balance
 }
 
-// Sign with the mnemonic of your current account
+// Sign with the signer of your current account
 func (c *ChainClient) Sign(msg []byte) ([]byte, error) {
-	return signature.Sign(msg, c.keyring.URI)
+	if c.signer == nil {
+		return nil, ERR_NoSigner
+	}
+	return signer.SignContext(c.ctx, c.signer, msg)
 }
 
-// Verify the signature with your current account's mnemonic
+// Verify the signature with your current account's public key
 func (c *ChainClient) Verify(msg []byte, sig []byte) (bool, error) {
-	return signature.Verify(msg, sig, c.keyring.URI)
+	if c.signer == nil {
+		return false, ERR_NoSigner
+	}
+	return signer.Verify(c.signer.Scheme(), c.signer.PublicKey(), msg, sig)
 }
 
 // ReconnectRpc reconnect rpc
 func (c *ChainClient) ReconnectRpc() error {
-	var err error
 	c.chainLock.Lock()
 	defer c.chainLock.Unlock()
 	if c.GetRpcState() {
 		return nil
 	}
-	if _, err := c.api.RPC.Chain.GetHeaderLatest(); err != nil {
-		c.SetRpcState(true)
-		return nil
-	}
-	if c.api != nil {
-		if c.api.Client != nil {
-			c.api.Client.Close()
-			c.api.Client = nil
-		}
-		c.api = nil
-	}
-	c.api,
-		c.metadata,
-		c.runtimeVersion,
-		c.eventRetriever,
-		c.genesisHash,
-		c.currentRpcAddr, err = reconnectRpc(c.currentRpcAddr, c.rpcAddr)
-	if err != nil {
-		return err
-	}
-	c.SetRpcState(true)
-
-	// if c.keyring.URI != "" && len(c.keyring.PublicKey) > 0 {
-	// 	accInfo, err := c.QueryAccountInfoByAccountID(c.keyring.PublicKey, -1)
-	// 	if err != nil {
-	// 		if !errors.Is(err, ERR_RPC_EMPTY_VALUE) {
-	// 			return err
-	// 		}
-	// 		c.balance = 0
-	// 	} else {
-	// 		free_bi, _ := new(big.Int).SetString(accInfo.Data.Free.String(), 10)
-	// 		minBanlance_bi, _ := new(big.Int).SetString(MinTransactionBalance, 10)
-	// 		free_bi = free_bi.Div(free_bi, minBanlance_bi)
-	// 		if free_bi.IsUint64() {
-	// 			c.balance = free_bi.Uint64()
-	// 		} else {
-	// 			c.balance = math.MaxUint64
-	// 		}
-	// 	}
-	// }
+	return c.connectRpc()
+}
 
-	return nil
+func CreatePrefixedKey(pallet, method string) []byte {
+	return append(xxhash.New128([]byte(pallet)).Sum(nil), xxhash.New128([]byte(method)).Sum(nil)...)
 }
 
-func reconnectRpc(oldRpc string, rpcs []string) (
-	*gsrpc.SubstrateAPI,
-	*types.Metadata,
-	*types.RuntimeVersion,
-	retriever.EventRetriever,
-	types.Hash,
-	string,
-	error,
-) {
-	var err error
-	var rpcAddr string
-	var api *gsrpc.SubstrateAPI
-	var rpcaddrs = make([]string, 0)
-	utils.RandSlice(rpcs)
-	for i := 0; i < len(rpcs); i++ {
-		if rpcs[i] != oldRpc {
-			rpcaddrs = append(rpcaddrs, rpcs[i])
-		}
-	}
-	rpcaddrs = append(rpcaddrs, oldRpc)
-	length := len(rpcaddrs)
+// close chain client
+func (c *ChainClient) Close() {
+	c.pool.close()
+}
 
-	defer log.SetOutput(os.Stdout)
-	log.SetOutput(io.Discard)
-	for i := 0; i < length; i++ {
-		api, err = gsrpc.NewSubstrateAPI(rpcaddrs[i])
-		if err != nil {
-			continue
-		}
-		rpcAddr = rpcaddrs[i]
-		break
-	}
+// SubmitExtrinsic signs the call with the next nonce of the signature account,
+// or of the account chosen with WithAccount or WithTxSigner,
+// submits it and waits for it to be included in a block.
+// The transaction is mortal and anchored at the best block unless its
+// lifetime is 0, a mortal transaction is waited for until it expires.
+// Several transactions of the same account can be submitted concurrently,
+// if the node rejects the nonce the transaction is re-anchored and re-signed with a resynced nonce.
+//   - call: the call to submit
+//   - extrinsicName: name of the extrinsic
+//   - opts: transaction options
+//
+// Return:
+//   - TxReceipt: receipt of the transaction, filled as far as the transaction got
+//   - error: error message, the dispatch error if the extrinsic failed,
+//     a *TxStatusError if the transaction was not included or finalized
+func (c *ChainClient) SubmitExtrinsic(call types.Call, extrinsicName string, opts ...TxOption) (TxReceipt, error) {
+	var receipt = TxReceipt{ExtrinsicName: extrinsicName}
+	txOpts, err := newTxOptions(c.defaultTxOptions(), opts)
 	if err != nil {
-		return nil, nil, nil, nil, types.Hash{}, rpcAddr, ERR_RPC_CONNECTION
+		return receipt, err
 	}
-	var metadata *types.Metadata
-	var runtimeVer *types.RuntimeVersion
-	var genesisHash types.Hash
-	var eventRetriever retriever.EventRetriever
-
-	metadata, err = api.RPC.State.GetMetadataLatest()
-	if err != nil {
-		return nil, nil, nil, nil, types.Hash{}, rpcAddr, ERR_RPC_CONNECTION
+	if call, err = c.txCall(call, txOpts); err != nil {
+		return receipt, err
 	}
-	genesisHash, err = api.RPC.Chain.GetBlockHash(0)
-	if err != nil {
-		return nil, nil, nil, nil, types.Hash{}, rpcAddr, ERR_RPC_CONNECTION
+	if txOpts.callTo != nil {
+		*txOpts.callTo = call
+		return receipt, nil
 	}
-	runtimeVer, err = api.RPC.State.GetRuntimeVersionLatest()
-	if err != nil {
-		return nil, nil, nil, nil, types.Hash{}, rpcAddr, ERR_RPC_CONNECTION
+	if c.at != nil {
+		return receipt, ERR_PinnedClient
 	}
-	eventRetriever, err = retriever.NewDefaultEventRetriever(state.NewEventProvider(api.RPC.State), api.RPC.State)
+	if txOpts.exportTo != nil {
+		*txOpts.exportTo, err = c.buildUnsignedTx(txOpts.exportAccount, call, extrinsicName, txOpts)
+		return receipt, err
+	}
+	txSigner, err := c.txSigner(txOpts)
 	if err != nil {
-		return nil, nil, nil, nil, types.Hash{}, rpcAddr, ERR_RPC_CONNECTION
+		return receipt, err
 	}
-	return api, metadata, runtimeVer, eventRetriever, genesisHash, rpcAddr, nil
-}
-
-func CreatePrefixedKey(pallet, method string) []byte {
-	return append(xxhash.New128([]byte(pallet)).Sum(nil), xxhash.New128([]byte(method)).Sum(nil)...)
-}
-
-// close chain client
-func (c *ChainClient) Close() {
-	if c.api != nil {
-		if c.api.Client != nil {
-			c.api.Client.Close()
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return receipt, ERR_RPC_CONNECTION
 		}
-		c.api = nil
 	}
-}
-
-func (c *ChainClient) SubmitExtrinsic(call types.Call, extrinsicName string) (string, error) {
-	ext := types.NewExtrinsic(call)
 
-	key, err := types.CreateStorageKey(c.metadata, System, Account, c.keyring.PublicKey)
+	rt := c.runtime.Load()
+	era, err := c.newTxEra(txOpts.Lifetime)
 	if err != nil {
-		return "", fmt.Errorf(" CreateStorageKey err: %v", err)
+		return receipt, fmt.Errorf(" extrinsic era err: %v", err)
+	}
+	tip := types.NewUCompactFromUInt(0)
+	if txOpts.Tip != nil {
+		tip = types.NewUCompact(txOpts.Tip)
 	}
 
-	if !c.GetRpcState() {
-		if err = c.ReconnectRpc(); err != nil {
-			return "", ERR_RPC_CONNECTION
+	if txOpts.dryRun {
+		if err = c.preflight(call, txSigner, txOpts); err != nil {
+			return receipt, err
 		}
 	}
 
-	var accountInfo types.AccountInfo
-	ok, err := c.api.RPC.State.GetStorageLatest(key, &accountInfo)
-	if err != nil {
-		c.SetRpcState(false)
-		return "", fmt.Errorf(" GetStorageLatest err: %v", err)
+	var (
+		nonce        uint64
+		exthash      types.Hash
+		subscription *author.ExtrinsicStatusSubscription
+		accountID    = signer.AccountID(txSigner)
+	)
+	for retry := 0; ; retry++ {
+		nonce, err = c.nonces.acquire(accountID, c.fetchNonce)
+		if err != nil {
+			return receipt, fmt.Errorf(" get nonce err: %v", err)
+		}
+
+		var ext types.Extrinsic
+		ext, err = c.newSignedExtrinsic(call, txSigner, rt.version, era, tip, nonce)
+		if err != nil {
+			c.nonces.release(accountID, nonce)
+			return receipt, fmt.Errorf(" extrinsic sign err: %v", err)
+		}
+		exthash, err = extrinsicHash(ext)
+		if err != nil {
+			c.nonces.release(accountID, nonce)
+			return receipt, fmt.Errorf(" extrinsic hash err: %v", err)
+		}
+		receipt.ExtrinsicHash = exthash.Hex()
+
+		subscription, err = c.api.RPC.Author.SubmitAndWatchExtrinsic(ext)
+		if err == nil {
+			break
+		}
+		if isNonceError(err) && retry < maxNonceRetries {
+			// the nonce is taken, e.g. by a transaction submitted by another client
+			c.nonces.done(accountID, nonce)
+			c.nonces.invalidate(accountID)
+			// an outdated transaction may also come from an expired era
+			if era, err = c.newTxEra(txOpts.Lifetime); err != nil {
+				return receipt, fmt.Errorf(" extrinsic era err: %v", err)
+			}
+			continue
+		}
+		c.nonces.release(accountID, nonce)
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		if c.runtime.Load() != rt {
+			// the call or the signature may not match the new runtime
+			return receipt, fmt.Errorf(" SubmitAndWatchExtrinsic err: %w: %v", ERR_RuntimeUpgraded, err)
+		}
+		return receipt, fmt.Errorf(" SubmitAndWatchExtrinsic err: %v", err)
 	}
+	defer subscription.Unsubscribe()
 
-	if !ok {
-		return "", fmt.Errorf(" GetStorageLatest: %v", ERR_RPC_EMPTY_VALUE)
+	return c.watchExtrinsic(subscription, receipt, exthash, accountID, nonce, era.inclusionTimeout(era.current, c.packingTime), txOpts)
+}
+
+// txCall returns the call that the transaction dispatches according to the options
+func (c *ChainClient) txCall(call types.Call, txOpts TxOptions) (types.Call, error) {
+	if txOpts.proxyReal != nil {
+		return c.proxyCall(call, txOpts.proxyReal, txOpts.proxyType)
 	}
+	return call, nil
+}
 
+// newSignedExtrinsic signs the call with s for the runtime version
+func (c *ChainClient) newSignedExtrinsic(call types.Call, s signer.Signer, version *types.RuntimeVersion, era txEra, tip types.UCompact, nonce uint64) (types.Extrinsic, error) {
+	ext := types.NewExtrinsic(call)
 	o := types.SignatureOptions{
-		BlockHash:          c.genesisHash,
-		Era:                types.ExtrinsicEra{IsMortalEra: false},
+		BlockHash:          era.blockHash,
+		Era:                era.era,
 		GenesisHash:        c.genesisHash,
-		Nonce:              types.NewUCompactFromUInt(uint64(accountInfo.Nonce)),
-		SpecVersion:        c.runtimeVersion.SpecVersion,
-		Tip:                types.NewUCompactFromUInt(0),
-		TransactionVersion: c.runtimeVersion.TransactionVersion,
+		Nonce:              types.NewUCompactFromUInt(nonce),
+		SpecVersion:        version.SpecVersion,
+		Tip:                tip,
+		TransactionVersion: version.TransactionVersion,
 	}
-
-	err = ext.Sign(c.keyring, o)
-	if err != nil {
-		return "", fmt.Errorf(" extrinsic sign err: %v", err)
-	}
-
-	subscription, err := c.api.RPC.Author.SubmitAndWatchExtrinsic(ext)
-	if err != nil {
-		c.SetRpcState(false)
-		return "", fmt.Errorf(" SubmitAndWatchExtrinsic err: %v", err)
+	if err := signExtrinsic(c.ctx, &ext, s, o); err != nil {
+		return ext, err
 	}
-	defer subscription.Unsubscribe()
+	return ext, nil
+}
 
-	timeout := time.NewTimer(c.packingTime)
+// watchExtrinsic waits for the submitted extrinsic to reach the stage
+// requested by the options and reports the stages it goes through,
+// the nonce of the extrinsic is done when it returns
+//   - inclusionTimeout: time to wait for the extrinsic to be included in a block
+func (c *ChainClient) watchExtrinsic(subscription *author.ExtrinsicStatusSubscription, receipt TxReceipt, exthash types.Hash, accountID []byte, nonce uint64, inclusionTimeout time.Duration, txOpts TxOptions) (TxReceipt, error) {
+	var (
+		err           error
+		extrinsicName = receipt.ExtrinsicName
+	)
+	defer c.nonces.done(accountID, nonce)
+	timeout := time.NewTimer(inclusionTimeout)
 	defer timeout.Stop()
 
-	blockhash := ""
+	var (
+		inBlock    bool
+		retracted  bool
+		receiptErr error
+	)
 	for {
 		select {
 		case status := <-subscription.Chan():
-			if status.IsInBlock {
-				blockhash = status.AsInBlock.Hex()
-				if extrinsicName != "" {
-					err = c.RetrieveEvent(status.AsInBlock, extrinsicName, c.signatureAcc)
-					if err != nil {
-						return blockhash, fmt.Errorf(" RetrieveEvent err: %v", err)
-					}
+			stage, blockhash := txStageOf(status)
+			switch stage {
+			case TxStageFuture, TxStageReady, TxStageBroadcast:
+				// a future transaction waits for the lower nonces still in flight
+				c.notifyTxStatus(txOpts, TxStatus{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash})
+			case TxStageInBlock:
+				receipt, receiptErr = c.getTxReceipt(blockhash, exthash, extrinsicName)
+				c.notifyTxStatus(txOpts, TxStatus{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash, BlockHash: receipt.BlockHash, Receipt: &receipt, Err: receiptErr})
+				if !txOpts.WaitFinalized {
+					return receipt, receiptErr
+				}
+				inBlock = true
+				retracted = false
+				resetTimer(timeout, txOpts.FinalizationTimeout)
+			case TxStageRetracted:
+				// the transaction returns to the pool and may be included in another block
+				inBlock = false
+				retracted = true
+				c.notifyTxStatus(txOpts, TxStatus{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash, BlockHash: blockhash.Hex()})
+			case TxStageFinalized:
+				if !inBlock || receipt.BlockHash != blockhash.Hex() {
+					receipt, receiptErr = c.getTxReceipt(blockhash, exthash, extrinsicName)
+				}
+				c.notifyTxStatus(txOpts, TxStatus{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash, BlockHash: receipt.BlockHash, Receipt: &receipt, Err: receiptErr})
+				return receipt, receiptErr
+			case TxStageFinalityTimeout, TxStageUsurped, TxStageDropped, TxStageInvalid:
+				if stage != TxStageFinalityTimeout {
+					// the nonce may be free again, the chain tells which nonces are
+					c.nonces.done(accountID, nonce)
+					c.nonces.invalidate(accountID)
 				}
-				return blockhash, nil
+				statusErr := &TxStatusError{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash}
+				if stage == TxStageFinalityTimeout {
+					statusErr.BlockHash = blockhash.Hex()
+				}
+				c.notifyTxStatus(txOpts, TxStatus{Stage: stage, ExtrinsicHash: receipt.ExtrinsicHash, BlockHash: statusErr.BlockHash, Err: statusErr})
+				return receipt, statusErr
 			}
 		case err = <-subscription.Err():
-			return blockhash, fmt.Errorf(" subscription err: %v", err)
+			return receipt, fmt.Errorf(" subscription err: %v", err)
 		case <-timeout.C:
-			return blockhash, errors.New(" subscription timeout")
+			if retracted {
+				return receipt, &TxStatusError{Stage: TxStageRetracted, ExtrinsicHash: receipt.ExtrinsicHash}
+			}
+			if inBlock {
+				return receipt, &TxStatusError{Stage: TxStageFinalityTimeout, ExtrinsicHash: receipt.ExtrinsicHash, BlockHash: receipt.BlockHash}
+			}
+			return receipt, errors.New(" subscription timeout")
+		case <-c.ctx.Done():
+			return receipt, fmt.Errorf(" subscription canceled: %v", c.ctx.Err())
+		}
+	}
+}
+
+// defaultTxOptions returns the transaction options configured for the client
+func (c *ChainClient) defaultTxOptions() TxOptions {
+	return TxOptions{
+		FinalizationTimeout: DefaultFinalizationTimeout,
+		Tip:                 c.tip,
+		Lifetime:            c.txLifetime,
+	}
+}
+
+// txStageOf returns the stage of the extrinsic status and the block hash it refers to
+func txStageOf(status types.ExtrinsicStatus) (TxStage, types.Hash) {
+	switch {
+	case status.IsFuture:
+		return TxStageFuture, types.Hash{}
+	case status.IsReady:
+		return TxStageReady, types.Hash{}
+	case status.IsBroadcast:
+		return TxStageBroadcast, types.Hash{}
+	case status.IsInBlock:
+		return TxStageInBlock, status.AsInBlock
+	case status.IsRetracted:
+		return TxStageRetracted, status.AsRetracted
+	case status.IsFinalityTimeout:
+		return TxStageFinalityTimeout, status.AsFinalityTimeout
+	case status.IsFinalized:
+		return TxStageFinalized, status.AsFinalized
+	case status.IsUsurped:
+		return TxStageUsurped, status.AsUsurped
+	case status.IsDropped:
+		return TxStageDropped, types.Hash{}
+	}
+	return TxStageInvalid, types.Hash{}
+}
+
+// notifyTxStatus reports the status of a transaction to the callback and channel of the options
+func (c *ChainClient) notifyTxStatus(o TxOptions, status TxStatus) {
+	if o.OnStatus != nil {
+		o.OnStatus(status)
+	}
+	if o.StatusCh != nil {
+		select {
+		case o.StatusCh <- status:
+		case <-c.ctx.Done():
+		}
+	}
+}
+
+func resetTimer(t *time.Timer, d time.Duration) {
+	if !t.Stop() {
+		select {
+		case <-t.C:
+		default:
 		}
 	}
+	t.Reset(d)
 }
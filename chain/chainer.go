@@ -8,147 +8,179 @@
 package chain
 
 import (
+	"context"
+
 	gsrpc "github.com/AstaFrode/go-substrate-rpc-client/v4"
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 )
 
 // chain client interface
+//
+// The queries take the block to read at as a BlockRef, e.g. LatestBlock() or BlockHash(hash).
+// For several queries to read the same block, call them with LatestBlock()
+// on the client returned by At:
+//
+//	at, err := c.At(chain.FinalizedBlock())
+//	issuance, err := at.QueryTotalIssuance(chain.LatestBlock())
+//	inactive, err := at.QueryInactiveIssuance(chain.LatestBlock())
 type Chainer interface {
 	// Audit
-	QueryChallengeSnapShot(accountID []byte, block int32) (bool, ChallengeInfo, error)
-	QueryCountedClear(accountID []byte, block int32) (uint8, error)
-	QueryCountedServiceFailed(accountID []byte, block int32) (uint32, error)
-	SubmitIdleProof(idleProof []types.U8) (string, error)
-	SubmitServiceProof(serviceProof []types.U8) (string, error)
-	SubmitVerifyIdleResult(totalProofHash []types.U8, front, rear types.U64, accumulator Accumulator, result types.Bool, sig types.Bytes, teePuk WorkerPublicKey) (string, error)
-	SubmitVerifyServiceResult(result types.Bool, sign types.Bytes, bloomFilter BloomFilter, teePuk WorkerPublicKey) (string, error)
+	QueryChallengeSnapShot(accountID []byte, block BlockRef) (bool, ChallengeInfo, error)
+	QueryCountedClear(accountID []byte, block BlockRef) (uint8, error)
+	QueryCountedServiceFailed(accountID []byte, block BlockRef) (uint32, error)
+	SubmitIdleProof(idleProof []types.U8, opts ...TxOption) (TxReceipt, error)
+	SubmitServiceProof(serviceProof []types.U8, opts ...TxOption) (TxReceipt, error)
+	SubmitVerifyIdleResult(totalProofHash []types.U8, front, rear types.U64, accumulator Accumulator, result types.Bool, sig types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error)
+	SubmitVerifyServiceResult(result types.Bool, sign types.Bytes, bloomFilter BloomFilter, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error)
 
 	// Babe
-	QueryAuthorities(block int32) ([]ConsensusRrscAppPublic, error)
+	QueryAuthorities(block BlockRef) ([]ConsensusRrscAppPublic, error)
 
 	// Balances
-	QueryTotalIssuance(block int32) (string, error)
-	QueryInactiveIssuance(block int32) (string, error)
-	TransferToken(dest string, amount string) (string, error)
+	QueryTotalIssuance(block BlockRef) (string, error)
+	QueryInactiveIssuance(block BlockRef) (string, error)
+	TransferToken(dest string, amount string, opts ...TxOption) (TxReceipt, error)
+
+	// Multisig
+	QueryMultisig(multisig []byte, callHash types.Hash, block BlockRef) (MultisigInfo, error)
+	QueryMultisigs(multisig []byte, block BlockRef) ([]MultisigInfo, error)
+	AsMulti(threshold uint16, otherSignatories [][]byte, timepoint *types.TimePoint, call types.Call, maxWeight types.Weight, opts ...TxOption) (TxReceipt, error)
+	ApproveAsMulti(threshold uint16, otherSignatories [][]byte, timepoint *types.TimePoint, callHash types.Hash, opts ...TxOption) (TxReceipt, error)
+	CancelAsMulti(threshold uint16, otherSignatories [][]byte, timepoint types.TimePoint, callHash types.Hash, opts ...TxOption) (TxReceipt, error)
 
 	// Oss
-	QueryOss(accountID []byte, block int32) (OssInfo, error)
-	QueryAllOss(block int32) ([]OssInfo, error)
-	QueryAllOssPeerId(block int32) ([]string, error)
-	QueryAuthorityList(accountID []byte, block int32) ([]types.AccountID, error)
-	Authorize(accountID []byte) (string, error)
-	CancelAuthorize(accountID []byte) (string, error)
-	RegisterOss(domain string) (string, error)
-	UpdateOss(domain string) (string, error)
-	DestroyOss() (string, error)
+	QueryOss(accountID []byte, block BlockRef) (OssInfo, error)
+	QueryAllOss(block BlockRef) ([]OssInfo, error)
+	QueryAllOssPeerId(block BlockRef) ([]string, error)
+	QueryAuthorityList(accountID []byte, block BlockRef) ([]types.AccountID, error)
+	Authorize(accountID []byte, opts ...TxOption) (TxReceipt, error)
+	CancelAuthorize(accountID []byte, opts ...TxOption) (TxReceipt, error)
+	RegisterOss(domain string, opts ...TxOption) (TxReceipt, error)
+	UpdateOss(domain string, opts ...TxOption) (TxReceipt, error)
+	DestroyOss(opts ...TxOption) (TxReceipt, error)
+
+	// Proxy
+	QueryProxies(accountID []byte, block BlockRef) (ProxyInfo, error)
+	AddProxy(delegate []byte, proxyType ProxyType, delay uint32, opts ...TxOption) (TxReceipt, error)
+	RemoveProxy(delegate []byte, proxyType ProxyType, delay uint32, opts ...TxOption) (TxReceipt, error)
+	RemoveProxies(opts ...TxOption) (TxReceipt, error)
 
 	// EVM
-	SendEvmCall(source types.H160, target types.H160, input types.Bytes, value types.U256, gasLimit types.U64, maxFeePerGas types.U256, accessList []AccessInfo) (string, error)
+	SendEvmCall(source types.H160, target types.H160, input types.Bytes, value types.U256, gasLimit types.U64, maxFeePerGas types.U256, accessList []AccessInfo, opts ...TxOption) (TxReceipt, error)
 
 	// FileBank
-	QueryDealMap(fid string, block int32) (StorageOrder, error)
-	QueryDealMapV1(fid string, block int32) (StorageOrderV1, error)
-	QueryDealMapList(block int32) ([]StorageOrder, error)
-	QueryFile(fid string, block int32) (FileMetadata, error)
-	QueryFileV1(fid string, block int32) (FileMetadataV1, error)
-	QueryRestoralOrder(fragmentHash string, block int32) (RestoralOrderInfo, error)
-	QueryAllRestoralOrder(block int32) ([]RestoralOrderInfo, error)
-	QueryUserHoldFileList(accountID []byte, block int32) ([]UserFileSliceInfo, error)
-	QueryUserFidList(accountID []byte, block int32) ([]string, error)
-	PlaceStorageOrder(fid, file_name, territory_name string, segment []SegmentDataInfo, owner []byte, file_size uint64) (string, error)
-	UploadDeclaration(fid string, segment []SegmentList, user UserBrief, filesize uint64) (string, error)
-	DeleteFile(owner []byte, fid string) (string, error)
-	TransferReport(index uint8, fid string) (string, error)
-	GenerateRestoralOrder(fid, fragmentHash string) (string, error)
-	ClaimRestoralOrder(fragmentHash string) (string, error)
-	ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash string) (string, error)
-	RestoralOrderComplete(fragmentHash string) (string, error)
-	CertIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey) (string, error)
-	ReplaceIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey) (string, error)
-	CalculateReport(teeSig types.Bytes, tagSigInfo TagSigInfo) (string, error)
-	TerritoryFileDelivery(user []byte, fid string, target_territory string) (string, error)
+	QueryDealMap(fid string, block BlockRef) (StorageOrder, error)
+	QueryDealMapV1(fid string, block BlockRef) (StorageOrderV1, error)
+	QueryDealMapList(block BlockRef) ([]KeyValue[string, StorageOrder], error)
+	QueryFile(fid string, block BlockRef) (FileMetadata, error)
+	QueryFileV1(fid string, block BlockRef) (FileMetadataV1, error)
+	QueryRestoralOrder(fragmentHash string, block BlockRef) (RestoralOrderInfo, error)
+	QueryAllRestoralOrder(block BlockRef) ([]RestoralOrderInfo, error)
+	QueryUserHoldFileList(accountID []byte, block BlockRef) ([]UserFileSliceInfo, error)
+	QueryUserFidList(accountID []byte, block BlockRef) ([]string, error)
+	PlaceStorageOrder(fid, file_name, territory_name string, segment []SegmentDataInfo, owner []byte, file_size uint64, opts ...TxOption) (TxReceipt, error)
+	UploadDeclaration(fid string, segment []SegmentList, user UserBrief, filesize uint64, opts ...TxOption) (TxReceipt, error)
+	DeleteFile(owner []byte, fid string, opts ...TxOption) (TxReceipt, error)
+	TransferReport(index uint8, fid string, opts ...TxOption) (TxReceipt, error)
+	GenerateRestoralOrder(fid, fragmentHash string, opts ...TxOption) (TxReceipt, error)
+	ClaimRestoralOrder(fragmentHash string, opts ...TxOption) (TxReceipt, error)
+	ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash string, opts ...TxOption) (TxReceipt, error)
+	RestoralOrderComplete(fragmentHash string, opts ...TxOption) (TxReceipt, error)
+	CertIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error)
+	ReplaceIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error)
+	CalculateReport(teeSig types.Bytes, tagSigInfo TagSigInfo, opts ...TxOption) (TxReceipt, error)
+	TerritoryFileDelivery(user []byte, fid string, target_territory string, opts ...TxOption) (TxReceipt, error)
 
 	// SchedulerCredit
-	QueryCurrentCounters(accountId []byte, block int32) (SchedulerCounterEntry, error)
+	QueryCurrentCounters(accountId []byte, block BlockRef) (SchedulerCounterEntry, error)
 
 	// Session
-	QueryValidators(block int32) ([]types.AccountID, error)
+	QueryValidators(block BlockRef) ([]types.AccountID, error)
 
 	// Sminer
-	QueryExpenders(block int32) (ExpendersInfo, error)
-	QueryMinerItems(accountID []byte, block int32) (MinerInfo, error)
-	QueryMinerItemsV1(accountID []byte, block int32) (MinerInfoV1, error)
-	QueryStakingStartBlock(accountID []byte, block int32) (uint32, error)
-	QueryAllMiner(block int32) ([]types.AccountID, error)
-	QueryCounterForMinerItems(block int32) (uint32, error)
-	QueryRewardMap(accountID []byte, block int32) (MinerReward, error)
-	QueryRestoralTarget(accountID []byte, block int32) (RestoralTargetInfo, error)
-	QueryAllRestoralTarget(block int32) ([]RestoralTargetInfo, error)
-	QueryPendingReplacements(accountID []byte, block int32) (types.U128, error)
-	QueryCompleteSnapShot(era uint32, block int32) (uint32, uint64, error)
-	QueryCompleteMinerSnapShot(puk []byte, block int32) ([]MinerCompleteInfo, error)
-	IncreaseCollateral(accountID []byte, token string) (string, error)
-	IncreaseDeclarationSpace(tibCount uint32) (string, error)
-	MinerExitPrep() (string, error)
-	MinerWithdraw() (string, error)
-	ReceiveReward() (string, error)
-	RegisterPoisKey(poisKey PoISKeyInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey) (string, error)
-	RegnstkSminer(earnings string, endpoint []byte, staking uint64, tibCount uint32) (string, error)
-	RegnstkAssignStaking(earnings string, endpoint []byte, stakingAcc string, tibCount uint32) (string, error)
-	UpdateBeneficiary(earnings string) (string, error)
-	UpdateSminerEndpoint(endpoint []byte) (string, error)
+	QueryExpenders(block BlockRef) (ExpendersInfo, error)
+	QueryMinerItems(accountID []byte, block BlockRef) (MinerInfo, error)
+	QueryMinerItemsV1(accountID []byte, block BlockRef) (MinerInfoV1, error)
+	QueryStakingStartBlock(accountID []byte, block BlockRef) (uint32, error)
+	QueryAllMiner(block BlockRef) ([]types.AccountID, error)
+	QueryAllMinerItems(block BlockRef) ([]KeyValue[types.AccountID, MinerInfo], error)
+	QueryCounterForMinerItems(block BlockRef) (uint32, error)
+	QueryRewardMap(accountID []byte, block BlockRef) (MinerReward, error)
+	QueryRestoralTarget(accountID []byte, block BlockRef) (RestoralTargetInfo, error)
+	QueryAllRestoralTarget(block BlockRef) ([]RestoralTargetInfo, error)
+	QueryPendingReplacements(accountID []byte, block BlockRef) (types.U128, error)
+	QueryCompleteSnapShot(era uint32, block BlockRef) (uint32, uint64, error)
+	QueryCompleteMinerSnapShot(puk []byte, block BlockRef) ([]MinerCompleteInfo, error)
+	IncreaseCollateral(accountID []byte, token string, opts ...TxOption) (TxReceipt, error)
+	IncreaseDeclarationSpace(tibCount uint32, opts ...TxOption) (TxReceipt, error)
+	MinerExitPrep(opts ...TxOption) (TxReceipt, error)
+	MinerWithdraw(opts ...TxOption) (TxReceipt, error)
+	ReceiveReward(opts ...TxOption) (TxReceipt, error)
+	RegisterPoisKey(poisKey PoISKeyInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error)
+	RegnstkSminer(earnings string, endpoint []byte, staking uint64, tibCount uint32, opts ...TxOption) (TxReceipt, error)
+	RegnstkAssignStaking(earnings string, endpoint []byte, stakingAcc string, tibCount uint32, opts ...TxOption) (TxReceipt, error)
+	UpdateBeneficiary(earnings string, opts ...TxOption) (TxReceipt, error)
+	UpdateSminerEndpoint(endpoint []byte, opts ...TxOption) (TxReceipt, error)
 
 	// Staking
-	QueryCounterForValidators(block int32) (uint32, error)
-	QueryValidatorsCount(block int32) (uint32, error)
-	QueryNominatorCount(block int32) (uint32, error)
-	QueryErasTotalStake(era uint32, block int32) (string, error)
-	QueryCurrentEra(block int32) (uint32, error)
-	QueryErasRewardPoints(era uint32, block int32) (StakingEraRewardPoints, error)
-	QueryAllNominators(block int32) ([]StakingNominations, error)
-	QueryAllBonded(block int32) ([]types.AccountID, error)
-	QueryValidatorCommission(accountID []byte, block int32) (uint8, error)
-	QueryEraValidatorReward(era uint32, block int32) (string, error)
-	QueryLedger(accountID []byte, block int32) (StakingLedger, error)
+	QueryCounterForValidators(block BlockRef) (uint32, error)
+	QueryValidatorsCount(block BlockRef) (uint32, error)
+	QueryNominatorCount(block BlockRef) (uint32, error)
+	QueryErasTotalStake(era uint32, block BlockRef) (string, error)
+	QueryCurrentEra(block BlockRef) (uint32, error)
+	QueryErasRewardPoints(era uint32, block BlockRef) (StakingEraRewardPoints, error)
+	QueryAllNominators(block BlockRef) ([]StakingNominations, error)
+	QueryAllBonded(block BlockRef) ([]types.AccountID, error)
+	QueryValidatorCommission(accountID []byte, block BlockRef) (uint8, error)
+	QueryEraValidatorReward(era uint32, block BlockRef) (string, error)
+	QueryLedger(accountID []byte, block BlockRef) (StakingLedger, error)
 	QueryeErasStakers(era uint32, accountId []byte) (StakingExposure, error)
 	QueryeAllErasStakersPaged(era uint32, accountId []byte) ([]StakingExposurePaged, error)
 	QueryeErasStakersOverview(era uint32, accountId []byte) (PagedExposureMetadata, error)
-	QueryeNominators(accountId []byte, block int32) (StakingNominations, error)
+	QueryeNominators(accountId []byte, block BlockRef) (StakingNominations, error)
 
 	// StorageHandler
-	QueryUnitPrice(block int32) (string, error)
-	QueryTotalIdleSpace(block int32) (uint64, error)
-	QueryTotalServiceSpace(block int32) (uint64, error)
-	QueryPurchasedSpace(block int32) (uint64, error)
-	QueryTerritory(accountId []byte, name string, block int32) (TerritoryInfo, error)
-	QueryConsignment(token types.H256, block int32) (ConsignmentInfo, error)
-	MintTerritory(gib_count uint32, territory_name string, days uint32) (string, error)
-	ExpandingTerritory(territory_name string, gib_count uint32) (string, error)
-	RenewalTerritory(territory_name string, days_count uint32) (string, error)
-	ReactivateTerritory(territory_name string, days_count uint32) (string, error)
-	TerritoryConsignment(territory_name string) (string, error)
-	CancelConsignment(territory_name string) (string, error)
-	BuyConsignment(token types.H256, territory_name string) (string, error)
-	CancelPurchaseAction(token types.H256) (string, error)
+	QueryUnitPrice(block BlockRef) (string, error)
+	QueryTotalIdleSpace(block BlockRef) (uint64, error)
+	QueryTotalServiceSpace(block BlockRef) (uint64, error)
+	QueryPurchasedSpace(block BlockRef) (uint64, error)
+	QueryTerritory(accountId []byte, name string, block BlockRef) (TerritoryInfo, error)
+	QueryConsignment(token types.H256, block BlockRef) (ConsignmentInfo, error)
+	MintTerritory(gib_count uint32, territory_name string, days uint32, opts ...TxOption) (TxReceipt, error)
+	ExpandingTerritory(territory_name string, gib_count uint32, opts ...TxOption) (TxReceipt, error)
+	RenewalTerritory(territory_name string, days_count uint32, opts ...TxOption) (TxReceipt, error)
+	ReactivateTerritory(territory_name string, days_count uint32, opts ...TxOption) (TxReceipt, error)
+	TerritoryConsignment(territory_name string, opts ...TxOption) (TxReceipt, error)
+	CancelConsignment(territory_name string, opts ...TxOption) (TxReceipt, error)
+	BuyConsignment(token types.H256, territory_name string, opts ...TxOption) (TxReceipt, error)
+	CancelPurchaseAction(token types.H256, opts ...TxOption) (TxReceipt, error)
+
+	// storage
+	GetStorageRaw(ctx context.Context, pallet, item string, block BlockRef, keys ...any) (types.StorageDataRaw, error)
+	GetStorageEntriesRaw(ctx context.Context, pallet, item string, block BlockRef, prefixKeys ...any) ([]StorageEntry, error)
+	IterateStorage(ctx context.Context, pallet, item string, opts IterOptions, fn func(StorageEntry) error, prefixKeys ...any) error
+	StreamStorage(ctx context.Context, pallet, item string, opts IterOptions, prefixKeys ...any) (<-chan StorageEntry, <-chan error)
+	WatchStorage(ctx context.Context, keys ...WatchKey) (<-chan StorageChange, error)
 
 	// System
 	QueryBlockNumber(blockhash string) (uint32, error)
-	QueryAccountInfo(account string, block int32) (types.AccountInfo, error)
-	QueryAccountInfoByAccountID(accountID []byte, block int32) (types.AccountInfo, error)
-	QueryAllAccountInfo(block int32) ([]types.AccountInfo, error)
+	QueryAccountInfo(account string, block BlockRef) (types.AccountInfo, error)
+	QueryAccountInfoByAccountID(accountID []byte, block BlockRef) (types.AccountInfo, error)
+	QueryAllAccountInfo(block BlockRef) ([]KeyValue[types.AccountID, types.AccountInfo], error)
 
 	// TeeWorker
-	QueryMasterPubKey(block int32) ([]byte, error)
-	QueryWorkers(puk WorkerPublicKey, block int32) (WorkerInfo, error)
-	QueryAllWorkers(block int32) ([]WorkerInfo, error)
-	QueryEndpoints(puk WorkerPublicKey, block int32) (string, error)
-	QueryWorkerAddedAt(puk WorkerPublicKey, block int32) (uint32, error)
+	QueryMasterPubKey(block BlockRef) ([]byte, error)
+	QueryWorkers(puk WorkerPublicKey, block BlockRef) (WorkerInfo, error)
+	QueryAllWorkers(block BlockRef) ([]WorkerInfo, error)
+	QueryEndpoints(puk WorkerPublicKey, block BlockRef) (string, error)
+	QueryWorkerAddedAt(puk WorkerPublicKey, block BlockRef) (uint32, error)
 
 	// CessTreasury
-	QueryCurrencyReward(block int32) (string, error)
-	QueryEraReward(block int32) (string, error)
-	QueryReserveReward(block int32) (string, error)
-	QueryRoundReward(era uint32, block int32) (string, error)
+	QueryCurrencyReward(block BlockRef) (string, error)
+	QueryEraReward(block BlockRef) (string, error)
+	QueryReserveReward(block BlockRef) (string, error)
+	QueryRoundReward(era uint32, block BlockRef) (string, error)
 
 	// rpc_call
 	ChainGetBlock(hash types.Hash) (types.SignedBlock, error)
@@ -161,17 +193,26 @@ type Chainer interface {
 	SystemVersion() (string, error)
 
 	// chain_client
+	WithContext(ctx context.Context) Chainer
+	At(ref BlockRef) (Chainer, error)
+	ResolveBlock(ref BlockRef) (types.Hash, error)
+	PinnedBlock() (types.Hash, bool)
+	Context() context.Context
 	GetSDKName() string
 	GetCurrentRpcAddr() string
+	GetRpcEndpointStatus() []RpcEndpointStatus
 	GetRpcState() bool
 	SetRpcState(state bool)
 	GetSignatureAcc() string
 	GetSignatureAccPulickey() []byte
 	GetSubstrateAPI() *gsrpc.SubstrateAPI
 	GetMetadata() *types.Metadata
+	GetRuntimeVersion() types.RuntimeVersion
+	OnRuntimeUpgrade(fn func(RuntimeUpgrade)) func()
 	GetTokenSymbol() string
 	GetNetworkEnv() string
-	GetURI() string
+	GetSigner() signer.Signer
+	GetKeystore() *signer.Keystore
 	GetBalances() uint64
 	SetBalances(balance uint64)
 	Sign(msg []byte) ([]byte, error)
@@ -183,10 +224,27 @@ type Chainer interface {
 	InitExtrinsicsName() error
 	InitExtrinsicsNameForMiner() error
 	InitExtrinsicsNameForOSS() error
-	ParseBlockData(blocknumber uint64) (BlockData, error)
+	ParseBlockData(blocknumber uint64, opts ...ParseOption) (BlockData, error)
+	ParseBlockDataByHash(blockhash types.Hash, opts ...ParseOption) (BlockData, error)
 	ParseFileInBlock(blocknumber uint64) (FileDataInBlock, error)
 
+	// Utility
+	Batch(calls []types.Call, mode BatchMode, opts ...TxOption) (BatchReceipt, error)
+
+	// fee estimation
+	EstimateFee(call types.Call, opts ...TxOption) (FeeInfo, error)
+	DryRun(call types.Call, opts ...TxOption) error
+
+	// offline signing
+	BuildUnsignedTx(accountID []byte, call types.Call, extrinsicName string, opts ...TxOption) (UnsignedTx, error)
+	SubmitSignedTx(tx SignedTx, opts ...TxOption) (TxReceipt, error)
+
+	// block stream
+	SubscribeNewHeads(ctx context.Context, opts ...HeadOption) (<-chan BlockHead, error)
+	SubscribeFinalizedHeads(ctx context.Context, opts ...HeadOption) (<-chan BlockHead, error)
+
 	// event
 	RetrieveAllEventName(blockhash types.Hash) ([]string, error)
 	RetrieveEvent(blockhash types.Hash, extrinsic_name, signer string) error
+	CheckEventTypes() error
 }
@@ -0,0 +1,281 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/scale"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// DispatchError is the reason why a dispatched call failed,
+// decoded with the sp_runtime::DispatchError type of the runtime metadata
+type DispatchError struct {
+	// variant of the dispatch error, e.g. Module, BadOrigin, Token
+	Kind string
+	// variant of the inner error of Token, Arithmetic and Transactional errors
+	Detail string
+	// index of the pallet that returned a Module error
+	ModuleIndex uint8
+	// index of the error within the pallet of a Module error
+	ErrorIndex [4]byte
+	// pallet of a Module error, e.g. FileBank, empty if it is not in the metadata
+	Pallet string
+	// name of a Module error, e.g. InsufficientStorage
+	Name string
+	// documentation of a Module error
+	Docs string
+}
+
+// NewModuleError creates a Module dispatch error to compare errors with, e.g.
+//
+//	errors.Is(err, chain.NewModuleError(chain.FileBank, "FileNonExistent"))
+func NewModuleError(pallet, name string) *DispatchError {
+	return &DispatchError{Kind: "Module", Pallet: pallet, Name: name}
+}
+
+// common errors of the CESS pallets, they match a decoded *DispatchError with errors.Is
+var (
+	// Balances
+	ErrBalancesInsufficientBalance = NewModuleError(Balances, "InsufficientBalance")
+	ErrBalancesExistentialDeposit  = NewModuleError(Balances, "ExistentialDeposit")
+
+	// FileBank
+	ErrFileBankFileExistent        = NewModuleError(FileBank, "FileExistent")
+	ErrFileBankFileNonExistent     = NewModuleError(FileBank, "FileNonExistent")
+	ErrFileBankNotOwner            = NewModuleError(FileBank, "NotOwner")
+	ErrFileBankBucketExistent      = NewModuleError(FileBank, "BucketExistent")
+	ErrFileBankBucketNotExist      = NewModuleError(FileBank, "BucketNotExist")
+	ErrFileBankInsufficientStorage = NewModuleError(FileBank, "InsufficientStorage")
+
+	// Sminer
+	ErrSminerNotMiner          = NewModuleError(Sminer, "NotMiner")
+	ErrSminerAlreadyRegistered = NewModuleError(Sminer, "AlreadyRegistered")
+
+	// StorageHandler
+	ErrStorageHandlerNotPurchasedSpace   = NewModuleError(StorageHandler, "NotPurchasedSpace")
+	ErrStorageHandlerInsufficientStorage = NewModuleError(StorageHandler, "InsufficientStorage")
+)
+
+// Error returns the description of the dispatch error
+func (e *DispatchError) Error() string {
+	switch {
+	case e.Kind == "Module" && e.Name != "":
+		if e.Docs != "" {
+			return fmt.Sprintf("%s.%s: %s", e.Pallet, e.Name, e.Docs)
+		}
+		return e.Pallet + DOT + e.Name
+	case e.Kind == "Module":
+		return fmt.Sprintf("Module(index: %d, error: %d)", e.ModuleIndex, e.ErrorIndex[0])
+	case e.Detail != "":
+		return fmt.Sprintf("%s(%s)", e.Kind, e.Detail)
+	}
+	return e.Kind
+}
+
+// Is reports whether target is the same dispatch error, Module errors
+// are compared by pallet and error name as their indexes depend on the runtime
+func (e *DispatchError) Is(target error) bool {
+	t, ok := target.(*DispatchError)
+	if !ok || t.Kind != e.Kind {
+		return false
+	}
+	if e.Kind == "Module" {
+		if t.Name == "" {
+			return t.ModuleIndex == e.ModuleIndex && t.ErrorIndex == e.ErrorIndex
+		}
+		return t.Pallet == e.Pallet && t.Name == e.Name
+	}
+	return t.Detail == e.Detail
+}
+
+// dispatchErrorDecoder decodes sp_runtime::DispatchError with the variant
+// names of the metadata, unlike the default registry decoder it keeps the variant
+type dispatchErrorDecoder struct {
+	lookup map[int64]*types.Si1Type
+	typeID int64
+	// pallets of the runtime to name Module errors
+	pallets []types.PalletMetadataV14
+}
+
+// Decode decodes a dispatch error
+func (d *dispatchErrorDecoder) Decode(decoder *scale.Decoder) (any, error) {
+	variant, err := d.readVariant(decoder, d.typeID)
+	if err != nil {
+		return nil, err
+	}
+	result := &DispatchError{Kind: string(variant.Name)}
+	if len(variant.Fields) == 0 {
+		return result, nil
+	}
+	if len(variant.Fields) != 1 {
+		return nil, fmt.Errorf("unsupported dispatch error variant %s", variant.Name)
+	}
+
+	fieldType, ok := d.lookup[variant.Fields[0].Type.Int64()]
+	if !ok {
+		return nil, fmt.Errorf("type of dispatch error variant %s not found", variant.Name)
+	}
+	switch {
+	case fieldType.Def.IsVariant:
+		inner, err := d.readVariant(decoder, variant.Fields[0].Type.Int64())
+		if err != nil {
+			return nil, err
+		}
+		if len(inner.Fields) != 0 {
+			return nil, fmt.Errorf("unsupported dispatch error variant %s.%s", variant.Name, inner.Name)
+		}
+		result.Detail = string(inner.Name)
+	case fieldType.Def.IsComposite:
+		// ModuleError { index: u8, error: [u8; 4] }, older runtimes use error: u8
+		if len(fieldType.Def.Composite.Fields) != 2 {
+			return nil, fmt.Errorf("unsupported dispatch error variant %s", variant.Name)
+		}
+		if result.ModuleIndex, err = decoder.ReadOneByte(); err != nil {
+			return nil, err
+		}
+		errorType, ok := d.lookup[fieldType.Def.Composite.Fields[1].Type.Int64()]
+		if !ok {
+			return nil, fmt.Errorf("type of dispatch error variant %s not found", variant.Name)
+		}
+		size := 1
+		if errorType.Def.IsArray {
+			size = int(errorType.Def.Array.Len)
+		}
+		if size > len(result.ErrorIndex) {
+			return nil, fmt.Errorf("unsupported module error size %d", size)
+		}
+		if err = decoder.Read(result.ErrorIndex[:size]); err != nil {
+			return nil, err
+		}
+		d.nameModuleError(result)
+	default:
+		return nil, fmt.Errorf("unsupported dispatch error variant %s", variant.Name)
+	}
+	return result, nil
+}
+
+// nameModuleError sets the pallet, name and docs of the Module error from the metadata
+func (d *dispatchErrorDecoder) nameModuleError(e *DispatchError) {
+	for _, pallet := range d.pallets {
+		if uint8(pallet.Index) != e.ModuleIndex {
+			continue
+		}
+		e.Pallet = string(pallet.Name)
+		if !pallet.HasErrors {
+			return
+		}
+		errorType, ok := d.lookup[pallet.Errors.Type.Int64()]
+		if !ok || !errorType.Def.IsVariant {
+			return
+		}
+		for _, v := range errorType.Def.Variant.Variants {
+			if uint8(v.Index) == e.ErrorIndex[0] {
+				e.Name = string(v.Name)
+				docs := make([]string, len(v.Docs))
+				for k, doc := range v.Docs {
+					docs[k] = strings.TrimSpace(string(doc))
+				}
+				e.Docs = strings.TrimSpace(strings.Join(docs, " "))
+				return
+			}
+		}
+		return
+	}
+}
+
+func (d *dispatchErrorDecoder) readVariant(decoder *scale.Decoder, typeID int64) (types.Si1Variant, error) {
+	typ, ok := d.lookup[typeID]
+	if !ok || !typ.Def.IsVariant {
+		return types.Si1Variant{}, fmt.Errorf("variant type %d not found", typeID)
+	}
+	index, err := decoder.ReadOneByte()
+	if err != nil {
+		return types.Si1Variant{}, err
+	}
+	for _, v := range typ.Def.Variant.Variants {
+		if byte(v.Index) == index {
+			return v, nil
+		}
+	}
+	return types.Si1Variant{}, fmt.Errorf("variant %d of type %d not found", index, typeID)
+}
+
+// newDispatchErrorDecoder creates the decoder of sp_runtime::DispatchError of the metadata
+func newDispatchErrorDecoder(meta *types.Metadata) (*dispatchErrorDecoder, error) {
+	for _, typ := range meta.AsMetadataV14.Lookup.Types {
+		if len(typ.Type.Path) == 2 && typ.Type.Path[0] == "sp_runtime" && typ.Type.Path[1] == "DispatchError" {
+			return &dispatchErrorDecoder{
+				lookup:  meta.AsMetadataV14.EfficientLookup,
+				typeID:  typ.ID.Int64(),
+				pallets: meta.AsMetadataV14.Pallets,
+			}, nil
+		}
+	}
+	return nil, errors.New("type sp_runtime::DispatchError not found in metadata")
+}
+
+// dispatchErrorOverrides returns the field overrides that decode
+// sp_runtime::DispatchError into *DispatchError
+func dispatchErrorOverrides(meta *types.Metadata) []registry.FieldOverride {
+	var overrides []registry.FieldOverride
+	for _, typ := range meta.AsMetadataV14.Lookup.Types {
+		if len(typ.Type.Path) != 2 || typ.Type.Path[0] != "sp_runtime" || typ.Type.Path[1] != "DispatchError" {
+			continue
+		}
+		overrides = append(overrides, registry.FieldOverride{
+			FieldLookupIndex: typ.ID.Int64(),
+			FieldDecoder: &dispatchErrorDecoder{
+				lookup:  meta.AsMetadataV14.EfficientLookup,
+				typeID:  typ.ID.Int64(),
+				pallets: meta.AsMetadataV14.Pallets,
+			},
+		})
+	}
+	return overrides
+}
+
+// newEventRegistry creates the event registry of the metadata
+func newEventRegistry(meta *types.Metadata) (registry.EventRegistry, error) {
+	eventRegistry, err := registry.NewFactory(dispatchErrorOverrides(meta)...).CreateEventRegistry(meta)
+	if err != nil {
+		return nil, errors.Wrap(err, "[CreateEventRegistry]")
+	}
+	return eventRegistry, nil
+}
+
+// dispatchErrorFromEvent returns the dispatch error carried by the event, nil if there is none,
+// it is also found inside a result, e.g. the Err of a DispatchResult
+func dispatchErrorFromEvent(fields registry.DecodedFields) *DispatchError {
+	for _, field := range fields {
+		if v := findDispatchError(field.Value); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func findDispatchError(value any) *DispatchError {
+	switch v := value.(type) {
+	case *DispatchError:
+		return v
+	case registry.DecodedFields:
+		return dispatchErrorFromEvent(v)
+	case []any:
+		for _, item := range v {
+			if e := findDispatchError(item); e != nil {
+				return e
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/scale"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testDispatchErrorLookup builds the type lookup of sp_runtime::DispatchError
+//   - 0: DispatchError
+//   - 1: ModuleError
+//   - 2: u8
+//   - 3: [u8; 4]
+//   - 4: TokenError
+func testDispatchErrorLookup() map[int64]*types.Si1Type {
+	field := func(id int64) types.Si1Field {
+		return types.Si1Field{Type: types.NewSi1LookupTypeIDFromUInt(uint64(id))}
+	}
+	variant := func(name string, index uint8, fields ...types.Si1Field) types.Si1Variant {
+		return types.Si1Variant{Name: types.Text(name), Index: types.U8(index), Fields: fields}
+	}
+	return map[int64]*types.Si1Type{
+		0: {Def: types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			variant("Other", 0),
+			variant("BadOrigin", 2),
+			variant("Module", 3, field(1)),
+			variant("Token", 7, field(4)),
+		}}}},
+		1: {Def: types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{field(2), field(3)}}}},
+		2: {Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU8}}},
+		3: {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 4, Type: types.NewSi1LookupTypeIDFromUInt(2)}}},
+		4: {Def: types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+			variant("FundsUnavailable", 0),
+			variant("BelowMinimum", 2),
+		}}}},
+	}
+}
+
+func TestDispatchErrorDecoder(t *testing.T) {
+	d := &dispatchErrorDecoder{lookup: testDispatchErrorLookup(), typeID: 0}
+	tests := []struct {
+		input  []byte
+		expect DispatchError
+	}{
+		{[]byte{2}, DispatchError{Kind: "BadOrigin"}},
+		{[]byte{3, 12, 5, 0, 0, 0}, DispatchError{Kind: "Module", ModuleIndex: 12, ErrorIndex: [4]byte{5, 0, 0, 0}}},
+		{[]byte{7, 2}, DispatchError{Kind: "Token", Detail: "BelowMinimum"}},
+	}
+	for _, tt := range tests {
+		decoder := scale.NewDecoder(bytes.NewReader(tt.input))
+		v, err := d.Decode(decoder)
+		assert.NoError(t, err)
+		assert.Equal(t, &tt.expect, v)
+	}
+
+	_, err := d.Decode(scale.NewDecoder(bytes.NewReader([]byte{9})))
+	assert.Error(t, err)
+}
+
+func TestDispatchErrorModuleName(t *testing.T) {
+	lookup := testDispatchErrorLookup()
+	lookup[5] = &types.Si1Type{Def: types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+		{Name: "FileExistent", Index: 0},
+		{Name: "FileNonExistent", Index: 5, Docs: []types.Text{" file does not exist"}},
+	}}}}
+	d := &dispatchErrorDecoder{lookup: lookup, typeID: 0, pallets: []types.PalletMetadataV14{
+		{Name: "FileBank", Index: 12, HasErrors: true, Errors: types.ErrorMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(5)}},
+	}}
+
+	v, err := d.Decode(scale.NewDecoder(bytes.NewReader([]byte{3, 12, 5, 0, 0, 0})))
+	require.NoError(t, err)
+	dispatchErr := v.(*DispatchError)
+	assert.Equal(t, "FileBank", dispatchErr.Pallet)
+	assert.Equal(t, "FileNonExistent", dispatchErr.Name)
+	assert.Equal(t, "FileBank.FileNonExistent: file does not exist", dispatchErr.Error())
+
+	wrapped := fmt.Errorf("SubmitExtrinsic: %w", dispatchErr)
+	assert.ErrorIs(t, wrapped, ErrFileBankFileNonExistent)
+	assert.NotErrorIs(t, wrapped, ErrFileBankFileExistent)
+	var target *DispatchError
+	assert.True(t, errors.As(wrapped, &target))
+	assert.Equal(t, dispatchErr, target)
+
+	// modules missing from the metadata keep their indexes
+	v, err = d.Decode(scale.NewDecoder(bytes.NewReader([]byte{3, 13, 1, 0, 0, 0})))
+	require.NoError(t, err)
+	assert.Equal(t, "Module(index: 13, error: 1)", v.(*DispatchError).Error())
+	assert.ErrorIs(t, v.(*DispatchError), &DispatchError{Kind: "Module", ModuleIndex: 13, ErrorIndex: [4]byte{1}})
+}
@@ -0,0 +1,455 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gethrpc "github.com/AstaFrode/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between two health checks of the rpc endpoints
+	DefaultHealthCheckInterval = BlockInterval
+	// DefaultMaxBlockLag is the default number of blocks an endpoint may lag behind the best endpoint
+	DefaultMaxBlockLag = 3
+)
+
+// RpcEndpointStatus is the health status of an rpc endpoint
+type RpcEndpointStatus struct {
+	// rpc address
+	Addr string
+	// whether a connection to the endpoint is established
+	Connected bool
+	// whether requests are routed to the endpoint
+	Healthy bool
+	// whether the endpoint is the preferred one for new requests
+	Current bool
+	// smoothed round-trip time of the health checks
+	Latency time.Duration
+	// best block number reported by the endpoint
+	BestBlock uint32
+	// number of blocks the endpoint lags behind the best endpoint
+	Lag uint32
+	// number of requests sent to the endpoint
+	Requests uint64
+	// number of requests and health checks that failed
+	Errors uint64
+	// last error returned by the endpoint
+	LastError string
+	// time of the last health check
+	LastCheck time.Time
+}
+
+// ErrorRate returns the ratio of failed requests to all requests of the endpoint
+func (s RpcEndpointStatus) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+type rpcEndpoint struct {
+	addr      string
+	conn      *rpcConn
+	verified  bool
+	alive     bool
+	latency   time.Duration
+	bestBlock uint32
+	requests  uint64
+	errors    uint64
+	lastErr   string
+	lastCheck time.Time
+}
+
+// endpointPool keeps a connection to every configured rpc endpoint and
+// routes requests to the healthy endpoint with the lowest latency
+type endpointPool struct {
+	lock      sync.Mutex
+	endpoints []*rpcEndpoint
+	current   *rpcEndpoint
+	genesis   types.Hash
+	interval  time.Duration
+	maxLag    uint32
+	closed    bool
+	stopCh    chan struct{}
+	stopOnce  sync.Once
+	// called when requests are routed to a different endpoint
+	onSwitch func()
+	lastUsed *rpcEndpoint
+}
+
+func newEndpointPool(rpcs []string, interval time.Duration, maxLag uint32) *endpointPool {
+	p := &endpointPool{
+		endpoints: make([]*rpcEndpoint, 0, len(rpcs)),
+		interval:  interval,
+		maxLag:    maxLag,
+		stopCh:    make(chan struct{}),
+	}
+	for _, addr := range rpcs {
+		p.endpoints = append(p.endpoints, &rpcEndpoint{addr: addr})
+	}
+	return p
+}
+
+// setGenesis sets the genesis hash that all endpoints must serve
+func (p *endpointPool) setGenesis(genesis types.Hash) {
+	p.lock.Lock()
+	p.genesis = genesis
+	p.lock.Unlock()
+}
+
+// run checks all endpoints periodically until the pool is closed or ctx is done,
+// the first check verifies the genesis hash of all endpoints
+func (p *endpointPool) run(ctx context.Context) {
+	if p.interval <= 0 {
+		return
+	}
+	p.checkAll(ctx)
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stopCh:
+			return
+		case <-ticker.C:
+			p.checkAll(ctx)
+		}
+	}
+}
+
+// checkAll connects to all endpoints that are not connected and
+// measures the latency and best block of every endpoint in parallel
+func (p *endpointPool) checkAll(ctx context.Context) {
+	p.lock.Lock()
+	endpoints := append([]*rpcEndpoint(nil), p.endpoints...)
+	p.lock.Unlock()
+
+	var wg sync.WaitGroup
+	for _, ep := range endpoints {
+		wg.Add(1)
+		go func(ep *rpcEndpoint) {
+			defer wg.Done()
+			p.check(ctx, ep)
+		}(ep)
+	}
+	wg.Wait()
+	p.electCurrent()
+}
+
+// notifySwitch calls onSwitch if requests are now routed to a different endpoint,
+// it must not be called with the lock held
+func (p *endpointPool) notifySwitch() {
+	p.lock.Lock()
+	switched := p.current != nil && p.lastUsed != nil && p.current != p.lastUsed
+	if p.current != nil {
+		p.lastUsed = p.current
+	}
+	onSwitch := p.onSwitch
+	p.lock.Unlock()
+	if switched && onSwitch != nil {
+		onSwitch()
+	}
+}
+
+func (p *endpointPool) check(ctx context.Context, ep *rpcEndpoint) {
+	p.lock.Lock()
+	if p.closed {
+		p.lock.Unlock()
+		return
+	}
+	conn := ep.conn
+	verified := ep.verified
+	genesis := p.genesis
+	p.lock.Unlock()
+
+	if conn == nil {
+		var err error
+		conn, err = dialRpc(ctx, ep.addr)
+		if err != nil {
+			p.checkFailed(ep, nil, err)
+			return
+		}
+		p.lock.Lock()
+		if p.closed {
+			p.lock.Unlock()
+			conn.Close()
+			return
+		}
+		ep.conn = conn
+		ep.verified = false
+		verified = false
+		p.lock.Unlock()
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, p.checkTimeout())
+	defer cancel()
+
+	if !verified && genesis != (types.Hash{}) {
+		var hash types.Hash
+		if err := conn.CallContext(cctx, &hash, RPC_Chain_getBlockHash, types.NewU32(0)); err != nil {
+			p.checkFailed(ep, conn, err)
+			return
+		}
+		if hash != genesis {
+			p.checkFailed(ep, conn, errors.New("genesis hash mismatch"))
+			return
+		}
+		verified = true
+	}
+
+	start := time.Now()
+	var header types.Header
+	if err := conn.CallContext(cctx, &header, RPC_Chain_getHeader); err != nil {
+		p.checkFailed(ep, conn, err)
+		return
+	}
+	rtt := time.Since(start)
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if ep.conn != conn {
+		return
+	}
+	if ep.latency == 0 {
+		ep.latency = rtt
+	} else {
+		ep.latency = (ep.latency*7 + rtt*3) / 10
+	}
+	ep.verified = verified
+	ep.alive = true
+	ep.bestBlock = uint32(header.Number)
+	ep.lastCheck = time.Now()
+}
+
+func (p *endpointPool) checkTimeout() time.Duration {
+	if p.interval > 0 && p.interval < BlockInterval {
+		return p.interval
+	}
+	return BlockInterval
+}
+
+func (p *endpointPool) checkFailed(ep *rpcEndpoint, conn *rpcConn, err error) {
+	p.lock.Lock()
+	ep.errors++
+	ep.lastErr = err.Error()
+	ep.lastCheck = time.Now()
+	p.dropLocked(ep, conn)
+	p.lock.Unlock()
+}
+
+// dropLocked closes the connection of the endpoint and stops routing to it
+func (p *endpointPool) dropLocked(ep *rpcEndpoint, conn *rpcConn) {
+	ep.alive = false
+	if conn != nil && ep.conn == conn {
+		ep.conn.Close()
+		ep.conn = nil
+		ep.verified = false
+	}
+	if p.current == ep {
+		p.current = nil
+	}
+}
+
+// electCurrent selects the healthy endpoint with the lowest latency as current endpoint
+func (p *endpointPool) electCurrent() {
+	p.lock.Lock()
+	p.current = nil
+	for _, ep := range p.healthyLocked() {
+		if p.current == nil || ep.latency < p.current.latency {
+			p.current = ep
+		}
+	}
+	p.lock.Unlock()
+	p.notifySwitch()
+}
+
+// healthyLocked returns the endpoints that are alive and do not lag behind the best endpoint
+func (p *endpointPool) healthyLocked() []*rpcEndpoint {
+	var best uint32
+	for _, ep := range p.endpoints {
+		if ep.alive && ep.bestBlock > best {
+			best = ep.bestBlock
+		}
+	}
+	healthy := make([]*rpcEndpoint, 0, len(p.endpoints))
+	for _, ep := range p.endpoints {
+		if ep.alive && ep.conn != nil && ep.bestBlock+p.maxLag >= best {
+			healthy = append(healthy, ep)
+		}
+	}
+	return healthy
+}
+
+// pick returns the endpoint a request should be sent to, skipping the endpoints in tried
+func (p *endpointPool) pick(tried []*rpcEndpoint) (*rpcEndpoint, *rpcConn) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.closed {
+		return nil, nil
+	}
+	if p.current != nil && p.current.conn != nil && !containsEndpoint(tried, p.current) {
+		p.current.requests++
+		return p.current, p.current.conn
+	}
+	candidates := p.healthyLocked()
+	healthy := len(candidates) > 0
+	if !healthy {
+		// no endpoint is known to be healthy, use any connected endpoint
+		for _, ep := range p.endpoints {
+			if ep.conn != nil {
+				candidates = append(candidates, ep)
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].latency < candidates[j].latency
+	})
+	for _, ep := range candidates {
+		if !containsEndpoint(tried, ep) {
+			if healthy && p.current == nil {
+				p.current = ep
+			}
+			ep.requests++
+			return ep, ep.conn
+		}
+	}
+	return nil, nil
+}
+
+// report records the result of a request sent to the endpoint,
+// a transport error takes the endpoint out of rotation until the next health check
+func (p *endpointPool) report(ep *rpcEndpoint, conn *rpcConn, err error) {
+	if err == nil {
+		return
+	}
+	if !isTransportError(err) {
+		return
+	}
+	p.lock.Lock()
+	ep.errors++
+	ep.lastErr = err.Error()
+	p.dropLocked(ep, conn)
+	p.lock.Unlock()
+}
+
+// suspend takes the current endpoint out of rotation until the next health check
+func (p *endpointPool) suspend() {
+	p.lock.Lock()
+	if p.current != nil {
+		p.current.alive = false
+		p.current.errors++
+		p.current = nil
+	}
+	p.lock.Unlock()
+}
+
+func (p *endpointPool) currentAddr() string {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.current != nil {
+		return p.current.addr
+	}
+	return ""
+}
+
+// available reports whether at least one endpoint can serve requests
+func (p *endpointPool) available() bool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return !p.closed && (p.current != nil || len(p.healthyLocked()) > 0)
+}
+
+func (p *endpointPool) status() []RpcEndpointStatus {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	var best uint32
+	for _, ep := range p.endpoints {
+		if ep.alive && ep.bestBlock > best {
+			best = ep.bestBlock
+		}
+	}
+	healthy := p.healthyLocked()
+	result := make([]RpcEndpointStatus, len(p.endpoints))
+	for k, ep := range p.endpoints {
+		result[k] = RpcEndpointStatus{
+			Addr:      ep.addr,
+			Connected: ep.conn != nil,
+			Healthy:   containsEndpoint(healthy, ep),
+			Current:   ep == p.current,
+			Latency:   ep.latency,
+			BestBlock: ep.bestBlock,
+			Requests:  ep.requests,
+			Errors:    ep.errors,
+			LastError: ep.lastErr,
+			LastCheck: ep.lastCheck,
+		}
+		if ep.alive && best > ep.bestBlock {
+			result[k].Lag = best - ep.bestBlock
+		}
+	}
+	return result
+}
+
+// close stops the health checks and closes all connections
+func (p *endpointPool) close() {
+	p.stopOnce.Do(func() {
+		close(p.stopCh)
+	})
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.closed = true
+	for _, ep := range p.endpoints {
+		p.dropLocked(ep, ep.conn)
+	}
+}
+
+func containsEndpoint(endpoints []*rpcEndpoint, ep *rpcEndpoint) bool {
+	for _, v := range endpoints {
+		if v == ep {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransportError reports whether err was caused by the connection
+// rather than returned by the rpc node
+func isTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr interface{ ErrorCode() int }
+	if errors.As(err, &rpcErr) {
+		return false
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, gethrpc.ErrClientQuit) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "connection lost") ||
+		strings.Contains(msg, "use of closed network connection") ||
+		strings.Contains(msg, "websocket: close")
+}
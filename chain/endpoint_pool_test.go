@@ -0,0 +1,158 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	gethrpc "github.com/AstaFrode/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChainService serves the chain_* rpc methods used by the endpoint pool
+type fakeChainService struct {
+	genesis string
+	best    atomic.Uint32
+}
+
+func (f *fakeChainService) GetHeader() map[string]interface{} {
+	return map[string]interface{}{
+		"parentHash":     "0x" + strings.Repeat("00", 32),
+		"number":         fmt.Sprintf("0x%x", f.best.Load()),
+		"stateRoot":      "0x" + strings.Repeat("00", 32),
+		"extrinsicsRoot": "0x" + strings.Repeat("00", 32),
+		"digest":         map[string]interface{}{"logs": []string{}},
+	}
+}
+
+func (f *fakeChainService) GetBlockHash(number uint64) string {
+	return f.genesis
+}
+
+type fakeNode struct {
+	chain  *fakeChainService
+	rpc    *gethrpc.Server
+	server *httptest.Server
+	url    string
+}
+
+// stop drops all connections and stops accepting new ones
+func (n *fakeNode) stop() {
+	n.server.Close()
+	n.rpc.Stop()
+}
+
+func newFakeNode(t *testing.T, genesis byte, best uint32) *fakeNode {
+	srv := gethrpc.NewServer()
+	chain := &fakeChainService{genesis: "0x" + strings.Repeat(fmt.Sprintf("%02x", genesis), 32)}
+	chain.best.Store(best)
+	assert.NoError(t, srv.RegisterName("chain", chain))
+	hs := httptest.NewServer(srv.WebsocketHandler([]string{"*"}))
+	t.Cleanup(hs.Close)
+	return &fakeNode{
+		chain:  chain,
+		rpc:    srv,
+		server: hs,
+		url:    "ws" + strings.TrimPrefix(hs.URL, "http"),
+	}
+}
+
+func TestEndpointPoolRouting(t *testing.T) {
+	fast := newFakeNode(t, 1, 100)
+	lagging := newFakeNode(t, 1, 90)
+
+	pool := newEndpointPool([]string{lagging.url, fast.url}, time.Second, 3)
+	defer pool.close()
+	pool.checkAll(context.Background())
+
+	assert.True(t, pool.available())
+	assert.Equal(t, fast.url, pool.currentAddr())
+
+	status := pool.status()
+	assert.Len(t, status, 2)
+	assert.False(t, status[0].Healthy)
+	assert.Equal(t, uint32(10), status[0].Lag)
+	assert.True(t, status[1].Healthy)
+	assert.True(t, status[1].Current)
+	assert.Equal(t, uint32(100), status[1].BestBlock)
+
+	// the lagging endpoint catches up
+	lagging.chain.best.Store(99)
+	pool.checkAll(context.Background())
+	for _, st := range pool.status() {
+		assert.True(t, st.Healthy, st.Addr)
+	}
+}
+
+func TestEndpointPoolFailover(t *testing.T) {
+	first := newFakeNode(t, 1, 100)
+	second := newFakeNode(t, 1, 100)
+
+	st := &clientState{pool: newEndpointPool([]string{first.url, second.url}, time.Second, 3)}
+	defer st.pool.close()
+	st.pool.checkAll(context.Background())
+	api := newSubstrateAPI(context.Background(), st)
+
+	// stop the endpoint the requests are routed to
+	current := st.pool.currentAddr()
+	if current == first.url {
+		first.stop()
+	} else {
+		second.stop()
+	}
+
+	header, err := api.RPC.Chain.GetHeaderLatest()
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(100), uint32(header.Number))
+	assert.NotEqual(t, current, st.pool.currentAddr())
+}
+
+func TestEndpointPoolGenesisMismatch(t *testing.T) {
+	node := newFakeNode(t, 1, 100)
+	other := newFakeNode(t, 2, 100)
+
+	pool := newEndpointPool([]string{node.url, other.url}, time.Second, 3)
+	defer pool.close()
+	pool.setGenesis(mustHash(node.chain.genesis))
+	pool.checkAll(context.Background())
+
+	status := pool.status()
+	assert.True(t, status[0].Healthy)
+	assert.False(t, status[1].Healthy)
+	assert.Equal(t, "genesis hash mismatch", status[1].LastError)
+}
+
+func TestEndpointPoolContextCanceled(t *testing.T) {
+	node := newFakeNode(t, 1, 100)
+
+	st := &clientState{pool: newEndpointPool([]string{node.url}, time.Second, 3)}
+	defer st.pool.close()
+	st.pool.checkAll(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	api := newSubstrateAPI(ctx, st)
+	_, err := api.RPC.Chain.GetHeaderLatest()
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.True(t, st.pool.available())
+}
+
+func mustHash(s string) types.Hash {
+	h, err := types.NewHashFromHexString(s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
@@ -0,0 +1,160 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"math/bits"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minimum lifetime of a mortal transaction in blocks
+	MinTxLifetime uint32 = 4
+	// maximum lifetime of a mortal transaction in blocks, the lifetime is also
+	// capped by System.BlockHashCount of the runtime
+	MaxTxLifetime uint32 = 1 << 16
+)
+
+// txEra is the era of a transaction and the block it is anchored at
+type txEra struct {
+	era       types.ExtrinsicEra
+	blockHash types.Hash
+	// number of blocks the transaction is valid for, 0 if it is immortal
+	period uint64
+	// number of the block the transaction is born at
+	birth uint64
+	// number of the best block when the transaction was anchored
+	current uint64
+}
+
+// lifetimeOf returns the number of blocks covering the waiting time d
+func lifetimeOf(d time.Duration) uint32 {
+	blocks := (d + BlockInterval - 1) / BlockInterval
+	if blocks < time.Duration(MinTxLifetime) {
+		return MinTxLifetime
+	}
+	if blocks > time.Duration(MaxTxLifetime) {
+		return MaxTxLifetime
+	}
+	return uint32(blocks)
+}
+
+// eraPeriod rounds the lifetime up to the next power of two within the
+// bounds accepted by the runtime
+//   - lifetime: lifetime of the transaction in blocks
+//   - blockHashCount: System.BlockHashCount, the birth block of a transaction
+//     must be among this number of last blocks
+func eraPeriod(lifetime uint32, blockHashCount uint64) uint64 {
+	longest := uint64(MaxTxLifetime)
+	if blockHashCount < longest {
+		// the longest power of two within the block hashes kept by the runtime
+		longest = 1 << (bits.Len64(blockHashCount) - 1)
+	}
+	if longest < uint64(MinTxLifetime) {
+		longest = uint64(MinTxLifetime)
+	}
+	if lifetime <= MinTxLifetime {
+		return uint64(MinTxLifetime)
+	}
+	if uint64(lifetime) >= longest {
+		return longest
+	}
+	return 1 << bits.Len32(lifetime-1)
+}
+
+// blockHashCount returns System.BlockHashCount of the runtime,
+// MaxTxLifetime if the metadata does not declare it
+func blockHashCount(meta *types.Metadata) uint64 {
+	if meta == nil {
+		return uint64(MaxTxLifetime)
+	}
+	data, err := meta.FindConstantValue(System, "BlockHashCount")
+	if err != nil {
+		return uint64(MaxTxLifetime)
+	}
+	var count types.U32
+	if err = codec.Decode(data, &count); err != nil || count == 0 {
+		return uint64(MaxTxLifetime)
+	}
+	return uint64(count)
+}
+
+// newMortalEra encodes a mortal era of the given period starting at block current,
+// it returns the era and the block number the era is born at
+func newMortalEra(current uint64, period uint64) (types.ExtrinsicEra, uint64) {
+	quantizeFactor := period >> 12
+	if quantizeFactor < 1 {
+		quantizeFactor = 1
+	}
+	phase := current % period / quantizeFactor * quantizeFactor
+
+	encoded := uint16(bits.TrailingZeros64(period) - 1)
+	if encoded < 1 {
+		encoded = 1
+	}
+	if encoded > 15 {
+		encoded = 15
+	}
+	encoded |= uint16(phase/quantizeFactor) << 4
+
+	birth := (current-phase)/period*period + phase
+	return types.ExtrinsicEra{
+		IsMortalEra: true,
+		AsMortalEra: types.MortalEra{First: byte(encoded), Second: byte(encoded >> 8)},
+	}, birth
+}
+
+// newTxEra anchors a transaction with the given lifetime at the best block, so that
+// a lagging finality does not make it outdated, the lifetime is capped by
+// System.BlockHashCount and a lifetime of 0 makes the transaction immortal
+func (c *ChainClient) newTxEra(lifetime uint32) (txEra, error) {
+	if lifetime == 0 {
+		return txEra{
+			era:       types.ExtrinsicEra{IsImmortalEra: true},
+			blockHash: c.genesisHash,
+		}, nil
+	}
+
+	bestHash, err := c.api.RPC.Chain.GetBlockHashLatest()
+	if err != nil {
+		return txEra{}, errors.Wrap(err, "[GetBlockHashLatest]")
+	}
+	header, err := c.api.RPC.Chain.GetHeader(bestHash)
+	if err != nil {
+		return txEra{}, errors.Wrap(err, "[GetHeader]")
+	}
+
+	current := uint64(header.Number)
+	period := eraPeriod(lifetime, blockHashCount(c.GetMetadata()))
+	era, birth := newMortalEra(current, period)
+	blockHash := bestHash
+	if birth != current {
+		blockHash, err = c.api.RPC.Chain.GetBlockHash(birth)
+		if err != nil {
+			return txEra{}, errors.Wrap(err, "[GetBlockHash]")
+		}
+	}
+	return txEra{era: era, blockHash: blockHash, period: period, birth: birth, current: current}, nil
+}
+
+// inclusionTimeout returns how long to wait at the block current for the transaction
+// to be included in a block, a mortal transaction is waited for until it expires
+// period blocks after its birth block
+func (e txEra) inclusionTimeout(current uint64, packingTime time.Duration) time.Duration {
+	if e.period == 0 {
+		return packingTime
+	}
+	if current >= e.birth+e.period {
+		return 0
+	}
+	return time.Duration(e.birth+e.period-current) * BlockInterval
+}
@@ -0,0 +1,71 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEraPeriod(t *testing.T) {
+	assert.Equal(t, uint64(4), eraPeriod(1, 4096))
+	assert.Equal(t, uint64(4), eraPeriod(4, 4096))
+	assert.Equal(t, uint64(8), eraPeriod(5, 4096))
+	assert.Equal(t, uint64(64), eraPeriod(64, 4096))
+	assert.Equal(t, uint64(1<<16), eraPeriod(1<<20, 1<<20))
+
+	// the birth block must be among the block hashes kept by the runtime
+	assert.Equal(t, uint64(2048), eraPeriod(1<<16, 2400))
+	assert.Equal(t, uint64(4096), eraPeriod(1<<16, 4096))
+	assert.Equal(t, uint64(4), eraPeriod(1<<16, 2))
+}
+
+func TestBlockHashCount(t *testing.T) {
+	count, err := codec.Encode(types.U32(2400))
+	require.NoError(t, err)
+	meta := &types.Metadata{Version: 14, AsMetadataV14: types.MetadataV14{
+		Pallets: []types.PalletMetadataV14{{
+			Name:      System,
+			Constants: []types.ConstantMetadataV14{{Name: "BlockHashCount", Value: count}},
+		}},
+	}}
+	assert.Equal(t, uint64(2400), blockHashCount(meta))
+	assert.Equal(t, uint64(MaxTxLifetime), blockHashCount(&types.Metadata{Version: 14}))
+	assert.Equal(t, uint64(MaxTxLifetime), blockHashCount(nil))
+}
+
+func TestNewMortalEra(t *testing.T) {
+	era, birth := newMortalEra(1587, 64)
+	assert.True(t, era.IsMortalEra)
+	assert.Equal(t, byte(0x35), era.AsMortalEra.First)
+	assert.Equal(t, byte(0x03), era.AsMortalEra.Second)
+	assert.Equal(t, uint64(1587), birth)
+
+	// phases of long periods are quantized
+	_, birth = newMortalEra(10007, 1<<14)
+	assert.Equal(t, uint64(10004), birth)
+}
+
+func TestLifetimeOf(t *testing.T) {
+	assert.Equal(t, MinTxLifetime, lifetimeOf(0))
+	assert.Equal(t, uint32(5), lifetimeOf(BlockInterval*4+time.Second))
+	assert.Equal(t, time.Second, txEra{}.inclusionTimeout(10, time.Second))
+}
+
+func TestInclusionTimeout(t *testing.T) {
+	era := txEra{period: 8, birth: 100}
+	assert.Equal(t, time.Duration(8)*BlockInterval, era.inclusionTimeout(100, time.Second))
+	// the time left is measured from the birth block
+	assert.Equal(t, time.Duration(3)*BlockInterval, era.inclusionTimeout(105, time.Second))
+	assert.Equal(t, time.Duration(0), era.inclusionTimeout(108, time.Second))
+}
@@ -0,0 +1,576 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// eventPallets are the CESS pallets whose events all have a typed struct,
+// SchedulerCredit is not listed as it has no events
+var eventPallets = []string{Audit, FileBank, Oss, Sminer, StorageHandler, TeeWorker, CessTreasury}
+
+// eventTypes maps the name of each event of the CESS pallets to its struct type
+var eventTypes = map[string]reflect.Type{
+	// Audit
+	AuditVerifyProof:               reflect.TypeOf(Event_VerifyProof{}),
+	AuditSubmitProof:               reflect.TypeOf(Event_SubmitProof{}),
+	AuditGenerateChallenge:         reflect.TypeOf(Event_GenerateChallenge{}),
+	AuditSubmitIdleProof:           reflect.TypeOf(Event_SubmitIdleProof{}),
+	AuditSubmitServiceProof:        reflect.TypeOf(Event_SubmitServiceProof{}),
+	AuditSubmitIdleVerifyResult:    reflect.TypeOf(Event_SubmitIdleVerifyResult{}),
+	AuditSubmitServiceVerifyResult: reflect.TypeOf(Event_SubmitServiceVerifyResult{}),
+
+	// FileBank
+	FileBankDeleteFile:            reflect.TypeOf(Event_DeleteFile{}),
+	FileBankFillerDelete:          reflect.TypeOf(Event_FillerDelete{}),
+	FileBankFillerUpload:          reflect.TypeOf(Event_FillerUpload{}),
+	FileBankUploadDeclaration:     reflect.TypeOf(Event_UploadDeclaration{}),
+	FileBankCreateBucket:          reflect.TypeOf(Event_CreateBucket{}),
+	FileBankDeleteBucket:          reflect.TypeOf(Event_DeleteBucket{}),
+	FileBankTransferReport:        reflect.TypeOf(Event_TransferReport{}),
+	FileBankReplaceFiller:         reflect.TypeOf(Event_ReplaceFiller{}),
+	FileBankGenerateRestoralOrder: reflect.TypeOf(Event_GenerateRestoralOrder{}),
+	FileBankClaimRestoralOrder:    reflect.TypeOf(Event_ClaimRestoralOrder{}),
+	FileBankRecoveryCompleted:     reflect.TypeOf(Event_RecoveryCompleted{}),
+	FileBankStorageCompleted:      reflect.TypeOf(Event_StorageCompleted{}),
+	FileBankIdleSpaceCert:         reflect.TypeOf(Event_IdleSpaceCert{}),
+	FileBankReplaceIdleSpace:      reflect.TypeOf(Event_ReplaceIdleSpace{}),
+	FileBankCalculateReport:       reflect.TypeOf(Event_CalculateReport{}),
+	FileBankTerritorFileDelivery:  reflect.TypeOf(Event_TerritorFileDelivery{}),
+	FileBankCalculateEnd:          reflect.TypeOf(Event_CalculateEnd{}),
+
+	// Oss
+	OssAuthorize:       reflect.TypeOf(Event_Authorize{}),
+	OssCancelAuthorize: reflect.TypeOf(Event_CancelAuthorize{}),
+	OssOssRegister:     reflect.TypeOf(Event_OssRegister{}),
+	OssOssUpdate:       reflect.TypeOf(Event_OssUpdate{}),
+	OssOssDestroy:      reflect.TypeOf(Event_OssDestroy{}),
+
+	// Sminer
+	SminerRegistered:               reflect.TypeOf(Event_Registered{}),
+	SminerRegisterPoisKey:          reflect.TypeOf(Event_RegisterPoisKey{}),
+	SminerDrawFaucetMoney:          reflect.TypeOf(Event_DrawFaucetMoney{}),
+	SminerFaucetTopUpMoney:         reflect.TypeOf(Event_FaucetTopUpMoney{}),
+	SminerIncreaseCollateral:       reflect.TypeOf(Event_IncreaseCollateral{}),
+	SminerDeposit:                  reflect.TypeOf(Event_Deposit{}),
+	SminerUpdateBeneficiary:        reflect.TypeOf(Event_UpdateBeneficiary{}),
+	SminerUpdateEndpoint:           reflect.TypeOf(Event_UpdateEndpoint{}),
+	SminerReceive:                  reflect.TypeOf(Event_Receive{}),
+	SminerMinerExitPrep:            reflect.TypeOf(Event_MinerExitPrep{}),
+	SminerWithdraw:                 reflect.TypeOf(Event_Withdraw{}),
+	SminerIncreaseDeclarationSpace: reflect.TypeOf(Event_IncreaseDeclarationSpace{}),
+	SminerLessThan24Hours:          reflect.TypeOf(Event_LessThan24Hours{}),
+	SminerAlreadyFrozen:            reflect.TypeOf(Event_AlreadyFrozen{}),
+	SminerMinerExit:                reflect.TypeOf(Event_MinerExit{}),
+	SminerMinerClaim:               reflect.TypeOf(Event_MinerClaim{}),
+	SminerUpdatePeerId:             reflect.TypeOf(Event_UpdatePeerId{}),
+
+	// StorageHandler
+	StorageHandlerMintTerritory:        reflect.TypeOf(Event_MintTerritory{}),
+	StorageHandlerExpansionTerritory:   reflect.TypeOf(Event_ExpansionTerritory{}),
+	StorageHandlerRenewalTerritory:     reflect.TypeOf(Event_RenewalTerritory{}),
+	StorageHandlerReactivateTerritory:  reflect.TypeOf(Event_ReactivateTerritory{}),
+	StorageHandlerConsignment:          reflect.TypeOf(Event_Consignment{}),
+	StorageHandlerCancleConsignment:    reflect.TypeOf(Event_CancleConsignment{}),
+	StorageHandlerBuyConsignment:       reflect.TypeOf(Event_BuyConsignment{}),
+	StorageHandlerCancelPurchaseAction: reflect.TypeOf(Event_CancelPurchaseAction{}),
+	StorageHandlerBuySpace:             reflect.TypeOf(Event_BuySpace{}),
+	StorageHandlerExpansionSpace:       reflect.TypeOf(Event_ExpansionSpace{}),
+	StorageHandlerRenewalSpace:         reflect.TypeOf(Event_RenewalSpace{}),
+	StorageHandlerLeaseExpired:         reflect.TypeOf(Event_LeaseExpired{}),
+	StorageHandlerLeaseExpireIn24Hours: reflect.TypeOf(Event_LeaseExpireIn24Hours{}),
+
+	// TeeWorker
+	TeeWorkerExit:                          reflect.TypeOf(Event_Exit{}),
+	TeeWorkerMasterKeyLaunched:             reflect.TypeOf(Event_MasterKeyLaunched{}),
+	TeeWorkerKeyfairyAdded:                 reflect.TypeOf(Event_KeyfairyAdded{}),
+	TeeWorkerWorkerAdded:                   reflect.TypeOf(Event_WorkerAdded{}),
+	TeeWorkerWorkerUpdated:                 reflect.TypeOf(Event_WorkerUpdated{}),
+	TeeWorkerMasterKeyRotated:              reflect.TypeOf(Event_MasterKeyRotated{}),
+	TeeWorkerMasterKeyRotationFailed:       reflect.TypeOf(Event_MasterKeyRotationFailed{}),
+	TeeWorkerMinimumCesealVersionChangedTo: reflect.TypeOf(Event_MinimumCesealVersionChangedTo{}),
+
+	// CessTreasury
+	CessTreasuryDeposit: reflect.TypeOf(Event_Deposit{}),
+}
+
+// DecodeEvent decodes an event of the CESS pallets into its struct
+//   - e: event parsed from a block
+//
+// Return:
+//   - any: pointer to the struct of the event, e.g. *Event_MinerExit for Sminer.MinerExit
+//   - error: error message
+func DecodeEvent(e *parser.Event) (any, error) {
+	if e == nil {
+		return nil, errors.New("event is nil")
+	}
+	typ, ok := eventTypes[e.Name]
+	if !ok {
+		return nil, fmt.Errorf("no type for event %s", e.Name)
+	}
+	v := reflect.New(typ)
+	if err := decodeEventInto(e, v.Elem()); err != nil {
+		return nil, err
+	}
+	return v.Interface(), nil
+}
+
+// DecodeEventAs decodes the event into T, the fields of the event are assigned
+// in order to the fields of T, except the Phase and Topics fields
+//   - e: event parsed from a block
+//
+// Return:
+//   - *T: the event
+//   - error: error message
+//
+// Note:
+//   - an enum field is assigned to an integer holding the variant index, so only
+//     enums without fields are supported, except Option. The event parser decodes
+//     a variant with fields to its fields without the variant index.
+func DecodeEventAs[T any](e *parser.Event) (*T, error) {
+	if e == nil {
+		return nil, errors.New("event is nil")
+	}
+	var event T
+	v := reflect.ValueOf(&event).Elem()
+	if v.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("event type %s is not a struct", v.Type())
+	}
+	if err := decodeEventInto(e, v); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// CheckEventTypes checks the structs of the events of the CESS pallets
+// against the runtime metadata of the client, see CheckEventTypes
+//
+// Return:
+//   - error: the events whose struct does not match the runtime
+func (c *ChainClient) CheckEventTypes() error {
+	return CheckEventTypes(c.GetMetadata())
+}
+
+// CheckEventTypes checks that every event of the CESS pallets in the metadata
+// has a struct, and that the fields of the struct match the fields of the event
+//   - meta: runtime metadata
+//
+// Return:
+//   - error: the events whose struct is missing or does not match the metadata
+//
+// Note:
+//   - the structs of events removed from the runtime are kept to decode older blocks
+//   - an event with a field of an enum with fields, other than Option, is reported
+//     as not supported, see DecodeEventAs
+func CheckEventTypes(meta *types.Metadata) error {
+	if meta == nil {
+		return errors.New("metadata is nil")
+	}
+	lookup := meta.AsMetadataV14.EfficientLookup
+	var problems []string
+	for _, pallet := range meta.AsMetadataV14.Pallets {
+		if !pallet.HasEvents || !isEventPallet(string(pallet.Name)) {
+			continue
+		}
+		events, ok := lookup[pallet.Events.Type.Int64()]
+		if !ok || !events.Def.IsVariant {
+			problems = append(problems, fmt.Sprintf("%s: events type not found", pallet.Name))
+			continue
+		}
+		for _, variant := range events.Def.Variant.Variants {
+			name := fmt.Sprintf("%s.%s", pallet.Name, variant.Name)
+			typ, ok := eventTypes[name]
+			if !ok {
+				problems = append(problems, fmt.Sprintf("%s: no type", name))
+				continue
+			}
+			if err := checkEventFields(lookup, variant.Fields, typ); err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+			}
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("event types do not match the runtime: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func isEventPallet(name string) bool {
+	for _, pallet := range eventPallets {
+		if pallet == name {
+			return true
+		}
+	}
+	return false
+}
+
+// eventFields returns the indexes of the fields of the event struct,
+// the Phase and Topics fields are set from the event record
+func eventFields(typ reflect.Type) []int {
+	var fields []int
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if !f.IsExported() || f.Name == "Phase" || f.Name == "Topics" {
+			continue
+		}
+		fields = append(fields, i)
+	}
+	return fields
+}
+
+func decodeEventInto(e *parser.Event, v reflect.Value) error {
+	fields := eventFields(v.Type())
+	if len(fields) != len(e.Fields) {
+		return fmt.Errorf("decode event %s: %s has %d fields, the event has %d", e.Name, v.Type(), len(fields), len(e.Fields))
+	}
+	for k, i := range fields {
+		if err := assignDecoded(v.Field(i), e.Fields[k].Value); err != nil {
+			return fmt.Errorf("decode event %s: field %s: %v", e.Name, v.Type().Field(i).Name, err)
+		}
+	}
+	if f := v.FieldByName("Phase"); f.IsValid() && e.Phase != nil && f.Type() == reflect.TypeOf(types.Phase{}) {
+		f.Set(reflect.ValueOf(*e.Phase))
+	}
+	if f := v.FieldByName("Topics"); f.IsValid() && f.Type() == reflect.TypeOf([]types.Hash{}) {
+		f.Set(reflect.ValueOf(e.Topics))
+	}
+	return nil
+}
+
+var (
+	u128Type     = reflect.TypeOf(types.U128{})
+	ucompactType = reflect.TypeOf(types.UCompact{})
+)
+
+// optionValue returns the type of the value of a types.Option, false if typ is not an option
+func optionValue(typ reflect.Type) (reflect.Type, bool) {
+	m, ok := reflect.PointerTo(typ).MethodByName("SetSome")
+	if !ok || m.Type.NumIn() != 2 {
+		return nil, false
+	}
+	if _, ok := reflect.PointerTo(typ).MethodByName("SetNone"); !ok {
+		return nil, false
+	}
+	return m.Type.In(1), true
+}
+
+// assignDecoded assigns a value decoded by the event registry or the call decoder to dst
+func assignDecoded(dst reflect.Value, src any) error {
+	if inner, ok := optionValue(dst.Type()); ok {
+		switch v := src.(type) {
+		case nil:
+			dst.Addr().MethodByName("SetNone").Call(nil)
+			return nil
+		case uint8:
+			// None is a variant without fields
+			if v != 0 {
+				return fmt.Errorf("invalid option variant %d", v)
+			}
+			dst.Addr().MethodByName("SetNone").Call(nil)
+			return nil
+		case registry.DecodedFields:
+			if len(v) != 1 {
+				return fmt.Errorf("option has %d values", len(v))
+			}
+			src = v[0].Value
+		}
+		value := reflect.New(inner).Elem()
+		if err := assignDecoded(value, src); err != nil {
+			return err
+		}
+		dst.Addr().MethodByName("SetSome").Call([]reflect.Value{value})
+		return nil
+	}
+
+	switch v := src.(type) {
+	case registry.DecodedFields:
+		if dst.Kind() == reflect.Struct && dst.Type() != u128Type && dst.NumField() == len(v) {
+			for i, field := range v {
+				if err := assignDecoded(dst.Field(i), field.Value); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		// a struct with a single field, e.g. AccountId32 or BoundedVec
+		if len(v) == 1 {
+			return assignDecoded(dst, v[0].Value)
+		}
+		return fmt.Errorf("cannot assign %d fields to %s", len(v), dst.Type())
+	case []any:
+		switch dst.Kind() {
+		case reflect.Slice:
+			dst.Set(reflect.MakeSlice(dst.Type(), len(v), len(v)))
+		case reflect.Array:
+			if dst.Len() != len(v) {
+				return fmt.Errorf("cannot assign %d items to %s", len(v), dst.Type())
+			}
+		case reflect.Struct:
+			// a tuple
+			if dst.NumField() != len(v) {
+				return fmt.Errorf("cannot assign %d items to %s", len(v), dst.Type())
+			}
+			for i, item := range v {
+				if err := assignDecoded(dst.Field(i), item); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return fmt.Errorf("cannot assign %d items to %s", len(v), dst.Type())
+		}
+		for i, item := range v {
+			if err := assignDecoded(dst.Index(i), item); err != nil {
+				return err
+			}
+		}
+		return nil
+	case types.Bytes:
+		return assignBytes(dst, v)
+	case *CallVariant:
+		if len(v.Fields) == 0 && dst.Kind() == reflect.Uint8 {
+			dst.SetUint(uint64(v.Index))
+			return nil
+		}
+		// an enum holding a single value, e.g. MultiAddress::Id
+		if len(v.Fields) == 1 {
+			return assignDecoded(dst, v.Fields[0].Value)
+		}
+		return fmt.Errorf("cannot assign variant %s to %s", v.Name, dst.Type())
+	case types.UCompact:
+		return assignBig(dst, (*big.Int)(&v))
+	case types.U128:
+		return assignBig(dst, v.Int)
+	}
+
+	value := reflect.ValueOf(src)
+	if !value.IsValid() {
+		return fmt.Errorf("cannot assign nil to %s", dst.Type())
+	}
+	// a struct with a single field, e.g. a wrapper of an account
+	if dst.Kind() == reflect.Struct && dst.Type() != u128Type && dst.NumField() == 1 && value.Type() != dst.Type() {
+		return assignDecoded(dst.Field(0), src)
+	}
+	if dst.Kind() == reflect.String && value.Kind() != reflect.String {
+		return fmt.Errorf("cannot assign %T to %s", src, dst.Type())
+	}
+	if dst.Kind() == reflect.Struct && value.Type() != dst.Type() {
+		return fmt.Errorf("cannot assign %T to %s", src, dst.Type())
+	}
+	if !value.Type().ConvertibleTo(dst.Type()) {
+		return fmt.Errorf("cannot assign %T to %s", src, dst.Type())
+	}
+	if isUint(value.Kind()) && isUint(dst.Kind()) && dst.OverflowUint(value.Uint()) {
+		return fmt.Errorf("%v overflows %s", src, dst.Type())
+	}
+	dst.Set(value.Convert(dst.Type()))
+	return nil
+}
+
+// assignBytes assigns bytes to a string, or to a slice or an array of bytes
+func assignBytes(dst reflect.Value, data []byte) error {
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(string(data))
+		return nil
+	case reflect.Slice:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		dst.Set(reflect.MakeSlice(dst.Type(), len(data), len(data)))
+	case reflect.Array:
+		if dst.Type().Elem().Kind() != reflect.Uint8 {
+			break
+		}
+		if dst.Len() != len(data) {
+			return fmt.Errorf("cannot assign %d bytes to %s", len(data), dst.Type())
+		}
+	case reflect.Struct:
+		if dst.Type() != u128Type && dst.NumField() == 1 {
+			return assignBytes(dst.Field(0), data)
+		}
+	}
+	if dst.Kind() != reflect.Slice && dst.Kind() != reflect.Array {
+		return fmt.Errorf("cannot assign bytes to %s", dst.Type())
+	}
+	if dst.Type().Elem().Kind() != reflect.Uint8 {
+		return fmt.Errorf("cannot assign bytes to %s", dst.Type())
+	}
+	for i, b := range data {
+		dst.Index(i).SetUint(uint64(b))
+	}
+	return nil
+}
+
+// assignBig assigns a big integer to an unsigned integer or a types.U128
+func assignBig(dst reflect.Value, n *big.Int) error {
+	if n == nil {
+		n = new(big.Int)
+	}
+	switch {
+	case dst.Type() == u128Type:
+		dst.Set(reflect.ValueOf(types.NewU128(*n)))
+	case dst.Type() == ucompactType:
+		dst.Set(reflect.ValueOf(types.NewUCompact(n)))
+	case isUint(dst.Kind()):
+		if !n.IsUint64() || dst.OverflowUint(n.Uint64()) {
+			return fmt.Errorf("%v overflows %s", n, dst.Type())
+		}
+		dst.SetUint(n.Uint64())
+	default:
+		return fmt.Errorf("cannot assign %v to %s", n, dst.Type())
+	}
+	return nil
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// checkEventFields checks the fields of the event struct against the fields of the event variant
+func checkEventFields(lookup map[int64]*types.Si1Type, fields []types.Si1Field, typ reflect.Type) error {
+	indexes := eventFields(typ)
+	if len(indexes) != len(fields) {
+		return fmt.Errorf("%s has %d fields, the event has %d", typ, len(indexes), len(fields))
+	}
+	for k, i := range indexes {
+		f := typ.Field(i)
+		if fields[k].HasName && !sameFieldName(f.Name, string(fields[k].Name)) {
+			return fmt.Errorf("field %s of %s is %s in the event", f.Name, typ, fields[k].Name)
+		}
+		if err := checkType(lookup, fields[k].Type.Int64(), f.Type); err != nil {
+			return fmt.Errorf("field %s of %s: %v", f.Name, typ, err)
+		}
+	}
+	return nil
+}
+
+// sameFieldName compares a go field name with a rust field name, e.g. TeeWorker and tee_worker
+func sameFieldName(goName, rustName string) bool {
+	return strings.EqualFold(strings.ReplaceAll(goName, "_", ""), strings.ReplaceAll(rustName, "_", ""))
+}
+
+// checkType checks that a value of the metadata type can be assigned to typ by assignDecoded
+func checkType(lookup map[int64]*types.Si1Type, id int64, typ reflect.Type) error {
+	t, ok := lookup[id]
+	if !ok {
+		return fmt.Errorf("type %d not found", id)
+	}
+	def := t.Def
+	mismatch := fmt.Errorf("%s does not match type %d", typ, id)
+
+	if inner, ok := optionValue(typ); ok {
+		if !def.IsVariant || len(t.Path) == 0 || t.Path[len(t.Path)-1] != "Option" {
+			return mismatch
+		}
+		for _, v := range def.Variant.Variants {
+			if len(v.Fields) == 1 {
+				return checkType(lookup, v.Fields[0].Type.Int64(), inner)
+			}
+		}
+		return mismatch
+	}
+
+	switch {
+	case def.IsCompact:
+		if typ != u128Type && typ != ucompactType && !isUint(typ.Kind()) {
+			return mismatch
+		}
+		return nil
+	case def.IsPrimitive:
+		return checkPrimitive(def.Primitive.Si0TypeDefPrimitive, typ, mismatch)
+	case def.IsArray:
+		if typ.Kind() != reflect.Array || typ.Len() != int(def.Array.Len) {
+			return mismatch
+		}
+		return checkType(lookup, def.Array.Type.Int64(), typ.Elem())
+	case def.IsSequence:
+		if typ.Kind() != reflect.Slice {
+			return mismatch
+		}
+		return checkType(lookup, def.Sequence.Type.Int64(), typ.Elem())
+	case def.IsTuple:
+		if typ.Kind() != reflect.Struct || typ.NumField() != len(def.Tuple) {
+			return mismatch
+		}
+		for i, item := range def.Tuple {
+			if err := checkType(lookup, item.Int64(), typ.Field(i).Type); err != nil {
+				return err
+			}
+		}
+		return nil
+	case def.IsComposite:
+		fields := def.Composite.Fields
+		if typ.Kind() == reflect.Struct && typ != u128Type && typ.NumField() == len(fields) {
+			for i, field := range fields {
+				if err := checkType(lookup, field.Type.Int64(), typ.Field(i).Type); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if len(fields) == 1 {
+			return checkType(lookup, fields[0].Type.Int64(), typ)
+		}
+		return mismatch
+	case def.IsVariant:
+		// the event parser only keeps the variant index for the enums without fields
+		for _, v := range def.Variant.Variants {
+			if len(v.Fields) != 0 {
+				return fmt.Errorf("%s: enum %d has fields in variant %s, only the enums without fields are supported", typ, id, v.Name)
+			}
+		}
+		if typ.Kind() != reflect.Uint8 {
+			return mismatch
+		}
+		return nil
+	}
+	return mismatch
+}
+
+func checkPrimitive(p types.Si0TypeDefPrimitive, typ reflect.Type, mismatch error) error {
+	var kind reflect.Kind
+	switch p {
+	case types.IsBool:
+		kind = reflect.Bool
+	case types.IsChar, types.IsU8:
+		kind = reflect.Uint8
+	case types.IsStr:
+		kind = reflect.String
+	case types.IsU16:
+		kind = reflect.Uint16
+	case types.IsU32:
+		kind = reflect.Uint32
+	case types.IsU64:
+		kind = reflect.Uint64
+	case types.IsU128:
+		if typ != u128Type {
+			return mismatch
+		}
+		return nil
+	default:
+		return mismatch
+	}
+	if typ.Kind() != kind {
+		return mismatch
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEventMetadata builds a runtime with a few events of the CESS pallets
+//   - 0: u8
+//   - 1: [u8; 32]
+//   - 2: AccountId32
+//   - 3: u128
+//   - 4: AttestationProvider
+//   - 5: Option<AttestationProvider>
+//   - 6: Sminer events
+//   - 7: TeeWorker events
+func testEventMetadata() *types.Metadata {
+	field := func(name string, id int64) types.Si1Field {
+		return types.Si1Field{HasName: name != "", Name: types.Text(name), Type: types.NewSi1LookupTypeIDFromUInt(uint64(id))}
+	}
+	variant := func(name string, index uint8, fields ...types.Si1Field) types.Si1Variant {
+		return types.Si1Variant{Name: types.Text(name), Index: types.U8(index), Fields: fields}
+	}
+	variants := func(v ...types.Si1Variant) types.Si1TypeDef {
+		return types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: v}}
+	}
+	primitive := func(p types.Si0TypeDefPrimitive) *types.Si1Type {
+		return &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+	}
+	lookup := map[int64]*types.Si1Type{
+		0: primitive(types.IsU8),
+		1: {Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: 32, Type: types.NewSi1LookupTypeIDFromUInt(0)}}},
+		2: {Def: types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{Fields: []types.Si1Field{field("", 1)}}}},
+		3: primitive(types.IsU128),
+		4: {Def: variants(variant("Root", 0), variant("Ias", 1), variant("Dcap", 2))},
+		5: {Path: types.Si1Path{"Option"}, Def: variants(variant("None", 0), variant("Some", 1, field("", 4)))},
+		6: {Def: variants(
+			variant("IncreaseCollateral", 0, field("acc", 2), field("balance", 3)),
+			variant("MinerExit", 1, field("acc", 2)),
+		)},
+		7: {Def: variants(
+			variant("WorkerAdded", 0, field("pubkey", 1), field("attestation_provider", 5), field("confidence_level", 0)),
+		)},
+	}
+	return &types.Metadata{AsMetadataV14: types.MetadataV14{
+		Pallets: []types.PalletMetadataV14{
+			{Name: Sminer, Index: 5, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(6)}},
+			{Name: TeeWorker, Index: 6, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(7)}},
+		},
+		EfficientLookup: lookup,
+	}}
+}
+
+// parseTestEvents encodes the events as the System.Events storage and parses them
+func parseTestEvents(t *testing.T, meta *types.Metadata, events ...[]byte) []*parser.Event {
+	eventRegistry, err := newEventRegistry(meta)
+	require.NoError(t, err)
+	count, err := codec.Encode(types.NewUCompactFromUInt(uint64(len(events))))
+	require.NoError(t, err)
+	data := types.StorageDataRaw(append(count, bytes.Join(events, nil)...))
+	parsed, err := parser.NewEventParser().ParseEvents(eventRegistry, &data)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestDecodeEvent(t *testing.T) {
+	meta := testEventMetadata()
+	acc := bytes.Repeat([]byte{7}, 32)
+	balance, err := codec.Encode(types.NewU128(*big.NewInt(1000)))
+	require.NoError(t, err)
+	topic := bytes.Repeat([]byte{3}, 32)
+
+	// phase ApplyExtrinsic(2), event id, fields, topics
+	collateral := append([]byte{0, 2, 0, 0, 0, 5, 0}, acc...)
+	collateral = append(append(collateral, balance...), 4)
+	collateral = append(collateral, topic...)
+	worker := append([]byte{0, 2, 0, 0, 0, 6, 0}, acc...)
+	worker = append(worker, 1, 2, 9, 0)
+	noProvider := append([]byte{0, 2, 0, 0, 0, 6, 0}, acc...)
+	noProvider = append(noProvider, 0, 9, 0)
+	events := parseTestEvents(t, meta, collateral, worker, noProvider)
+	require.Len(t, events, 3)
+
+	v, err := DecodeEvent(events[0])
+	require.NoError(t, err)
+	event, ok := v.(*Event_IncreaseCollateral)
+	require.True(t, ok)
+	assert.Equal(t, acc, event.Acc.ToBytes())
+	assert.Equal(t, "1000", event.Balance.String())
+	assert.Equal(t, uint32(2), event.Phase.AsApplyExtrinsic)
+	assert.Equal(t, []types.Hash{types.NewHash(topic)}, event.Topics)
+
+	added, err := DecodeEventAs[Event_WorkerAdded](events[1])
+	require.NoError(t, err)
+	var pubkey WorkerPublicKey
+	for i, b := range acc {
+		pubkey[i] = types.U8(b)
+	}
+	assert.Equal(t, pubkey, added.Pubkey)
+	ok, provider := added.AttestationProvider.Unwrap()
+	assert.True(t, ok)
+	assert.Equal(t, types.U8(2), provider)
+	assert.Equal(t, types.U8(9), added.ConfidenceLevel)
+
+	added, err = DecodeEventAs[Event_WorkerAdded](events[2])
+	require.NoError(t, err)
+	assert.False(t, added.AttestationProvider.HasValue())
+
+	// the struct must match the event
+	_, err = DecodeEventAs[Event_MinerExit](events[0])
+	assert.Error(t, err)
+	events[0].Name = "Sminer.Unknown"
+	_, err = DecodeEvent(events[0])
+	assert.Error(t, err)
+}
+
+func TestCheckEventTypes(t *testing.T) {
+	meta := testEventMetadata()
+	assert.NoError(t, CheckEventTypes(meta))
+
+	events := meta.AsMetadataV14.EfficientLookup[6]
+	events.Def.Variant.Variants[1].Fields[0].Name = "miner"
+	events.Def.Variant.Variants = append(events.Def.Variant.Variants, types.Si1Variant{Name: "Unknown", Index: 2})
+	// a balance of u64 instead of u128
+	meta.AsMetadataV14.EfficientLookup[8] = &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: types.IsU64}}}
+	events.Def.Variant.Variants[0].Fields[1].Type = types.NewSi1LookupTypeIDFromUInt(8)
+
+	err := CheckEventTypes(meta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Sminer.IncreaseCollateral: field Balance")
+	assert.Contains(t, err.Error(), "Sminer.MinerExit: field Acc")
+	assert.Contains(t, err.Error(), "Sminer.Unknown: no type")
+	assert.NotContains(t, err.Error(), TeeWorkerWorkerAdded)
+
+	// the enums with fields are not supported
+	provider := meta.AsMetadataV14.EfficientLookup[4]
+	provider.Def.Variant.Variants[1].Fields = []types.Si1Field{{Type: types.NewSi1LookupTypeIDFromUInt(0)}}
+	err = CheckEventTypes(meta)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "TeeWorker.WorkerAdded: field AttestationProvider")
+	assert.Contains(t, err.Error(), "only the enums without fields are supported")
+}
+
+// testRuntimeEventMetadata builds the events of the Oss and StorageHandler pallets
+// as they are declared by the runtime
+func testRuntimeEventMetadata() *types.Metadata {
+	field := func(name string, id int64) types.Si1Field {
+		return types.Si1Field{HasName: true, Name: types.Text(name), Type: types.NewSi1LookupTypeIDFromUInt(uint64(id))}
+	}
+	variant := func(name string, index uint8, fields ...types.Si1Field) types.Si1Variant {
+		return types.Si1Variant{Name: types.Text(name), Index: types.U8(index), Fields: fields}
+	}
+	variants := func(v ...types.Si1Variant) types.Si1TypeDef {
+		return types.Si1TypeDef{IsVariant: true, Variant: types.Si1TypeDefVariant{Variants: v}}
+	}
+	primitive := func(p types.Si0TypeDefPrimitive) *types.Si1Type {
+		return &types.Si1Type{Def: types.Si1TypeDef{IsPrimitive: true, Primitive: types.Si1TypeDefPrimitive{Si0TypeDefPrimitive: p}}}
+	}
+	array := func(n uint32, id int64) *types.Si1Type {
+		return &types.Si1Type{Def: types.Si1TypeDef{IsArray: true, Array: types.Si1TypeDefArray{Len: types.U32(n), Type: types.NewSi1LookupTypeIDFromUInt(uint64(id))}}}
+	}
+	composite := func(path string, id int64) *types.Si1Type {
+		return &types.Si1Type{Path: types.Si1Path{types.Text(path)}, Def: types.Si1TypeDef{IsComposite: true, Composite: types.Si1TypeDefComposite{
+			Fields: []types.Si1Field{{Type: types.NewSi1LookupTypeIDFromUInt(uint64(id))}},
+		}}}
+	}
+	const (
+		u8 = iota
+		u32
+		u128
+		bytes32
+		accountId
+		h256
+		peerId
+		vec
+		boundedVec
+		ossEvents
+		storageHandlerEvents
+	)
+	lookup := map[int64]*types.Si1Type{
+		u8:         primitive(types.IsU8),
+		u32:        primitive(types.IsU32),
+		u128:       primitive(types.IsU128),
+		bytes32:    array(32, u8),
+		accountId:  composite("AccountId32", bytes32),
+		h256:       composite("H256", bytes32),
+		peerId:     array(PeerIdPublicKeyLen, u8),
+		vec:        {Def: types.Si1TypeDef{IsSequence: true, Sequence: types.Si1TypeDefSequence{Type: types.NewSi1LookupTypeIDFromUInt(u8)}}},
+		boundedVec: composite("BoundedVec", vec),
+		ossEvents: {Def: variants(
+			variant("Authorize", 0, field("acc", accountId), field("operator", accountId)),
+			variant("CancelAuthorize", 1, field("acc", accountId)),
+			variant("OssRegister", 2, field("acc", accountId), field("endpoint", peerId)),
+			variant("OssUpdate", 3, field("acc", accountId), field("new_endpoint", peerId)),
+			variant("OssDestroy", 4, field("acc", accountId)),
+		)},
+		storageHandlerEvents: {Def: variants(
+			variant("BuySpace", 0, field("acc", accountId), field("storage_capacity", u128), field("spend", u128)),
+			variant("ExpansionSpace", 1, field("acc", accountId), field("expansion_space", u128), field("fee", u128)),
+			variant("RenewalSpace", 2, field("acc", accountId), field("renewal_days", u32), field("fee", u128)),
+			variant("LeaseExpired", 3, field("acc", accountId), field("size", u128)),
+			variant("LeaseExpireIn24Hours", 4, field("acc", accountId), field("size", u128)),
+			variant("MintTerritory", 5, field("token", h256), field("name", boundedVec), field("storage_capacity", u128), field("spend", u128)),
+			variant("ExpansionTerritory", 6, field("name", boundedVec), field("expansion_space", u128), field("fee", u128)),
+			variant("RenewalTerritory", 7, field("name", boundedVec), field("days", u32), field("fee", u128)),
+			variant("ReactivateTerritory", 8, field("name", boundedVec), field("days", u32), field("fee", u128)),
+			variant("Consignment", 9, field("name", boundedVec), field("token", h256), field("price", u128)),
+			variant("BuyConsignment", 10, field("name", boundedVec), field("token", h256), field("price", u128)),
+			variant("CancelPurchaseAction", 11, field("token", h256)),
+			variant("CancleConsignment", 12, field("token", h256)),
+		)},
+	}
+	return &types.Metadata{AsMetadataV14: types.MetadataV14{
+		Pallets: []types.PalletMetadataV14{
+			{Name: Oss, Index: 1, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(ossEvents)}},
+			{Name: StorageHandler, Index: 2, HasEvents: true, Events: types.EventMetadataV14{Type: types.NewSi1LookupTypeIDFromUInt(storageHandlerEvents)}},
+			// no events
+			{Name: SchedulerCredit, Index: 3},
+		},
+		EfficientLookup: lookup,
+	}}
+}
+
+func TestCheckEventTypesRuntime(t *testing.T) {
+	meta := testRuntimeEventMetadata()
+	require.NoError(t, CheckEventTypes(meta))
+
+	// the events are decoded with the structs checked
+	acc := bytes.Repeat([]byte{7}, 32)
+	token := bytes.Repeat([]byte{1}, 32)
+	price, err := codec.Encode(types.NewU128(*big.NewInt(500)))
+	require.NoError(t, err)
+	// phase Finalization, pallet 2, event 9, name "abc", token, price, no topics
+	consignment := append([]byte{1, 2, 9, 12, 'a', 'b', 'c'}, token...)
+	consignment = append(append(consignment, price...), 0)
+	authorize := append(append([]byte{1, 1, 0}, acc...), acc...)
+	authorize = append(authorize, 0)
+	events := parseTestEvents(t, meta, consignment, authorize)
+	require.Len(t, events, 2)
+
+	v, err := DecodeEvent(events[0])
+	require.NoError(t, err)
+	event, ok := v.(*Event_Consignment)
+	require.True(t, ok)
+	assert.Equal(t, types.Bytes("abc"), event.Name)
+	assert.Equal(t, types.NewH256(token), event.Token)
+	assert.Equal(t, "500", event.Price.String())
+	v, err = DecodeEvent(events[1])
+	require.NoError(t, err)
+	require.IsType(t, &Event_Authorize{}, v)
+	assert.Equal(t, acc, v.(*Event_Authorize).Operator.ToBytes())
+}
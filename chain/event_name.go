@@ -39,6 +39,7 @@ const (
 	FileBankCalculateReport       = "FileBank.CalculateReport"
 
 	FileBankTerritorFileDelivery = "FileBank.TerritorFileDelivery"
+	FileBankCalculateEnd         = "FileBank.CalculateEnd"
 
 	// Oss
 	OssAuthorize       = "Oss.Authorize"
@@ -60,6 +61,11 @@ const (
 	SminerMinerExitPrep            = "Sminer.MinerExitPrep"
 	SminerWithdraw                 = "Sminer.Withdraw"
 	SminerIncreaseDeclarationSpace = "Sminer.IncreaseDeclarationSpace"
+	SminerLessThan24Hours          = "Sminer.LessThan24Hours"
+	SminerAlreadyFrozen            = "Sminer.AlreadyFrozen"
+	SminerMinerExit                = "Sminer.MinerExit"
+	SminerMinerClaim               = "Sminer.MinerClaim"
+	SminerUpdatePeerId             = "Sminer.UpdatePeerId"
 
 	// Staking
 	StakingStakersElected = "Staking.StakersElected"
@@ -77,6 +83,25 @@ const (
 	StorageHandlerCancleConsignment    = "StorageHandler.CancleConsignment"
 	StorageHandlerBuyConsignment       = "StorageHandler.BuyConsignment"
 	StorageHandlerCancelPurchaseAction = "StorageHandler.CancelPurchaseAction"
+	StorageHandlerBuySpace             = "StorageHandler.BuySpace"
+	StorageHandlerExpansionSpace       = "StorageHandler.ExpansionSpace"
+	StorageHandlerRenewalSpace         = "StorageHandler.RenewalSpace"
+	StorageHandlerLeaseExpired         = "StorageHandler.LeaseExpired"
+	StorageHandlerLeaseExpireIn24Hours = "StorageHandler.LeaseExpireIn24Hours"
+
+	// CessTreasury
+	CessTreasuryDeposit = "CessTreasury.Deposit"
+
+	// Multisig
+	MultisigNewMultisig       = "Multisig.NewMultisig"
+	MultisigMultisigApproval  = "Multisig.MultisigApproval"
+	MultisigMultisigExecuted  = "Multisig.MultisigExecuted"
+	MultisigMultisigCancelled = "Multisig.MultisigCancelled"
+
+	// Proxy
+	ProxyProxyAdded    = "Proxy.ProxyAdded"
+	ProxyProxyExecuted = "Proxy.ProxyExecuted"
+	ProxyProxyRemoved  = "Proxy.ProxyRemoved"
 
 	// TeeWorker
 	TeeWorkerExit                          = "TeeWorker.Exit"
@@ -88,6 +113,13 @@ const (
 	TeeWorkerMasterKeyRotationFailed       = "TeeWorker.MasterKeyRotationFailed"
 	TeeWorkerMinimumCesealVersionChangedTo = "TeeWorker.MinimumCesealVersionChangedTo"
 
+	// Utility
+	UtilityBatchCompleted           = "Utility.BatchCompleted"
+	UtilityBatchCompletedWithErrors = "Utility.BatchCompletedWithErrors"
+	UtilityBatchInterrupted         = "Utility.BatchInterrupted"
+	UtilityItemCompleted            = "Utility.ItemCompleted"
+	UtilityItemFailed               = "Utility.ItemFailed"
+
 	//
 	TransactionPaymentTransactionFeePaid = "TransactionPayment.TransactionFeePaid"
 	//
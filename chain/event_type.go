@@ -140,7 +140,7 @@ type Event_UpdatePeerId struct {
 
 type Event_Receive struct {
 	Phase  types.Phase
-	Acc    string
+	Acc    types.AccountID
 	Reward types.U128
 	Topics []types.Hash
 }
@@ -151,6 +151,14 @@ type Event_MinerExitPrep struct {
 	Topics []types.Hash
 }
 
+type Event_UpdateEndpoint struct {
+	Phase  types.Phase
+	Acc    types.AccountID
+	Old    types.Bytes
+	New    types.Bytes
+	Topics []types.Hash
+}
+
 // ------------------------FileBank----------------------
 type Event_DeleteFile struct {
 	Phase    types.Phase
@@ -325,6 +333,67 @@ type Event_LeaseExpireIn24Hours struct {
 	Topics []types.Hash
 }
 
+type Event_MintTerritory struct {
+	Phase            types.Phase
+	Token            types.H256
+	Name             types.Bytes
+	Storage_capacity types.U128
+	Spend            types.U128
+	Topics           []types.Hash
+}
+
+type Event_ExpansionTerritory struct {
+	Phase           types.Phase
+	Name            types.Bytes
+	Expansion_space types.U128
+	Fee             types.U128
+	Topics          []types.Hash
+}
+
+type Event_RenewalTerritory struct {
+	Phase  types.Phase
+	Name   types.Bytes
+	Days   types.U32
+	Fee    types.U128
+	Topics []types.Hash
+}
+
+type Event_ReactivateTerritory struct {
+	Phase  types.Phase
+	Name   types.Bytes
+	Days   types.U32
+	Fee    types.U128
+	Topics []types.Hash
+}
+
+type Event_Consignment struct {
+	Phase  types.Phase
+	Name   types.Bytes
+	Token  types.H256
+	Price  types.U128
+	Topics []types.Hash
+}
+
+type Event_BuyConsignment struct {
+	Phase  types.Phase
+	Name   types.Bytes
+	Token  types.H256
+	Price  types.U128
+	Topics []types.Hash
+}
+
+type Event_CancleConsignment struct {
+	Phase  types.Phase
+	Token  types.H256
+	Topics []types.Hash
+}
+
+type Event_CancelPurchaseAction struct {
+	Phase  types.Phase
+	Token  types.H256
+	Topics []types.Hash
+}
+
 // ------------------------TEE Worker--------------------
 type Event_Exit struct {
 	Phase  types.Phase
@@ -15,10 +15,8 @@ import (
 	"github.com/CESSProject/cess-go-sdk/utils"
 )
 
-func (c *ChainClient) SendEvmCall(source types.H160, target types.H160, input types.Bytes, value types.U256, gasLimit types.U64, maxFeePerGas types.U256, accessList []AccessInfo) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) SendEvmCall(source types.H160, target types.H160, input types.Bytes, value types.U256, gasLimit types.U64, maxFeePerGas types.U256, accessList []AccessInfo, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -30,15 +28,15 @@ func (c *ChainClient) SendEvmCall(source types.H160, target types.H160, input ty
 	var maxPriorityFeePerGas types.Option[types.U256]
 	maxPriorityFeePerGas.SetNone()
 
-	newcall, err := types.NewCall(c.metadata, ExtName_Evm_call, source, target, input, value, gasLimit, maxFeePerGas, maxPriorityFeePerGas, nonce, accessList)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Evm_call, source, target, input, value, gasLimit, maxFeePerGas, maxPriorityFeePerGas, nonce, accessList)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Evm_call, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Evm_call, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_Evm_call)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Evm_call, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_Evm_call, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Evm_call, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
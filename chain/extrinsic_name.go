@@ -8,11 +8,62 @@
 package chain
 
 import (
+	"fmt"
+	"strings"
+	"sync"
+
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
 )
 
+// ExtrinsicsName maps the call indexes to the transaction names, it is initialised by
+// InitExtrinsicsName and rebuilt after a runtime upgrade, read it with LookupExtrinsicName
 var ExtrinsicsName map[types.CallIndex]string
 
+var extrinsicsNameLock sync.RWMutex
+
+// LookupExtrinsicName returns the transaction name of the call index
+func LookupExtrinsicName(callIndex types.CallIndex) (string, bool) {
+	extrinsicsNameLock.RLock()
+	defer extrinsicsNameLock.RUnlock()
+	name, ok := ExtrinsicsName[callIndex]
+	return name, ok
+}
+
+func extrinsicsNameInitialised() bool {
+	extrinsicsNameLock.RLock()
+	defer extrinsicsNameLock.RUnlock()
+	return len(ExtrinsicsName) > 0
+}
+
+// setExtrinsicsName replaces the transaction names, init rebuilds them after a runtime upgrade
+func (c *ChainClient) setExtrinsicsName(names map[types.CallIndex]string, init func(*ChainClient) error) {
+	extrinsicsNameLock.Lock()
+	ExtrinsicsName = names
+	c.extrinsicsNameInit = init
+	extrinsicsNameLock.Unlock()
+}
+
+// initExtrinsicsName names the calls of the current metadata and swaps in the names,
+// the names missing from the metadata are skipped and returned in the error
+func (c *ChainClient) initExtrinsicsName(list []string, init func(*ChainClient) error) error {
+	meta := c.GetMetadata()
+	names := make(map[types.CallIndex]string, len(list))
+	var missing []string
+	for _, name := range list {
+		callIndex, err := meta.FindCallIndex(name)
+		if err != nil {
+			missing = append(missing, name)
+			continue
+		}
+		names[callIndex] = name
+	}
+	c.setExtrinsicsName(names, init)
+	if len(missing) > 0 {
+		return fmt.Errorf("calls not found in the metadata: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 const (
 	// AssetConversion
 	ExtName_AssetConversion_add_liquidity                = "AssetConversion.add_liquidity"
@@ -449,2005 +500,521 @@ const (
 //
 // Note:
 //   - If you need to parse all transaction events, you need to call this function.
+//   - the transactions missing from the metadata are skipped and listed in the error
 func (c *ChainClient) InitExtrinsicsName() error {
-	ExtrinsicsName = make(map[types.CallIndex]string, 0)
+	return c.initExtrinsicsName(extrinsicNames, (*ChainClient).InitExtrinsicsName)
+}
+
+// InitExtrinsicsNameForMiner initialises all transaction required by the storage miner
+//
+// Return:
+//   - error: error message
+//
+// Note:
+//   - The storage miner program needs to call this function, otherwise the transaction event cannot be parsed.
+//   - the transactions missing from the metadata are skipped and listed in the error
+func (c *ChainClient) InitExtrinsicsNameForMiner() error {
+	return c.initExtrinsicsName(minerExtrinsicNames, (*ChainClient).InitExtrinsicsNameForMiner)
+}
+
+// InitExtrinsicsNameForOSS initialises all transaction required by the deoss
+//
+// Return:
+//   - error: error message
+//
+// Note:
+//   - The deoss program needs to call this function, otherwise the transaction event cannot be parsed.
+//   - the transactions missing from the metadata are skipped and listed in the error
+func (c *ChainClient) InitExtrinsicsNameForOSS() error {
+	return c.initExtrinsicsName(ossExtrinsicNames, (*ChainClient).InitExtrinsicsNameForOSS)
+}
 
+// extrinsicNames are the transactions named by InitExtrinsicsName
+var extrinsicNames = []string{
 	// AssetConversion
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_add_liquidity); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_add_liquidity
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_create_pool); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_create_pool
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_remove_liquidity); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_remove_liquidity
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_swap_exact_tokens_for_tokens); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_swap_exact_tokens_for_tokens
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_swap_tokens_for_exact_tokens); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_swap_tokens_for_exact_tokens
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetConversion_stouch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetConversion_stouch
-	} else {
-		return err
-	}
+	ExtName_AssetConversion_add_liquidity,
+	ExtName_AssetConversion_create_pool,
+	ExtName_AssetConversion_remove_liquidity,
+	ExtName_AssetConversion_swap_exact_tokens_for_tokens,
+	ExtName_AssetConversion_swap_tokens_for_exact_tokens,
+	ExtName_AssetConversion_stouch,
 
 	// AssetRate
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetRate_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetRate_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetRate_remove); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetRate_remove
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_AssetRate_update); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_AssetRate_update
-	} else {
-		return err
-	}
+	ExtName_AssetRate_create,
+	ExtName_AssetRate_remove,
+	ExtName_AssetRate_update,
 
 	// Assets
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_approve_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_approve_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_block); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_block
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_burn); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_burn
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_cancel_approval); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_cancel_approval
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_clear_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_clear_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_destroy_accounts); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_destroy_accounts
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_destroy_approvals); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_destroy_approvals
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_finish_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_finish_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_asset_status); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_asset_status
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_cancel_approval); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_cancel_approval
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_clear_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_clear_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_set_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_set_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_force_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_force_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_freeze); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_freeze
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_freeze_asset); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_freeze_asset
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_mint); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_mint
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_refund); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_refund
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_refund_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_refund_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_set_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_set_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_set_min_balance); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_set_min_balance
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_set_team); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_set_team
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_start_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_start_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_thaw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_thaw
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_thaw_asset); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_thaw_asset
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_touch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_touch
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_touch_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_touch_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_transfer_all); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_transfer_all
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_transfer_approved); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_transfer_approved
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_transfer_keep_alive); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_transfer_keep_alive
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Assets_transfer_ownership); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Assets_transfer_ownership
-	} else {
-		return err
-	}
+	ExtName_Assets_approve_transfer,
+	ExtName_Assets_block,
+	ExtName_Assets_burn,
+	ExtName_Assets_cancel_approval,
+	ExtName_Assets_clear_metadata,
+	ExtName_Assets_create,
+	ExtName_Assets_destroy_accounts,
+	ExtName_Assets_destroy_approvals,
+	ExtName_Assets_finish_destroy,
+	ExtName_Assets_force_asset_status,
+	ExtName_Assets_force_cancel_approval,
+	ExtName_Assets_force_clear_metadata,
+	ExtName_Assets_force_create,
+	ExtName_Assets_force_set_metadata,
+	ExtName_Assets_force_transfer,
+	ExtName_Assets_freeze,
+	ExtName_Assets_freeze_asset,
+	ExtName_Assets_mint,
+	ExtName_Assets_refund,
+	ExtName_Assets_refund_other,
+	ExtName_Assets_set_metadata,
+	ExtName_Assets_set_min_balance,
+	ExtName_Assets_set_team,
+	ExtName_Assets_start_destroy,
+	ExtName_Assets_thaw,
+	ExtName_Assets_thaw_asset,
+	ExtName_Assets_touch,
+	ExtName_Assets_touch_other,
+	ExtName_Assets_transfer,
+	ExtName_Assets_transfer_all,
+	ExtName_Assets_transfer_approved,
+	ExtName_Assets_transfer_keep_alive,
+	ExtName_Assets_transfer_ownership,
 
 	// Audit
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_point_miner_challenge); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_point_miner_challenge
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_idle_proof); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_idle_proof
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_service_proof); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_service_proof
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_verify_idle_result); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_verify_idle_result
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_verify_service_result); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_verify_service_result
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_test_update_clear_slip); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_test_update_clear_slip
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_test_update_verify_slip); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_test_update_verify_slip
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_update_counted_clear); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_update_counted_clear
-	} else {
-		return err
-	}
+	ExtName_Audit_point_miner_challenge,
+	ExtName_Audit_submit_idle_proof,
+	ExtName_Audit_submit_service_proof,
+	ExtName_Audit_submit_verify_idle_result,
+	ExtName_Audit_submit_verify_service_result,
+	ExtName_Audit_test_update_clear_slip,
+	ExtName_Audit_test_update_verify_slip,
+	ExtName_Audit_update_counted_clear,
 
 	// Babe
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Babe_plan_config_change); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Babe_plan_config_change
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Babe_report_equivocation); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Babe_report_equivocation
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Babe_report_equivocation_unsigned); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Babe_report_equivocation_unsigned
-	} else {
-		return err
-	}
+	ExtName_Babe_plan_config_change,
+	ExtName_Babe_report_equivocation,
+	ExtName_Babe_report_equivocation_unsigned,
 
 	// Balances
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_burn); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_burn
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_force_adjust_total_issuance); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_force_adjust_total_issuance
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_force_set_balance); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_force_set_balance
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_force_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_force_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_force_unreserve); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_force_unreserve
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_transfer_all); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_transfer_all
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_transfer_allow_death); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_transfer_allow_death
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_transferKeepAlive); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_transferKeepAlive
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Balances_upgrade_accounts); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Balances_upgrade_accounts
-	} else {
-		return err
-	}
+	ExtName_Balances_burn,
+	ExtName_Balances_force_adjust_total_issuance,
+	ExtName_Balances_force_set_balance,
+	ExtName_Balances_force_transfer,
+	ExtName_Balances_force_unreserve,
+	ExtName_Balances_transfer_all,
+	ExtName_Balances_transfer_allow_death,
+	ExtName_Balances_transferKeepAlive,
+	ExtName_Balances_upgrade_accounts,
 
 	// BaseFee
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_BaseFee_set_base_fee_per_gas); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_BaseFee_set_base_fee_per_gas
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_BaseFee_set_elasticity); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_BaseFee_set_elasticity
-	} else {
-		return err
-	}
+	ExtName_BaseFee_set_base_fee_per_gas,
+	ExtName_BaseFee_set_elasticity,
 
 	// Cacher
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Cacher_logout); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Cacher_logout
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Cacher_pay); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Cacher_pay
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Cacher_register); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Cacher_register
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Cacher_update); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Cacher_update
-	} else {
-		return err
-	}
+	ExtName_Cacher_logout,
+	ExtName_Cacher_pay,
+	ExtName_Cacher_register,
+	ExtName_Cacher_update,
 
 	// CesMq
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CesMq_force_push_pallet_message); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CesMq_force_push_pallet_message
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CesMq_push_message); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CesMq_push_message
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CesMq_sync_offchain_message); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CesMq_sync_offchain_message
-	} else {
-		return err
-	}
+	ExtName_CesMq_force_push_pallet_message,
+	ExtName_CesMq_push_message,
+	ExtName_CesMq_sync_offchain_message,
 
 	// CessTreasury
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_pid_burn_funds); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_pid_burn_funds
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_pid_burn_funds,
 
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_pid_send_funds); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_pid_send_funds
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_pid_send_funds,
 
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_send_funds_to_pid); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_send_funds_to_pid
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_send_funds_to_pid,
 
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_send_funds_to_sid); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_send_funds_to_sid
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_send_funds_to_sid,
 
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_sid_burn_funds); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_sid_burn_funds
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_sid_burn_funds,
 
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_CessTreasury_sid_send_funds); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_CessTreasury_sid_send_funds
-	} else {
-		return err
-	}
+	ExtName_CessTreasury_sid_send_funds,
 
 	// Contracts
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_call); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_call
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_call_old_weight); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_call_old_weight
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_instantiate); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_instantiate
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_instantiate_old_weight); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_instantiate_old_weight
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_instantiate_with_code); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_instantiate_with_code
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_instantiate_with_code_old_weight); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_instantiate_with_code_old_weight
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_migrate); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_migrate
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_remove_code); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_remove_code
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_set_code); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_set_code
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Contracts_upload_code); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Contracts_upload_code
-	} else {
-		return err
-	}
+	ExtName_Contracts_call,
+	ExtName_Contracts_call_old_weight,
+	ExtName_Contracts_instantiate,
+	ExtName_Contracts_instantiate_old_weight,
+	ExtName_Contracts_instantiate_with_code,
+	ExtName_Contracts_instantiate_with_code_old_weight,
+	ExtName_Contracts_migrate,
+	ExtName_Contracts_remove_code,
+	ExtName_Contracts_set_code,
+	ExtName_Contracts_upload_code,
 
 	// Council
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_close); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_close
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_disapprove_proposal); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_disapprove_proposal
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_execute); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_execute
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_propose); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_propose
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_set_members); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_set_members
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Council_vote); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Council_vote
-	} else {
-		return err
-	}
+	ExtName_Council_close,
+	ExtName_Council_disapprove_proposal,
+	ExtName_Council_execute,
+	ExtName_Council_propose,
+	ExtName_Council_set_members,
+	ExtName_Council_vote,
 
 	// ElectionProviderMultiPhase
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ElectionProviderMultiPhase_governance_fallback); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ElectionProviderMultiPhase_governance_fallback
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ElectionProviderMultiPhase_set_emergency_election_result); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ElectionProviderMultiPhase_set_emergency_election_result
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ElectionProviderMultiPhase_set_minimum_untrusted_score); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ElectionProviderMultiPhase_set_minimum_untrusted_score
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ElectionProviderMultiPhase_submit); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ElectionProviderMultiPhase_submit
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ElectionProviderMultiPhase_submit_unsigned); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ElectionProviderMultiPhase_submit_unsigned
-	} else {
-		return err
-	}
+	ExtName_ElectionProviderMultiPhase_governance_fallback,
+	ExtName_ElectionProviderMultiPhase_set_emergency_election_result,
+	ExtName_ElectionProviderMultiPhase_set_minimum_untrusted_score,
+	ExtName_ElectionProviderMultiPhase_submit,
+	ExtName_ElectionProviderMultiPhase_submit_unsigned,
 
 	// Ethereum
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Ethereum_transact); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Ethereum_transact
-	} else {
-		return err
-	}
+	ExtName_Ethereum_transact,
 
 	// Evm
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Evm_call); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Evm_call
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Evm_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Evm_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Evm_create2); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Evm_create2
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Evm_withdraw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Evm_withdraw
-	} else {
-		return err
-	}
+	ExtName_Evm_call,
+	ExtName_Evm_create,
+	ExtName_Evm_create2,
+	ExtName_Evm_withdraw,
 
 	// EvmAccountMapping
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_EvmAccountMapping_meta_call); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_EvmAccountMapping_meta_call
-	} else {
-		return err
-	}
+	ExtName_EvmAccountMapping_meta_call,
 
 	// FastUnstake
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FastUnstake_control); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FastUnstake_control
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FastUnstake_deregister); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FastUnstake_deregister
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FastUnstake_register_fast_unstake); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FastUnstake_register_fast_unstake
-	} else {
-		return err
-	}
+	ExtName_FastUnstake_control,
+	ExtName_FastUnstake_deregister,
+	ExtName_FastUnstake_register_fast_unstake,
 
 	// FileBank
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_calculate_report); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_calculate_report
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_cert_idle_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_cert_idle_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_claim_restoral_noexist_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_claim_restoral_noexist_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_claim_restoral_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_claim_restoral_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_delete_file); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_delete_file
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_generate_restoral_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_generate_restoral_order
-	} else {
-		return err
-	}
-
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_replace_idle_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_replace_idle_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_restoral_order_complete); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_restoral_order_complete
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_root_clear_file); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_root_clear_file
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_transfer_report); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_transfer_report
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_upload_declaration); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_upload_declaration
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_territory_file_delivery); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_territory_file_delivery
-	} else {
-		return err
-	}
+	ExtName_FileBank_calculate_report,
+	ExtName_FileBank_cert_idle_space,
+	ExtName_FileBank_claim_restoral_noexist_order,
+	ExtName_FileBank_claim_restoral_order,
+	ExtName_FileBank_delete_file,
+	ExtName_FileBank_generate_restoral_order,
+
+	ExtName_FileBank_replace_idle_space,
+	ExtName_FileBank_restoral_order_complete,
+	ExtName_FileBank_root_clear_file,
+	ExtName_FileBank_transfer_report,
+	ExtName_FileBank_upload_declaration,
+	ExtName_FileBank_territory_file_delivery,
 
 	// Grandpa
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Grandpa_note_stalled); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Grandpa_note_stalled
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Grandpa_report_equivocation); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Grandpa_report_equivocation
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Grandpa_report_equivocation_unsigned); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Grandpa_report_equivocation_unsigned
-	} else {
-		return err
-	}
+	ExtName_Grandpa_note_stalled,
+	ExtName_Grandpa_report_equivocation,
+	ExtName_Grandpa_report_equivocation_unsigned,
 
 	// ImOnline
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_ImOnline_heartbeat); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_ImOnline_heartbeat
-	} else {
-		return err
-	}
+	ExtName_ImOnline_heartbeat,
 
 	// Indices
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Indices_claim); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Indices_claim
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Indices_force_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Indices_force_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Indices_free); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Indices_free
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Indices_freeze); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Indices_freeze
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Indices_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Indices_transfer
-	} else {
-		return err
-	}
+	ExtName_Indices_claim,
+	ExtName_Indices_force_transfer,
+	ExtName_Indices_free,
+	ExtName_Indices_freeze,
+	ExtName_Indices_transfer,
 
 	// MultiBlockMigrations
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_MultiBlockMigrations_clear_historic); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_MultiBlockMigrations_clear_historic
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_MultiBlockMigrations_force_onboard_mbms); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_MultiBlockMigrations_force_onboard_mbms
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_MultiBlockMigrations_force_set_active_cursor); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_MultiBlockMigrations_force_set_active_cursor
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_MultiBlockMigrations_force_set_cursor); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_MultiBlockMigrations_force_set_cursor
-	} else {
-		return err
-	}
+	ExtName_MultiBlockMigrations_clear_historic,
+	ExtName_MultiBlockMigrations_force_onboard_mbms,
+	ExtName_MultiBlockMigrations_force_set_active_cursor,
+	ExtName_MultiBlockMigrations_force_set_cursor,
 
 	// Multisig
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Multisig_approve_as_multi); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Multisig_approve_as_multi
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Multisig_as_multi); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Multisig_as_multi
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Multisig_as_multi_threshold1); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Multisig_as_multi_threshold1
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Multisig_cancel_as_multi); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Multisig_cancel_as_multi
-	} else {
-		return err
-	}
+	ExtName_Multisig_approve_as_multi,
+	ExtName_Multisig_as_multi,
+	ExtName_Multisig_as_multi_threshold1,
+	ExtName_Multisig_cancel_as_multi,
 
 	// Oss
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_authorize); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_authorize
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_cancel_authorize); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_cancel_authorize
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_evm_proxy_authorzie); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_evm_proxy_authorzie
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_proxy_authorzie); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_proxy_authorzie
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_register); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_register
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_update); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_update
-	} else {
-		return err
-	}
+	ExtName_Oss_authorize,
+	ExtName_Oss_cancel_authorize,
+	ExtName_Oss_destroy,
+	ExtName_Oss_evm_proxy_authorzie,
+	ExtName_Oss_proxy_authorzie,
+	ExtName_Oss_register,
+	ExtName_Oss_update,
 
 	// Parameters
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Parameters_set_parameter); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Parameters_set_parameter
-	} else {
-		return err
-	}
+	ExtName_Parameters_set_parameter,
 
 	// PoolAssets
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_approve_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_approve_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_block); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_block
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_burn); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_burn
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_cancel_approval); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_cancel_approval
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_clear_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_clear_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_destroy_accounts); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_destroy_accounts
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_destroy_approvals); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_destroy_approvals
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_finish_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_finish_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_asset_status); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_asset_status
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_cancel_approval); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_cancel_approval
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_clear_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_clear_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_create); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_create
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_set_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_set_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_force_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_force_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_freeze); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_freeze
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_freeze_asset); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_freeze_asset
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_mint); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_mint
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_refund); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_refund
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_refund_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_refund_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_set_metadata); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_set_metadata
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_set_min_balance); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_set_min_balance
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_set_team); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_set_team
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_start_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_start_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_thaw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_thaw
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_thaw_asset); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_thaw_asset
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_touch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_touch
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_touch_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_touch_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_transfer); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_transfer
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_transfer_all); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_transfer_all
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_transfer_approved); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_transfer_approved
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_transfer_keep_alive); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_transfer_keep_alive
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_PoolAssets_transfer_ownership); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_PoolAssets_transfer_ownership
-	} else {
-		return err
-	}
+	ExtName_PoolAssets_approve_transfer,
+	ExtName_PoolAssets_block,
+	ExtName_PoolAssets_burn,
+	ExtName_PoolAssets_cancel_approval,
+	ExtName_PoolAssets_clear_metadata,
+	ExtName_PoolAssets_create,
+	ExtName_PoolAssets_destroy_accounts,
+	ExtName_PoolAssets_destroy_approvals,
+	ExtName_PoolAssets_finish_destroy,
+	ExtName_PoolAssets_force_asset_status,
+	ExtName_PoolAssets_force_cancel_approval,
+	ExtName_PoolAssets_force_clear_metadata,
+	ExtName_PoolAssets_force_create,
+	ExtName_PoolAssets_force_set_metadata,
+	ExtName_PoolAssets_force_transfer,
+	ExtName_PoolAssets_freeze,
+	ExtName_PoolAssets_freeze_asset,
+	ExtName_PoolAssets_mint,
+	ExtName_PoolAssets_refund,
+	ExtName_PoolAssets_refund_other,
+	ExtName_PoolAssets_set_metadata,
+	ExtName_PoolAssets_set_min_balance,
+	ExtName_PoolAssets_set_team,
+	ExtName_PoolAssets_start_destroy,
+	ExtName_PoolAssets_thaw,
+	ExtName_PoolAssets_thaw_asset,
+	ExtName_PoolAssets_touch,
+	ExtName_PoolAssets_touch_other,
+	ExtName_PoolAssets_transfer,
+	ExtName_PoolAssets_transfer_all,
+	ExtName_PoolAssets_transfer_approved,
+	ExtName_PoolAssets_transfer_keep_alive,
+	ExtName_PoolAssets_transfer_ownership,
 
 	// Preimage
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Preimage_ensure_updated); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Preimage_ensure_updated
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Preimage_note_preimage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Preimage_note_preimage
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Preimage_request_preimage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Preimage_request_preimage
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Preimage_unnote_preimage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Preimage_unnote_preimage
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Preimage_unrequest_preimage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Preimage_unrequest_preimage
-	} else {
-		return err
-	}
+	ExtName_Preimage_ensure_updated,
+	ExtName_Preimage_note_preimage,
+	ExtName_Preimage_request_preimage,
+	ExtName_Preimage_unnote_preimage,
+	ExtName_Preimage_unrequest_preimage,
 
 	// Proxy
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_add_proxy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_add_proxy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_announce); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_announce
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_create_pure); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_create_pure
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_kill_pure); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_kill_pure
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_proxy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_proxy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_proxy_announced); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_proxy_announced
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_reject_announcement); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_reject_announcement
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_remove_announcement); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_remove_announcement
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_remove_proxies); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_remove_proxies
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Proxy_remove_proxy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Proxy_remove_proxy
-	} else {
-		return err
-	}
+	ExtName_Proxy_add_proxy,
+	ExtName_Proxy_announce,
+	ExtName_Proxy_create_pure,
+	ExtName_Proxy_kill_pure,
+	ExtName_Proxy_proxy,
+	ExtName_Proxy_proxy_announced,
+	ExtName_Proxy_reject_announcement,
+	ExtName_Proxy_remove_announcement,
+	ExtName_Proxy_remove_proxies,
+	ExtName_Proxy_remove_proxy,
 
 	// Reservoir
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_attend_evnet); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_attend_evnet
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_create_event); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_create_event
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_event_withdraw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_event_withdraw
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_filling); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_filling
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_store); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_store
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Reservoir_withdraw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Reservoir_withdraw
-	} else {
-		return err
-	}
+	ExtName_Reservoir_attend_evnet,
+	ExtName_Reservoir_create_event,
+	ExtName_Reservoir_event_withdraw,
+	ExtName_Reservoir_filling,
+	ExtName_Reservoir_store,
+	ExtName_Reservoir_withdraw,
 
 	// Scheduler
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_cancel); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_cancel
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_cancel_named); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_cancel_named
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_cancel_retry); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_cancel_retry
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_cancel_retry_named); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_cancel_retry_named
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_schedule); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_schedule
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_schedule_after); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_schedule_after
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_schedule_named); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_schedule_named
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_schedule_named_after); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_schedule_named_after
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_set_retry); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_set_retry
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Scheduler_set_retry_named); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Scheduler_set_retry_named
-	} else {
-		return err
-	}
+	ExtName_Scheduler_cancel,
+	ExtName_Scheduler_cancel_named,
+	ExtName_Scheduler_cancel_retry,
+	ExtName_Scheduler_cancel_retry_named,
+	ExtName_Scheduler_schedule,
+	ExtName_Scheduler_schedule_after,
+	ExtName_Scheduler_schedule_named,
+	ExtName_Scheduler_schedule_named_after,
+	ExtName_Scheduler_set_retry,
+	ExtName_Scheduler_set_retry_named,
 
 	// Session
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Session_purge_keys); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Session_purge_keys
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Session_set_keys); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Session_set_keys
-	} else {
-		return err
-	}
+	ExtName_Session_purge_keys,
+	ExtName_Session_set_keys,
 
 	// Sminer
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_clear_miner_service); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_clear_miner_service
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_decrease_declaration_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_decrease_declaration_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_faucet); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_faucet
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_faucet_top_up); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_faucet_top_up
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_increase_collateral); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_increase_collateral
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_increase_declaration_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_increase_declaration_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_exit); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_exit
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_exit_prep); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_exit_prep
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_withdraw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_withdraw
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_receive_reward); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_receive_reward
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_register_pois_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_register_pois_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_regnstk); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_regnstk
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_regnstk_assign_staking); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_regnstk_assign_staking
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_set_facuet_whitelist); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_set_facuet_whitelist
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_update_beneficiary); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_update_beneficiary
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_update_endpoint); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_update_endpoint
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_update_expender); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_update_expender
-	} else {
-		return err
-	}
+	ExtName_Sminer_clear_miner_service,
+	ExtName_Sminer_decrease_declaration_space,
+	ExtName_Sminer_faucet,
+	ExtName_Sminer_faucet_top_up,
+	ExtName_Sminer_increase_collateral,
+	ExtName_Sminer_increase_declaration_space,
+	ExtName_Sminer_miner_exit,
+	ExtName_Sminer_miner_exit_prep,
+	ExtName_Sminer_miner_withdraw,
+	ExtName_Sminer_receive_reward,
+	ExtName_Sminer_register_pois_key,
+	ExtName_Sminer_regnstk,
+	ExtName_Sminer_regnstk_assign_staking,
+	ExtName_Sminer_set_facuet_whitelist,
+	ExtName_Sminer_update_beneficiary,
+	ExtName_Sminer_update_endpoint,
+	ExtName_Sminer_update_expender,
 
 	// Staking
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_bond); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_bond
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_bond_extra); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_bond_extra
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_cancel_deferred_slash); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_cancel_deferred_slash
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_chill); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_chill
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_chill_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_chill_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_deprecate_controller_batch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_deprecate_controller_batch
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_force_apply_min_commission); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_force_apply_min_commission
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_force_new_era); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_force_new_era
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_force_new_era_always); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_force_new_era_always
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_force_no_eras); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_force_no_eras
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_force_unstake); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_force_unstake
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_increase_validator_count); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_increase_validator_count
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_kick); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_kick
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_nominate); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_nominate
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_payout_stakers); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_payout_stakers
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_payout_stakers_by_page); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_payout_stakers_by_page
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_reap_stash); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_reap_stash
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_rebond); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_rebond
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_restore_ledger); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_restore_ledger
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_scale_validator_count); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_scale_validator_count
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_controller); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_controller
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_invulnerables); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_invulnerables
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_min_commission); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_min_commission
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_payee); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_payee
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_staking_configs); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_staking_configs
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_set_validator_count); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_set_validator_count
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_unbond); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_unbond
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_update_payee); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_update_payee
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_validate); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_validate
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Staking_withdraw_unbonded); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Staking_withdraw_unbonded
-	} else {
-		return err
-	}
+	ExtName_Staking_bond,
+	ExtName_Staking_bond_extra,
+	ExtName_Staking_cancel_deferred_slash,
+	ExtName_Staking_chill,
+	ExtName_Staking_chill_other,
+	ExtName_Staking_deprecate_controller_batch,
+	ExtName_Staking_force_apply_min_commission,
+	ExtName_Staking_force_new_era,
+	ExtName_Staking_force_new_era_always,
+	ExtName_Staking_force_no_eras,
+	ExtName_Staking_force_unstake,
+	ExtName_Staking_increase_validator_count,
+	ExtName_Staking_kick,
+	ExtName_Staking_nominate,
+	ExtName_Staking_payout_stakers,
+	ExtName_Staking_payout_stakers_by_page,
+	ExtName_Staking_reap_stash,
+	ExtName_Staking_rebond,
+	ExtName_Staking_restore_ledger,
+	ExtName_Staking_scale_validator_count,
+	ExtName_Staking_set_controller,
+	ExtName_Staking_set_invulnerables,
+	ExtName_Staking_set_min_commission,
+	ExtName_Staking_set_payee,
+	ExtName_Staking_set_staking_configs,
+	ExtName_Staking_set_validator_count,
+	ExtName_Staking_unbond,
+	ExtName_Staking_update_payee,
+	ExtName_Staking_validate,
+	ExtName_Staking_withdraw_unbonded,
 
 	// StateTrieMigration
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_continue_migrate); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_continue_migrate
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_control_auto_migration); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_control_auto_migration
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_force_set_progress); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_force_set_progress
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_migrate_custom_child); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_migrate_custom_child
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_migrate_custom_top); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_migrate_custom_top
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StateTrieMigration_set_signed_max_limits); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StateTrieMigration_set_signed_max_limits
-	} else {
-		return err
-	}
+	ExtName_StateTrieMigration_continue_migrate,
+	ExtName_StateTrieMigration_control_auto_migration,
+	ExtName_StateTrieMigration_force_set_progress,
+	ExtName_StateTrieMigration_migrate_custom_child,
+	ExtName_StateTrieMigration_migrate_custom_top,
+	ExtName_StateTrieMigration_set_signed_max_limits,
 
 	// StorageHandler
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_buy_consignment); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_buy_consignment
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_cancel_consignment); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_cancel_consignment
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_cancel_purchase_action); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_cancel_purchase_action
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_clear_service_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_clear_service_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_create_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_create_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_define_update_price); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_define_update_price
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_exec_consignment); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_exec_consignment
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_exec_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_exec_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_expanding_territory); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_expanding_territory
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_mint_territory); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_mint_territory
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_reactivate_territory); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_reactivate_territory
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_renewal_territory); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_renewal_territory
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_territory_consignment); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_territory_consignment
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_territory_grants); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_territory_grants
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_territory_rename); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_territory_rename
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_update_expired_exec); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_update_expired_exec
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_update_price); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_update_price
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_StorageHandler_update_user_territory_life); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_StorageHandler_update_user_territory_life
-	} else {
-		return err
-	}
+	ExtName_StorageHandler_buy_consignment,
+	ExtName_StorageHandler_cancel_consignment,
+	ExtName_StorageHandler_cancel_purchase_action,
+	ExtName_StorageHandler_clear_service_space,
+	ExtName_StorageHandler_create_order,
+	ExtName_StorageHandler_define_update_price,
+	ExtName_StorageHandler_exec_consignment,
+	ExtName_StorageHandler_exec_order,
+	ExtName_StorageHandler_expanding_territory,
+	ExtName_StorageHandler_mint_territory,
+	ExtName_StorageHandler_reactivate_territory,
+	ExtName_StorageHandler_renewal_territory,
+	ExtName_StorageHandler_territory_consignment,
+	ExtName_StorageHandler_territory_grants,
+	ExtName_StorageHandler_territory_rename,
+	ExtName_StorageHandler_update_expired_exec,
+	ExtName_StorageHandler_update_price,
+	ExtName_StorageHandler_update_user_territory_life,
 
 	// Sudo
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sudo_remove_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sudo_remove_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sudo_set_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sudo_set_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sudo_sudo); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sudo_sudo
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sudo_sudo_as); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sudo_sudo_as
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sudo_sudo_unchecked_weight); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sudo_sudo_unchecked_weight
-	} else {
-		return err
-	}
+	ExtName_Sudo_remove_key,
+	ExtName_Sudo_set_key,
+	ExtName_Sudo_sudo,
+	ExtName_Sudo_sudo_as,
+	ExtName_Sudo_sudo_unchecked_weight,
 
 	// System
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_apply_authorized_upgrade); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_apply_authorized_upgrade
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_authorize_upgrade); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_authorize_upgrade
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_authorize_upgrade_without_checks); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_authorize_upgrade_without_checks
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_kill_prefix); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_kill_prefix
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_kill_storage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_kill_storage
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_remark); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_remark
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_remark_with_event); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_remark_with_event
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_set_code); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_set_code
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_set_code_without_checks); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_set_code_without_checks
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_set_heap_pages); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_set_heap_pages
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_System_set_storage); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_System_set_storage
-	} else {
-		return err
-	}
+	ExtName_System_apply_authorized_upgrade,
+	ExtName_System_authorize_upgrade,
+	ExtName_System_authorize_upgrade_without_checks,
+	ExtName_System_kill_prefix,
+	ExtName_System_kill_storage,
+	ExtName_System_remark,
+	ExtName_System_remark_with_event,
+	ExtName_System_set_code,
+	ExtName_System_set_code_without_checks,
+	ExtName_System_set_heap_pages,
+	ExtName_System_set_storage,
 
 	// TechnicalCommittee
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_close); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_close
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_disapprove_proposal); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_disapprove_proposal
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_execute); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_execute
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_propose); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_propose
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_set_members); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_set_members
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TechnicalCommittee_vote); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TechnicalCommittee_vote
-	} else {
-		return err
-	}
+	ExtName_TechnicalCommittee_close,
+	ExtName_TechnicalCommittee_disapprove_proposal,
+	ExtName_TechnicalCommittee_execute,
+	ExtName_TechnicalCommittee_propose,
+	ExtName_TechnicalCommittee_set_members,
+	ExtName_TechnicalCommittee_vote,
 
 	// TeeWorker
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_add_ceseal); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_add_ceseal
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_apply_master_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_apply_master_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_change_first_holder); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_change_first_holder
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_clear_master_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_clear_master_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_force_clear_tee); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_force_clear_tee
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_force_register_worker); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_force_register_worker
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_launch_master_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_launch_master_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_migration_last_work); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_migration_last_work
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_patch_clear_invalid_tee); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_patch_clear_invalid_tee
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_patch_clear_not_work_tee); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_patch_clear_not_work_tee
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_refresh_tee_status); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_refresh_tee_status
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_register_worker); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_register_worker
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_register_worker_v2); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_register_worker_v2
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_remove_ceseal); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_remove_ceseal
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_set_minimum_ceseal_version); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_set_minimum_ceseal_version
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_set_note_stalled); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_set_note_stalled
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TeeWorker_update_worker_endpoint); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TeeWorker_update_worker_endpoint
-	} else {
-		return err
-	}
+	ExtName_TeeWorker_add_ceseal,
+	ExtName_TeeWorker_apply_master_key,
+	ExtName_TeeWorker_change_first_holder,
+	ExtName_TeeWorker_clear_master_key,
+	ExtName_TeeWorker_force_clear_tee,
+	ExtName_TeeWorker_force_register_worker,
+	ExtName_TeeWorker_launch_master_key,
+	ExtName_TeeWorker_migration_last_work,
+	ExtName_TeeWorker_patch_clear_invalid_tee,
+	ExtName_TeeWorker_patch_clear_not_work_tee,
+	ExtName_TeeWorker_refresh_tee_status,
+	ExtName_TeeWorker_register_worker,
+	ExtName_TeeWorker_register_worker_v2,
+	ExtName_TeeWorker_remove_ceseal,
+	ExtName_TeeWorker_set_minimum_ceseal_version,
+	ExtName_TeeWorker_set_note_stalled,
+	ExtName_TeeWorker_update_worker_endpoint,
 
 	// Timestamp
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Timestamp_set); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Timestamp_set
-	} else {
-		return err
-	}
+	ExtName_Timestamp_set,
 
 	// TransactionStorage
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TransactionStorage_check_proof); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TransactionStorage_check_proof
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TransactionStorage_renew); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TransactionStorage_renew
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_TransactionStorage_store); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_TransactionStorage_store
-	} else {
-		return err
-	}
+	ExtName_TransactionStorage_check_proof,
+	ExtName_TransactionStorage_renew,
+	ExtName_TransactionStorage_store,
 
 	// Treasury
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_check_status); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_check_status
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_payout); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_payout
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_remove_approval); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_remove_approval
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_spend); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_spend
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_spend_local); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_spend_local
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Treasury_void_spend); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Treasury_void_spend
-	} else {
-		return err
-	}
+	ExtName_Treasury_check_status,
+	ExtName_Treasury_payout,
+	ExtName_Treasury_remove_approval,
+	ExtName_Treasury_spend,
+	ExtName_Treasury_spend_local,
+	ExtName_Treasury_void_spend,
 
 	// Utility
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_as_derivative); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_as_derivative
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_batch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_batch
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_batch_all); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_batch_all
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_dispatch_as); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_dispatch_as
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_force_batch); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_force_batch
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Utility_with_weight); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Utility_with_weight
-	} else {
-		return err
-	}
+	ExtName_Utility_as_derivative,
+	ExtName_Utility_batch,
+	ExtName_Utility_batch_all,
+	ExtName_Utility_dispatch_as,
+	ExtName_Utility_force_batch,
+	ExtName_Utility_with_weight,
 
 	// VoterList
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_VoterList_put_in_front_of); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_VoterList_put_in_front_of
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_VoterList_put_in_front_of_other); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_VoterList_put_in_front_of_other
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_VoterList_rebag); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_VoterList_rebag
-	} else {
-		return err
-	}
-	return nil
+	ExtName_VoterList_put_in_front_of,
+	ExtName_VoterList_put_in_front_of_other,
+	ExtName_VoterList_rebag,
 }
 
-// InitExtrinsicsNameForMiner initialises all transaction required by the storage miner
-//
-// Return:
-//   - error: error message
-//
-// Note:
-//   - The storage miner program needs to call this function, otherwise the transaction event cannot be parsed.
-func (c *ChainClient) InitExtrinsicsNameForMiner() error {
-	ExtrinsicsName = make(map[types.CallIndex]string, 0)
-
+// minerExtrinsicNames are the transactions named by InitExtrinsicsNameForMiner
+var minerExtrinsicNames = []string{
 	// Audit
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_idle_proof); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_idle_proof
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_service_proof); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_service_proof
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_verify_idle_result); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_verify_idle_result
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Audit_submit_verify_service_result); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Audit_submit_verify_service_result
-	} else {
-		return err
-	}
+	ExtName_Audit_submit_idle_proof,
+	ExtName_Audit_submit_service_proof,
+	ExtName_Audit_submit_verify_idle_result,
+	ExtName_Audit_submit_verify_service_result,
 
 	// FileBank
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_calculate_report); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_calculate_report
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_cert_idle_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_cert_idle_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_claim_restoral_noexist_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_claim_restoral_noexist_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_claim_restoral_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_claim_restoral_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_generate_restoral_order); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_generate_restoral_order
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_replace_idle_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_replace_idle_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_restoral_order_complete); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_restoral_order_complete
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_transfer_report); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_transfer_report
-	} else {
-		return err
-	}
+	ExtName_FileBank_calculate_report,
+	ExtName_FileBank_cert_idle_space,
+	ExtName_FileBank_claim_restoral_noexist_order,
+	ExtName_FileBank_claim_restoral_order,
+	ExtName_FileBank_generate_restoral_order,
+	ExtName_FileBank_replace_idle_space,
+	ExtName_FileBank_restoral_order_complete,
+	ExtName_FileBank_transfer_report,
 
 	// Sminer
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_decrease_declaration_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_decrease_declaration_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_faucet); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_faucet
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_faucet_top_up); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_faucet_top_up
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_increase_collateral); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_increase_collateral
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_increase_declaration_space); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_increase_declaration_space
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_exit); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_exit
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_exit_prep); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_exit_prep
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_miner_withdraw); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_miner_withdraw
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_receive_reward); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_receive_reward
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_register_pois_key); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_register_pois_key
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_regnstk); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_regnstk
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_regnstk_assign_staking); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_regnstk_assign_staking
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_update_beneficiary); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_update_beneficiary
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Sminer_update_endpoint); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Sminer_update_endpoint
-	} else {
-		return err
-	}
+	ExtName_Sminer_decrease_declaration_space,
+	ExtName_Sminer_faucet,
+	ExtName_Sminer_faucet_top_up,
+	ExtName_Sminer_increase_collateral,
+	ExtName_Sminer_increase_declaration_space,
+	ExtName_Sminer_miner_exit,
+	ExtName_Sminer_miner_exit_prep,
+	ExtName_Sminer_miner_withdraw,
+	ExtName_Sminer_receive_reward,
+	ExtName_Sminer_register_pois_key,
+	ExtName_Sminer_regnstk,
+	ExtName_Sminer_regnstk_assign_staking,
+	ExtName_Sminer_update_beneficiary,
+	ExtName_Sminer_update_endpoint,
 
 	// Timestamp
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Timestamp_set); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Timestamp_set
-	} else {
-		return err
-	}
-
-	return nil
+	ExtName_Timestamp_set,
 }
 
-// InitExtrinsicsNameForOSS initialises all transaction required by the deoss
-//
-// Return:
-//   - error: error message
-//
-// Note:
-//   - The deoss program needs to call this function, otherwise the transaction event cannot be parsed.
-func (c *ChainClient) InitExtrinsicsNameForOSS() error {
-	ExtrinsicsName = make(map[types.CallIndex]string, 0)
-
+// ossExtrinsicNames are the transactions named by InitExtrinsicsNameForOSS
+var ossExtrinsicNames = []string{
 	// FileBank
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_delete_file); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_delete_file
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_upload_declaration); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_upload_declaration
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_FileBank_territory_file_delivery); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_FileBank_territory_file_delivery
-	} else {
-		return err
-	}
+	ExtName_FileBank_delete_file,
+	ExtName_FileBank_upload_declaration,
+	ExtName_FileBank_territory_file_delivery,
 
 	// Oss
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_destroy); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_destroy
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_register); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_register
-	} else {
-		return err
-	}
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Oss_update); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Oss_update
-	} else {
-		return err
-	}
+	ExtName_Oss_destroy,
+	ExtName_Oss_register,
+	ExtName_Oss_update,
 
 	// Timestamp
-	if callIndex, err := c.GetMetadata().FindCallIndex(ExtName_Timestamp_set); err == nil {
-		ExtrinsicsName[callIndex] = ExtName_Timestamp_set
-	} else {
-		return err
-	}
-	return nil
+	ExtName_Timestamp_set,
 }
@@ -0,0 +1,44 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitExtrinsicsNameSkipsMissing(t *testing.T) {
+	extrinsicsNameLock.Lock()
+	names := ExtrinsicsName
+	ExtrinsicsName = map[types.CallIndex]string{{SectionIndex: 1}: ExtName_Oss_authorize}
+	extrinsicsNameLock.Unlock()
+	defer func() {
+		extrinsicsNameLock.Lock()
+		ExtrinsicsName = names
+		extrinsicsNameLock.Unlock()
+	}()
+
+	meta := testCallMetadata()
+	meta.Version = 14
+	c := &ChainClient{clientState: &clientState{}}
+	c.runtime.Store(&runtimeState{metadata: meta})
+
+	err := c.initExtrinsicsName([]string{ExtName_Balances_transferKeepAlive, ExtName_Oss_authorize}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), ExtName_Oss_authorize)
+
+	// the names found in the metadata replace the previous names
+	name, ok := LookupExtrinsicName(types.CallIndex{SectionIndex: 5, MethodIndex: 3})
+	assert.True(t, ok)
+	assert.Equal(t, ExtName_Balances_transferKeepAlive, name)
+	_, ok = LookupExtrinsicName(types.CallIndex{SectionIndex: 1})
+	assert.False(t, ok)
+}
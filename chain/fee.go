@@ -0,0 +1,269 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/scale"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// variants of frame_support::dispatch::DispatchClass
+var dispatchClasses = []string{"Normal", "Operational", "Mandatory"}
+
+// variants of sp_runtime::transaction_validity::InvalidTransaction
+var invalidTransactions = []string{
+	"Call", "Payment", "Future", "Stale", "BadProof", "AncientBirthBlock",
+	"ExhaustsResources", "Custom", "BadMandatory", "MandatoryValidation", "BadSigner",
+}
+
+// variants of sp_runtime::transaction_validity::UnknownTransaction
+var unknownTransactions = []string{"CannotLookup", "NoUnsignedValidator", "Custom"}
+
+// FeeInfo is the fee of a transaction estimated by the runtime
+type FeeInfo struct {
+	// weight of the call
+	Weight types.Weight
+	// dispatch class of the call: Normal, Operational or Mandatory
+	Class string
+	// fee of the transaction without the tip, in the smallest unit
+	PartialFee types.U128
+}
+
+type runtimeDispatchInfo struct {
+	Weight     types.Weight
+	Class      types.U8
+	PartialFee types.U128
+}
+
+// TxValidityError is the reason why the transaction pool rejects a transaction,
+// e.g. Invalid(Payment) if the signer cannot pay the fee
+type TxValidityError struct {
+	// Invalid or Unknown
+	Kind string
+	// variant of the inner error, e.g. Payment, Stale or Custom(3)
+	Detail string
+}
+
+// Error returns the description of the validity error
+func (e *TxValidityError) Error() string {
+	return fmt.Sprintf("%s(%s)", e.Kind, e.Detail)
+}
+
+// EstimateFee estimates the fee of the call with TransactionPaymentApi_query_info,
+// the call is signed like a submitted transaction but is not submitted
+//   - call: the call to estimate, built with the BuildCall option
+//   - opts: transaction options, e.g. the signer, the tip and the proxy
+//
+// Return:
+//   - FeeInfo: weight, class and fee of the transaction
+//   - error: error message
+func (c *ChainClient) EstimateFee(call types.Call, opts ...TxOption) (FeeInfo, error) {
+	ext, _, err := c.preflightExtrinsic(call, opts)
+	if err != nil {
+		return FeeInfo{}, err
+	}
+	return c.queryFeeInfo(ext)
+}
+
+// DryRun dispatches the call with system_dryRun on the state of the latest block,
+// the node must expose the unsafe rpc methods
+//   - call: the call to dry run, built with the BuildCall option
+//   - opts: transaction options, e.g. the signer, the tip and the proxy
+//
+// Return:
+//   - error: nil if the call would succeed, a *DispatchError if the call would fail,
+//     a *TxValidityError if the transaction would be rejected
+func (c *ChainClient) DryRun(call types.Call, opts ...TxOption) error {
+	ext, _, err := c.preflightExtrinsic(call, opts)
+	if err != nil {
+		return err
+	}
+	return c.dryRun(ext)
+}
+
+// preflightExtrinsic signs the call with the nonce of the signer in the latest block
+func (c *ChainClient) preflightExtrinsic(call types.Call, opts []TxOption) (types.Extrinsic, types.AccountInfo, error) {
+	txOpts, err := newTxOptions(c.defaultTxOptions(), opts)
+	if err != nil {
+		return types.Extrinsic{}, types.AccountInfo{}, err
+	}
+	if call, err = c.txCall(call, txOpts); err != nil {
+		return types.Extrinsic{}, types.AccountInfo{}, err
+	}
+	txSigner, err := c.txSigner(txOpts)
+	if err != nil {
+		return types.Extrinsic{}, types.AccountInfo{}, err
+	}
+	return c.signPreflight(call, txSigner, txOpts)
+}
+
+func (c *ChainClient) signPreflight(call types.Call, s signer.Signer, txOpts TxOptions) (types.Extrinsic, types.AccountInfo, error) {
+	if c.at != nil {
+		return types.Extrinsic{}, types.AccountInfo{}, ERR_PinnedClient
+	}
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return types.Extrinsic{}, types.AccountInfo{}, ERR_RPC_CONNECTION
+		}
+	}
+	account, err := c.QueryAccountInfoByAccountID(signer.AccountID(s), LatestBlock())
+	if err != nil {
+		if !errors.Is(err, ERR_RPC_EMPTY_VALUE) {
+			return types.Extrinsic{}, account, errors.Wrap(err, "[QueryAccountInfoByAccountID]")
+		}
+		account.Data.Free = types.NewU128(*big.NewInt(0))
+	}
+	era, err := c.newTxEra(txOpts.Lifetime)
+	if err != nil {
+		return types.Extrinsic{}, account, errors.Wrap(err, "[newTxEra]")
+	}
+	tip := types.NewUCompactFromUInt(0)
+	if txOpts.Tip != nil {
+		tip = types.NewUCompact(txOpts.Tip)
+	}
+	ext, err := c.newSignedExtrinsic(call, s, c.runtime.Load().version, era, tip, uint64(account.Nonce))
+	if err != nil {
+		return ext, account, errors.Wrap(err, "[newSignedExtrinsic]")
+	}
+	return ext, account, nil
+}
+
+// preflight checks that the transaction would succeed and that the signer can pay for it
+func (c *ChainClient) preflight(call types.Call, s signer.Signer, txOpts TxOptions) error {
+	ext, account, err := c.signPreflight(call, s, txOpts)
+	if err != nil {
+		return err
+	}
+	fee, err := c.queryFeeInfo(ext)
+	if err != nil {
+		return err
+	}
+	need := new(big.Int).Set(fee.PartialFee.Int)
+	if txOpts.Tip != nil {
+		need.Add(need, txOpts.Tip)
+	}
+	if account.Data.Free.Cmp(need) < 0 {
+		return fmt.Errorf("%w: free %s, need %s", ERR_InsufficientFee, account.Data.Free.String(), need.String())
+	}
+	return c.dryRun(ext)
+}
+
+func (c *ChainClient) queryFeeInfo(ext types.Extrinsic) (FeeInfo, error) {
+	encoded, err := codec.Encode(ext)
+	if err != nil {
+		return FeeInfo{}, errors.Wrap(err, "[Encode extrinsic]")
+	}
+	// query_info(uxt: Block::Extrinsic, len: u32)
+	args := binary.LittleEndian.AppendUint32(encoded, uint32(len(encoded)))
+
+	var res string
+	err = c.api.Client.Call(&res, RPC_State_call, RuntimeApi_TransactionPayment_queryInfo, hexutil.Encode(args))
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return FeeInfo{}, fmt.Errorf("rpc err: [%s] [rpc_call] [%s] %v", c.GetCurrentRpcAddr(), RuntimeApi_TransactionPayment_queryInfo, err)
+	}
+	var info runtimeDispatchInfo
+	if err = codec.DecodeFromHex(res, &info); err != nil {
+		return FeeInfo{}, errors.Wrap(err, "[Decode RuntimeDispatchInfo]")
+	}
+	class := fmt.Sprint(info.Class)
+	if int(info.Class) < len(dispatchClasses) {
+		class = dispatchClasses[info.Class]
+	}
+	return FeeInfo{Weight: info.Weight, Class: class, PartialFee: info.PartialFee}, nil
+}
+
+func (c *ChainClient) dryRun(ext types.Extrinsic) error {
+	encoded, err := codec.EncodeToHex(ext)
+	if err != nil {
+		return errors.Wrap(err, "[Encode extrinsic]")
+	}
+	var res string
+	if err = c.api.Client.Call(&res, RPC_SYS_DryRun, encoded); err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return fmt.Errorf("rpc err: [%s] [rpc_call] [%s] %v", c.GetCurrentRpcAddr(), RPC_SYS_DryRun, err)
+	}
+	data, err := hexutil.Decode(res)
+	if err != nil {
+		return errors.Wrap(err, "[Decode dry run result]")
+	}
+	d, err := newDispatchErrorDecoder(c.GetMetadata())
+	if err != nil {
+		return err
+	}
+	return decodeApplyExtrinsicResult(data, d)
+}
+
+// decodeApplyExtrinsicResult decodes
+// Result<Result<(), DispatchError>, TransactionValidityError>,
+// it returns nil if the extrinsic was dispatched successfully
+func decodeApplyExtrinsicResult(data []byte, d *dispatchErrorDecoder) error {
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+	outer, err := decoder.ReadOneByte()
+	if err != nil {
+		return errors.Wrap(err, "[Decode ApplyExtrinsicResult]")
+	}
+	switch outer {
+	case 0:
+		inner, err := decoder.ReadOneByte()
+		if err != nil {
+			return errors.Wrap(err, "[Decode DispatchOutcome]")
+		}
+		if inner == 0 {
+			return nil
+		}
+		v, err := d.Decode(decoder)
+		if err != nil {
+			return errors.Wrap(err, "[Decode DispatchError]")
+		}
+		return v.(*DispatchError)
+	case 1:
+		kind, err := decoder.ReadOneByte()
+		if err != nil {
+			return errors.Wrap(err, "[Decode TransactionValidityError]")
+		}
+		variants := invalidTransactions
+		result := &TxValidityError{Kind: "Invalid"}
+		if kind == 1 {
+			variants = unknownTransactions
+			result.Kind = "Unknown"
+		} else if kind != 0 {
+			return fmt.Errorf("unknown transaction validity error %d", kind)
+		}
+		index, err := decoder.ReadOneByte()
+		if err != nil {
+			return errors.Wrap(err, "[Decode TransactionValidityError]")
+		}
+		if int(index) >= len(variants) {
+			return fmt.Errorf("unknown transaction validity error %s(%d)", result.Kind, index)
+		}
+		result.Detail = variants[index]
+		if result.Detail == "Custom" {
+			code, err := decoder.ReadOneByte()
+			if err != nil {
+				return errors.Wrap(err, "[Decode TransactionValidityError]")
+			}
+			result.Detail = fmt.Sprintf("Custom(%d)", code)
+		}
+		return result
+	}
+	return fmt.Errorf("unknown apply extrinsic result %d", outer)
+}
@@ -0,0 +1,35 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeApplyExtrinsicResult(t *testing.T) {
+	d := &dispatchErrorDecoder{lookup: testDispatchErrorLookup(), typeID: 0}
+	tests := []struct {
+		input  []byte
+		expect error
+	}{
+		{[]byte{0, 0}, nil},
+		{[]byte{0, 1, 3, 12, 5, 0, 0, 0}, &DispatchError{Kind: "Module", ModuleIndex: 12, ErrorIndex: [4]byte{5, 0, 0, 0}}},
+		{[]byte{0, 1, 7, 0}, &DispatchError{Kind: "Token", Detail: "FundsUnavailable"}},
+		{[]byte{1, 0, 1}, &TxValidityError{Kind: "Invalid", Detail: "Payment"}},
+		{[]byte{1, 0, 7, 3}, &TxValidityError{Kind: "Invalid", Detail: "Custom(3)"}},
+		{[]byte{1, 1, 0}, &TxValidityError{Kind: "Unknown", Detail: "CannotLookup"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expect, decodeApplyExtrinsicResult(tt.input, d))
+	}
+
+	assert.Error(t, decodeApplyExtrinsicResult([]byte{2}, d))
+	assert.Error(t, decodeApplyExtrinsicResult([]byte{1, 0, 20}, d))
+}
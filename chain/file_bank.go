@@ -14,632 +14,188 @@ import (
 	"path/filepath"
 
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
 	"github.com/CESSProject/cess-go-sdk/utils"
 	"github.com/pkg/errors"
 )
 
 // QueryDealMap query file storage order
 //   - fid: file identification
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - StorageOrder: file storage order
 //   - error: error message
-func (c *ChainClient) QueryDealMap(fid string, block int32) (StorageOrder, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return StorageOrder{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, DealMap, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryDealMap(fid string, block BlockRef) (StorageOrder, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var (
-		data StorageOrder
-		hash FileHash
-	)
-
-	if len(fid) != FileHashLen {
-		return data, errors.New("invalid filehash")
-	}
-
-	for i := 0; i < len(hash); i++ {
-		hash[i] = types.U8(fid[i])
-	}
-
-	param_hash, err := codec.Encode(hash)
-	if err != nil {
-		return data, errors.Wrap(err, "[Encode]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, DealMap, param_hash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		return data, err
-	}
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
+	hash, err := BytesToFileHash([]byte(fid))
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		c.SetRpcState(false)
-		return data, err
+		return StorageOrder{}, errors.New("invalid filehash")
 	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[StorageOrder](c.ctx, c, FileBank, DealMap, block, hash)
 }
 
 // QueryDealMap query file storage order
 //   - fid: file identification
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - StorageOrderV1: file storage order
 //   - error: error message
-func (c *ChainClient) QueryDealMapV1(fid string, block int32) (StorageOrderV1, error) {
-	if !c.GetRpcState() {
-		err := c.ReconnectRpc()
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, DealMap, ERR_RPC_CONNECTION.Error())
-			return StorageOrderV1{}, err
-		}
-	}
-
+func (c *ChainClient) QueryDealMapV1(fid string, block BlockRef) (StorageOrderV1, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var (
-		data StorageOrderV1
-		hash FileHash
-	)
-
-	if len(fid) != FileHashLen {
-		return data, errors.New("invalid filehash")
-	}
-
-	for i := 0; i < len(hash); i++ {
-		hash[i] = types.U8(fid[i])
-	}
-
-	param_hash, err := codec.Encode(hash)
-	if err != nil {
-		return data, errors.Wrap(err, "[Encode]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, DealMap, param_hash)
+	hash, err := BytesToFileHash([]byte(fid))
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		return data, err
+		return StorageOrderV1{}, errors.New("invalid filehash")
 	}
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		c.SetRpcState(false)
-		return data, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[StorageOrderV1](c.ctx, c, FileBank, DealMap, block, hash)
 }
 
 // QueryDealMapList query file storage order list
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
-//   - []StorageOrder: file storage order list
+//   - []KeyValue[string, StorageOrder]: fids with their storage orders
 //   - error: error message
-func (c *ChainClient) QueryDealMapList(block int32) ([]StorageOrder, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []StorageOrder{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, DealMap, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryDealMapList(block BlockRef) ([]KeyValue[string, StorageOrder], error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var result []StorageOrder
-
-	key := CreatePrefixedKey(FileBank, DealMap)
-	keys, err := c.api.RPC.State.GetKeysLatest(key)
+	orders, err := queryAllEntries[FileHash, StorageOrder](c, FileBank, DealMap, block)
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetKeysLatest: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-		c.SetRpcState(false)
 		return nil, err
 	}
-
-	var set []types.StorageChangeSet
-	if block < 0 {
-		set, err = c.api.RPC.State.QueryStorageAtLatest(keys)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAtLatest: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	} else {
-		blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-		if err != nil {
-			return nil, err
-		}
-		set, err = c.api.RPC.State.QueryStorageAt(keys, blockhash)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAtLatest: %v", c.GetCurrentRpcAddr(), FileBank, DealMap, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	}
-	for _, elem := range set {
-		for _, change := range elem.Changes {
-			var data StorageOrder
-			if err := codec.Decode(change.StorageData, &data); err != nil {
-				continue
-			}
-			result = append(result, data)
-		}
+	result := make([]KeyValue[string, StorageOrder], len(orders))
+	for i, order := range orders {
+		result[i] = KeyValue[string, StorageOrder]{Key: string(order.Key[:]), Value: order.Value}
 	}
 	return result, nil
 }
 
 // QueryFile query file metadata
 //   - fid: file identification
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - FileMetadata: file metadata
 //   - error: error message
-func (c *ChainClient) QueryFile(fid string, block int32) (FileMetadata, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return FileMetadata{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, File, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryFile(fid string, block BlockRef) (FileMetadata, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var (
-		data FileMetadata
-		hash FileHash
-	)
-
-	if len(fid) != FileHashLen {
-		return data, errors.New("invalid filehash")
-	}
-
-	for i := 0; i < len(hash); i++ {
-		hash[i] = types.U8(fid[i])
-	}
-
-	param_hash, err := codec.Encode(hash)
-	if err != nil {
-		return data, errors.Wrap(err, "[Encode]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, File, param_hash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		return data, err
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
+	hash, err := BytesToFileHash([]byte(fid))
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		c.SetRpcState(false)
-		return data, err
+		return FileMetadata{}, errors.New("invalid filehash")
 	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[FileMetadata](c.ctx, c, FileBank, File, block, hash)
 }
 
 // QueryFile query file metadata
 //   - fid: file identification
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - FileMetadataV1: file metadata
 //   - error: error message
-func (c *ChainClient) QueryFileV1(fid string, block int32) (FileMetadataV1, error) {
-	if !c.GetRpcState() {
-		err := c.ReconnectRpc()
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, File, ERR_RPC_CONNECTION.Error())
-			return FileMetadataV1{}, err
-		}
-	}
-
+func (c *ChainClient) QueryFileV1(fid string, block BlockRef) (FileMetadataV1, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var (
-		data FileMetadataV1
-		hash FileHash
-	)
-
-	if len(fid) != FileHashLen {
-		return data, errors.New("invalid filehash")
-	}
-
-	for i := 0; i < len(hash); i++ {
-		hash[i] = types.U8(fid[i])
-	}
-
-	param_hash, err := codec.Encode(hash)
+	hash, err := BytesToFileHash([]byte(fid))
 	if err != nil {
-		return data, errors.Wrap(err, "[Encode]")
+		return FileMetadataV1{}, errors.New("invalid filehash")
 	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, File, param_hash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		return data, err
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHash: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, File, err)
-		c.SetRpcState(false)
-		return data, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[FileMetadataV1](c.ctx, c, FileBank, File, block, hash)
 }
 
 // QueryRestoralOrder query file restoral order
 //   - fragmentHash: fragment hash
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - RestoralOrderInfo: restoral order info
 //   - error: error message
-func (c *ChainClient) QueryRestoralOrder(fragmentHash string, block int32) (RestoralOrderInfo, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return RestoralOrderInfo{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryRestoralOrder(fragmentHash string, block BlockRef) (RestoralOrderInfo, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var (
-		data RestoralOrderInfo
-		hash FileHash
-	)
-
-	if len(fragmentHash) != FileHashLen {
-		return data, errors.New("invalid fragment hash")
-	}
-
-	for i := 0; i < len(hash); i++ {
-		hash[i] = types.U8(fragmentHash[i])
-	}
-
-	param_hash, err := codec.Encode(hash)
-	if err != nil {
-		return data, errors.Wrap(err, "[Encode]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, RestoralOrder, param_hash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-		return data, err
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
+	hash, err := BytesToFileHash([]byte(fragmentHash))
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-		c.SetRpcState(false)
-		return data, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
+		return RestoralOrderInfo{}, errors.New("invalid fragment hash")
 	}
-	return data, nil
+	return QueryStorage[RestoralOrderInfo](c.ctx, c, FileBank, RestoralOrder, block, hash)
 }
 
 // QueryAllRestoralOrder query all file restoral order
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - []RestoralOrderInfo: all restoral order info
 //   - error: error message
-func (c *ChainClient) QueryAllRestoralOrder(block int32) ([]RestoralOrderInfo, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []RestoralOrderInfo{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryAllRestoralOrder(block BlockRef) ([]RestoralOrderInfo, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var result []RestoralOrderInfo
-
-	key := CreatePrefixedKey(FileBank, RestoralOrder)
-	keys, err := c.api.RPC.State.GetKeysLatest(key)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetKeysLatest: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-		c.SetRpcState(false)
-		return nil, err
-	}
-	var set []types.StorageChangeSet
-	if block < 0 {
-		set, err = c.api.RPC.State.QueryStorageAtLatest(keys)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAtLatest: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	} else {
-		blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-		if err != nil {
-			return nil, err
-		}
-		set, err = c.api.RPC.State.QueryStorageAt(keys, blockhash)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAt: %v", c.GetCurrentRpcAddr(), FileBank, RestoralOrder, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	}
-
-	for _, elem := range set {
-		for _, change := range elem.Changes {
-			var data RestoralOrderInfo
-			if err := codec.Decode(change.StorageData, &data); err != nil {
-				continue
-			}
-			result = append(result, data)
-		}
-	}
-	return result, nil
+	return queryAllValues[RestoralOrderInfo](c, FileBank, RestoralOrder, block)
 }
 
 // QueryUserHoldFileList query user's all files
 //   - accountID: user account
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - []UserFileSliceInfo: file list
 //   - error: error message
-func (c *ChainClient) QueryUserHoldFileList(accountID []byte, block int32) ([]UserFileSliceInfo, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []UserFileSliceInfo{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryUserHoldFileList(accountID []byte, block BlockRef) ([]UserFileSliceInfo, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data []UserFileSliceInfo
-
 	acc, err := types.NewAccountID(accountID)
 	if err != nil {
 		return nil, errors.Wrap(err, "[NewAccountID]")
 	}
-
-	owner, err := codec.Encode(*acc)
-	if err != nil {
-		return nil, errors.Wrap(err, "[EncodeToBytes]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, UserHoldFileList, owner)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
-		return nil, err
-	}
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return nil, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
-		c.SetRpcState(false)
-		return nil, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[[]UserFileSliceInfo](c.ctx, c, FileBank, UserHoldFileList, block, *acc)
 }
 
 // QueryUserFidList query user's all fid
 //   - accountID: user account
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - []string: all fid
 //   - error: error message
-func (c *ChainClient) QueryUserFidList(accountID []byte, block int32) ([]string, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []string{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
-	defer func() {
-		if err := recover(); err != nil {
-			log.Println(utils.RecoverError(err))
-		}
-	}()
-
-	var data []UserFileSliceInfo
-
-	acc, err := types.NewAccountID(accountID)
-	if err != nil {
-		return nil, errors.Wrap(err, "[NewAccountID]")
-	}
-
-	owner, err := codec.Encode(*acc)
-	if err != nil {
-		return nil, errors.Wrap(err, "[EncodeToBytes]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, FileBank, UserHoldFileList, owner)
+func (c *ChainClient) QueryUserFidList(accountID []byte, block BlockRef) ([]string, error) {
+	data, err := c.QueryUserHoldFileList(accountID, block)
 	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
 		return nil, err
 	}
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-		if !ok {
-			return []string{}, ERR_RPC_EMPTY_VALUE
-		}
-		var value = make([]string, len(data))
-		for i := 0; i < len(data); i++ {
-			value[i] = string(data[i].Filehash[:])
-		}
-		return value, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return nil, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorage: %v", c.GetCurrentRpcAddr(), FileBank, UserHoldFileList, err)
-		c.SetRpcState(false)
-		return nil, err
-	}
-	if !ok {
-		return []string{}, ERR_RPC_EMPTY_VALUE
-	}
 	var value = make([]string, len(data))
 	for i := 0; i < len(data); i++ {
 		value[i] = string(data[i].Filehash[:])
@@ -656,9 +212,9 @@ func (c *ChainClient) QueryUserFidList(accountID []byte, block int32) ([]string,
 //   - filesize: file size
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
-func (c *ChainClient) PlaceStorageOrder(fid, file_name, territory_name string, segment []SegmentDataInfo, owner []byte, file_size uint64) (string, error) {
+func (c *ChainClient) PlaceStorageOrder(fid, file_name, territory_name string, segment []SegmentDataInfo, owner []byte, file_size uint64, opts ...TxOption) (TxReceipt, error) {
 	var err error
 	var segmentList = make([]SegmentList, len(segment))
 	var user UserBrief
@@ -679,12 +235,12 @@ func (c *ChainClient) PlaceStorageOrder(fid, file_name, territory_name string, s
 
 	acc, err := types.NewAccountID(owner)
 	if err != nil {
-		return "", err
+		return TxReceipt{}, err
 	}
 	user.User = *acc
 	user.FileName = types.NewBytes([]byte(file_name))
 	user.TerriortyName = types.NewBytes([]byte(territory_name))
-	return c.UploadDeclaration(fid, segmentList, user, file_size)
+	return c.UploadDeclaration(fid, segmentList, user, file_size, opts...)
 }
 
 // GenerateStorageOrder generate a file storage order
@@ -695,12 +251,10 @@ func (c *ChainClient) PlaceStorageOrder(fid, file_name, territory_name string, s
 //   - filesize: file size
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
-func (c *ChainClient) UploadDeclaration(fid string, segment []SegmentList, user UserBrief, filesize uint64) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) UploadDeclaration(fid string, segment []SegmentList, user UserBrief, filesize uint64, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -709,26 +263,26 @@ func (c *ChainClient) UploadDeclaration(fid string, segment []SegmentList, user
 	var hash FileHash
 
 	if len(fid) != FileHashLen {
-		return "", errors.New("invalid filehash")
+		return TxReceipt{}, errors.New("invalid filehash")
 	}
 	if filesize <= 0 {
-		return "", errors.New("invalid filesize")
+		return TxReceipt{}, errors.New("invalid filesize")
 	}
 	for i := 0; i < len(hash); i++ {
 		hash[i] = types.U8(fid[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_upload_declaration, hash, segment, user, types.NewU128(*new(big.Int).SetUint64(filesize)))
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_upload_declaration, hash, segment, user, types.NewU128(*new(big.Int).SetUint64(filesize)))
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_upload_declaration, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_upload_declaration, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_upload_declaration)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_upload_declaration, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_upload_declaration, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_upload_declaration, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // DeleteFile delete a bucket for owner
@@ -736,27 +290,25 @@ func (c *ChainClient) UploadDeclaration(fid string, segment []SegmentList, user
 //   - fid: file identification
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - if you are not the owner, the owner account must be authorised to you
-func (c *ChainClient) DeleteFile(owner []byte, fid string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) DeleteFile(owner []byte, fid string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
 	if len(fid) != FileHashLen {
-		return "", errors.New("invalid fid")
+		return TxReceipt{}, errors.New("invalid fid")
 	}
 
 	acc, err := types.NewAccountID(owner)
 	if err != nil {
-		return "", errors.Wrap(err, "[NewAccountID]")
+		return TxReceipt{}, errors.Wrap(err, "[NewAccountID]")
 	}
 
 	var fhash FileHash
@@ -764,17 +316,17 @@ func (c *ChainClient) DeleteFile(owner []byte, fid string) (string, error) {
 		fhash[i] = types.U8(fid[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_delete_file, *acc, fhash)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_delete_file, *acc, fhash)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_delete_file, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_delete_file, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_delete_file)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_delete_file, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_delete_file, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_delete_file, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // TransferReport is used by miners to report that a file has been transferred
@@ -782,22 +334,20 @@ func (c *ChainClient) DeleteFile(owner []byte, fid string) (string, error) {
 //   - fid: file identification
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) TransferReport(index uint8, fid string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) TransferReport(index uint8, fid string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
 	if index <= 0 || int(index) > (DataShards+ParShards) {
-		return "", errors.New("invalid index")
+		return TxReceipt{}, errors.New("invalid index")
 	}
 
 	var fhash FileHash
@@ -806,17 +356,17 @@ func (c *ChainClient) TransferReport(index uint8, fid string) (string, error) {
 		fhash[j] = types.U8(fid[j])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_transfer_report, types.NewU8(index), fhash)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_transfer_report, types.NewU8(index), fhash)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_transfer_report, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_transfer_report, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_transfer_report)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_transfer_report, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_transfer_report, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_transfer_report, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // GenerateRestoralOrder generate restoral orders for file fragment
@@ -824,15 +374,13 @@ func (c *ChainClient) TransferReport(index uint8, fid string) (string, error) {
 //   - fragmentHash: fragment hash
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) GenerateRestoralOrder(fid, fragmentHash string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) GenerateRestoralOrder(fid, fragmentHash string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -842,11 +390,11 @@ func (c *ChainClient) GenerateRestoralOrder(fid, fragmentHash string) (string, e
 	var fragh FileHash
 
 	if len(fid) != FileHashLen {
-		return "", errors.New("invalid file hash")
+		return TxReceipt{}, errors.New("invalid file hash")
 	}
 
 	if len(fragmentHash) != FileHashLen {
-		return "", errors.New("invalid fragment hash")
+		return TxReceipt{}, errors.New("invalid fragment hash")
 	}
 
 	for i := 0; i < len(fid); i++ {
@@ -857,39 +405,37 @@ func (c *ChainClient) GenerateRestoralOrder(fid, fragmentHash string) (string, e
 		fragh[i] = types.U8(fragmentHash[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_generate_restoral_order, rooth, fragh)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_generate_restoral_order, rooth, fragh)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_generate_restoral_order, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_generate_restoral_order, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_generate_restoral_order)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_generate_restoral_order, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_generate_restoral_order, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_generate_restoral_order, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // ClaimRestoralOrder claim a restoral order
 //   - fragmentHash: fragment hash
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) ClaimRestoralOrder(fragmentHash string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) ClaimRestoralOrder(fragmentHash string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
 	if len(fragmentHash) != FileHashLen {
-		return "", errors.New("invalid fragment hash")
+		return TxReceipt{}, errors.New("invalid fragment hash")
 	}
 
 	var fragh FileHash
@@ -897,17 +443,17 @@ func (c *ChainClient) ClaimRestoralOrder(fragmentHash string) (string, error) {
 		fragh[i] = types.U8(fragmentHash[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_claim_restoral_order, fragh)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_claim_restoral_order, fragh)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_order, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_order, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_claim_restoral_order)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_claim_restoral_order, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_order, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_order, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // ClaimRestoralNoExistOrder claim the restoral order of an exited storage miner
@@ -916,15 +462,13 @@ func (c *ChainClient) ClaimRestoralOrder(fragmentHash string) (string, error) {
 //   - fragmentHash: fragment hash
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -932,18 +476,18 @@ func (c *ChainClient) ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash st
 
 	acc, err := types.NewAccountID(puk)
 	if err != nil {
-		return "", errors.Wrap(err, "[NewAccountID]")
+		return TxReceipt{}, errors.Wrap(err, "[NewAccountID]")
 	}
 
 	var rooth FileHash
 	var fragh FileHash
 
 	if len(fid) != FileHashLen {
-		return "", errors.New("invalid file hash")
+		return TxReceipt{}, errors.New("invalid file hash")
 	}
 
 	if len(fragmentHash) != FileHashLen {
-		return "", errors.New("invalid fragment hash")
+		return TxReceipt{}, errors.New("invalid fragment hash")
 	}
 
 	for i := 0; i < len(fid); i++ {
@@ -954,32 +498,30 @@ func (c *ChainClient) ClaimRestoralNoExistOrder(puk []byte, fid, fragmentHash st
 		fragh[i] = types.U8(fragmentHash[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_claim_restoral_noexist_order, *acc, rooth, fragh)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_claim_restoral_noexist_order, *acc, rooth, fragh)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_noexist_order, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_noexist_order, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_claim_restoral_noexist_order)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_claim_restoral_noexist_order, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_noexist_order, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_claim_restoral_noexist_order, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // RestoralOrderComplete submits the restored completed order
 //   - fragmentHash: fragment hash
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) RestoralOrderComplete(fragmentHash string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) RestoralOrderComplete(fragmentHash string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -988,24 +530,24 @@ func (c *ChainClient) RestoralOrderComplete(fragmentHash string) (string, error)
 	var fragh FileHash
 
 	if len(fragmentHash) != FileHashLen {
-		return "", errors.New("invalid fragment hash")
+		return TxReceipt{}, errors.New("invalid fragment hash")
 	}
 
 	for i := 0; i < len(fragmentHash); i++ {
 		fragh[i] = types.U8(fragmentHash[i])
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_restoral_order_complete, fragh)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_restoral_order_complete, fragh)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_restoral_order_complete, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_restoral_order_complete, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_restoral_order_complete)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_restoral_order_complete, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_restoral_order_complete, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_restoral_order_complete, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // CertIdleSpace authenticates idle file to the chain
@@ -1015,31 +557,29 @@ func (c *ChainClient) RestoralOrderComplete(fragmentHash string) (string, error)
 //   - teePuk: tee work public key
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) CertIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) CertIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_cert_idle_space, spaceProofInfo, teeSignWithAcc, teeSign, teePuk)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_cert_idle_space, spaceProofInfo, teeSignWithAcc, teeSign, teePuk)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_cert_idle_space, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_cert_idle_space, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_cert_idle_space)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_cert_idle_space, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_cert_idle_space, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_cert_idle_space, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // ReplaceIdleSpace replaces idle files with service files
@@ -1049,31 +589,29 @@ func (c *ChainClient) CertIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAc
 //   - teePuk: tee work public key
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) ReplaceIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) ReplaceIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWithAcc, teeSign types.Bytes, teePuk WorkerPublicKey, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_replace_idle_space, spaceProofInfo, teeSignWithAcc, teeSign, teePuk)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_replace_idle_space, spaceProofInfo, teeSignWithAcc, teeSign, teePuk)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_replace_idle_space, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_replace_idle_space, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_replace_idle_space)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_replace_idle_space, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_replace_idle_space, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_replace_idle_space, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // CalculateReport report file tag calculation completed
@@ -1081,31 +619,29 @@ func (c *ChainClient) ReplaceIdleSpace(spaceProofInfo SpaceProofInfo, teeSignWit
 //   - tagSigInfo: tag sig info
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
 //
 // Note:
 //   - for storage miner use only
-func (c *ChainClient) CalculateReport(teeSig types.Bytes, tagSigInfo TagSigInfo) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) CalculateReport(teeSig types.Bytes, tagSigInfo TagSigInfo, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_calculate_report, teeSig, tagSigInfo)
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_calculate_report, teeSig, tagSigInfo)
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_calculate_report, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_calculate_report, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_calculate_report)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_calculate_report, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_calculate_report, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_calculate_report, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
 
 // TerritoryFileDelivery transfer files to another territory
@@ -1114,12 +650,10 @@ func (c *ChainClient) CalculateReport(teeSig types.Bytes, tagSigInfo TagSigInfo)
 //   - target_territory: transfer to the target territory
 //
 // Return:
-//   - string: block hash
+//   - TxReceipt: transaction receipt
 //   - error: error message
-func (c *ChainClient) TerritoryFileDelivery(user []byte, fid string, target_territory string) (string, error) {
-	<-c.tradeCh
+func (c *ChainClient) TerritoryFileDelivery(user []byte, fid string, target_territory string, opts ...TxOption) (TxReceipt, error) {
 	defer func() {
-		c.tradeCh <- true
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
@@ -1127,18 +661,18 @@ func (c *ChainClient) TerritoryFileDelivery(user []byte, fid string, target_terr
 
 	acc, err := types.NewAccountID(user)
 	if err != nil {
-		return "", errors.Wrap(err, "[NewAccountID]")
+		return TxReceipt{}, errors.Wrap(err, "[NewAccountID]")
 	}
 
-	newcall, err := types.NewCall(c.metadata, ExtName_FileBank_territory_file_delivery, *acc, types.NewBytes([]byte(fid)), types.NewBytes([]byte(target_territory)))
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_FileBank_territory_file_delivery, *acc, types.NewBytes([]byte(fid)), types.NewBytes([]byte(target_territory)))
 	if err != nil {
-		return "", fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_territory_file_delivery, err)
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_territory_file_delivery, err)
 	}
 
-	blockhash, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_territory_file_delivery)
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_FileBank_territory_file_delivery, opts...)
 	if err != nil {
-		return blockhash, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %v", c.GetCurrentRpcAddr(), ExtName_FileBank_territory_file_delivery, err)
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_FileBank_territory_file_delivery, err)
 	}
 
-	return blockhash, nil
+	return receipt, nil
 }
@@ -0,0 +1,246 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// prefix of the preimage of a multisig account id, see pallet_multisig::Pallet::multi_account_id
+const multisigAccountPrefix = "modlpy/utilisuba"
+
+// MultisigAccount derives the account id of the multisig account
+//   - signatories: accounts of all signatories, in any order
+//   - threshold: number of approvals needed to dispatch a call
+//
+// Return:
+//   - []byte: account id of the multisig account
+//   - error: error message
+func MultisigAccount(signatories [][]byte, threshold uint16) ([]byte, error) {
+	sorted, err := sortSignatories(signatories)
+	if err != nil {
+		return nil, err
+	}
+	if threshold == 0 || int(threshold) > len(sorted) {
+		return nil, fmt.Errorf("invalid threshold %d of %d signatories", threshold, len(sorted))
+	}
+	encoded, err := codec.Encode(sorted)
+	if err != nil {
+		return nil, errors.Wrap(err, "[Encode signatories]")
+	}
+	preimage := append([]byte(multisigAccountPrefix), encoded...)
+	preimage = binary.LittleEndian.AppendUint16(preimage, threshold)
+	h := blake2b.Sum256(preimage)
+	return h[:], nil
+}
+
+// MultisigCallHash returns the hash of the call that the signatories approve
+func MultisigCallHash(call types.Call) (types.Hash, error) {
+	encoded, err := codec.Encode(call)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	return types.Hash(blake2b.Sum256(encoded)), nil
+}
+
+// sortSignatories sorts the signatories in ascending order as the runtime requires
+func sortSignatories(signatories [][]byte) ([]types.AccountID, error) {
+	sorted := make([]types.AccountID, len(signatories))
+	for k, v := range signatories {
+		acc, err := types.NewAccountID(v)
+		if err != nil {
+			return nil, errors.Wrap(err, "[NewAccountID]")
+		}
+		sorted[k] = *acc
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+	for k := 1; k < len(sorted); k++ {
+		if sorted[k] == sorted[k-1] {
+			return nil, fmt.Errorf("duplicate signatory %x", sorted[k][:])
+		}
+	}
+	return sorted, nil
+}
+
+// QueryMultisig query a pending multisig operation
+//   - multisig: account id of the multisig account
+//   - callHash: hash of the call of the operation
+//   - block: block to read at, LatestBlock() for the latest block
+//
+// Return:
+//   - MultisigInfo: the operation with its timepoint and approvals
+//   - error: error message
+func (c *ChainClient) QueryMultisig(multisig []byte, callHash types.Hash, block BlockRef) (MultisigInfo, error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println(utils.RecoverError(err))
+		}
+	}()
+
+	data, err := QueryStorage[multisigOnChain](c.ctx, c, Multisig, Multisigs, block, multisig, callHash[:])
+	if err != nil {
+		return MultisigInfo{}, err
+	}
+	return newMultisigInfo(callHash, data), nil
+}
+
+// QueryMultisigs query all pending operations of a multisig account
+//   - multisig: account id of the multisig account
+//   - block: block to read at, LatestBlock() for the latest block
+//
+// Return:
+//   - []MultisigInfo: pending operations with their timepoints and approvals
+//   - error: error message
+func (c *ChainClient) QueryMultisigs(multisig []byte, block BlockRef) ([]MultisigInfo, error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println(utils.RecoverError(err))
+		}
+	}()
+
+	entries, err := QueryMap[types.Hash, multisigOnChain](c.ctx, c, Multisig, Multisigs, block, multisig)
+	if err != nil {
+		return nil, err
+	}
+	var result []MultisigInfo
+	for _, entry := range entries {
+		result = append(result, newMultisigInfo(entry.Key, entry.Value))
+	}
+	return result, nil
+}
+
+func newMultisigInfo(callHash types.Hash, data multisigOnChain) MultisigInfo {
+	return MultisigInfo{
+		CallHash:  callHash,
+		When:      data.When,
+		Deposit:   data.Deposit,
+		Depositor: data.Depositor,
+		Approvals: data.Approvals,
+	}
+}
+
+// AsMulti starts or approves a multisig operation with the call, the call is dispatched
+// from the multisig account by the approval that reaches the threshold
+//   - threshold: number of approvals needed to dispatch the call
+//   - otherSignatories: the signatories other than the signer, in any order
+//   - timepoint: timepoint of the operation, nil to start a new operation
+//   - call: the call to dispatch, built with the BuildCall option
+//   - maxWeight: maximum weight of the call, only checked by the final approval
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) AsMulti(threshold uint16, otherSignatories [][]byte, timepoint *types.TimePoint, call types.Call, maxWeight types.Weight, opts ...TxOption) (TxReceipt, error) {
+	others, err := sortSignatories(otherSignatories)
+	if err != nil {
+		return TxReceipt{}, err
+	}
+
+	var newcall types.Call
+	if threshold == 1 {
+		newcall, err = types.NewCall(c.GetMetadata(), ExtName_Multisig_as_multi_threshold1, others, call)
+		if err != nil {
+			return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Multisig_as_multi_threshold1, err)
+		}
+		receipt, err := c.SubmitExtrinsic(newcall, ExtName_Multisig_as_multi_threshold1, opts...)
+		if err != nil {
+			return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Multisig_as_multi_threshold1, err)
+		}
+		return receipt, nil
+	}
+
+	newcall, err = types.NewCall(c.GetMetadata(), ExtName_Multisig_as_multi, types.NewU16(threshold), others, newTimepointOption(timepoint), call, maxWeight)
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Multisig_as_multi, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Multisig_as_multi, opts...)
+	if err != nil {
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Multisig_as_multi, err)
+	}
+	return receipt, nil
+}
+
+// ApproveAsMulti approves a multisig operation by the hash of its call,
+// the final approval must be made with AsMulti to provide the call
+//   - threshold: number of approvals needed to dispatch the call
+//   - otherSignatories: the signatories other than the signer, in any order
+//   - timepoint: timepoint of the operation, nil to start a new operation
+//   - callHash: hash of the call, see MultisigCallHash
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) ApproveAsMulti(threshold uint16, otherSignatories [][]byte, timepoint *types.TimePoint, callHash types.Hash, opts ...TxOption) (TxReceipt, error) {
+	others, err := sortSignatories(otherSignatories)
+	if err != nil {
+		return TxReceipt{}, err
+	}
+
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Multisig_approve_as_multi, types.NewU16(threshold), others, newTimepointOption(timepoint), callHash, types.NewWeight(types.NewUCompactFromUInt(0), types.NewUCompactFromUInt(0)))
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Multisig_approve_as_multi, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Multisig_approve_as_multi, opts...)
+	if err != nil {
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Multisig_approve_as_multi, err)
+	}
+	return receipt, nil
+}
+
+// CancelAsMulti cancels a multisig operation, only the signatory that started it can cancel it
+//   - threshold: number of approvals needed to dispatch the call
+//   - otherSignatories: the signatories other than the signer, in any order
+//   - timepoint: timepoint of the operation
+//   - callHash: hash of the call of the operation
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) CancelAsMulti(threshold uint16, otherSignatories [][]byte, timepoint types.TimePoint, callHash types.Hash, opts ...TxOption) (TxReceipt, error) {
+	others, err := sortSignatories(otherSignatories)
+	if err != nil {
+		return TxReceipt{}, err
+	}
+
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Multisig_cancel_as_multi, types.NewU16(threshold), others, timepoint, callHash)
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Multisig_cancel_as_multi, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Multisig_cancel_as_multi, opts...)
+	if err != nil {
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Multisig_cancel_as_multi, err)
+	}
+	return receipt, nil
+}
+
+func newTimepointOption(timepoint *types.TimePoint) types.Option[types.TimePoint] {
+	if timepoint == nil {
+		return types.NewEmptyOption[types.TimePoint]()
+	}
+	return types.NewOption(*timepoint)
+}
+
+// MultisigTimepoint returns the timepoint of the multisig operation started by the transaction
+func MultisigTimepoint(receipt TxReceipt) types.TimePoint {
+	return types.TimePoint{Height: types.U32(receipt.BlockNumber), Index: types.U32(receipt.ExtrinsicIndex)}
+}
@@ -0,0 +1,66 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultisigAccount(t *testing.T) {
+	alice := bytes.Repeat([]byte{1}, 32)
+	bob := bytes.Repeat([]byte{2}, 32)
+	charlie := bytes.Repeat([]byte{3}, 32)
+
+	acc, err := MultisigAccount([][]byte{alice, bob, charlie}, 2)
+	require.NoError(t, err)
+	assert.Len(t, acc, 32)
+
+	// the order of the signatories does not matter
+	reordered, err := MultisigAccount([][]byte{charlie, alice, bob}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, acc, reordered)
+
+	other, err := MultisigAccount([][]byte{alice, bob, charlie}, 3)
+	require.NoError(t, err)
+	assert.NotEqual(t, acc, other)
+
+	// multisig account of the dev accounts Alice, Bob and Charlie with threshold 2
+	var devs [][]byte
+	for _, uri := range []string{"//Alice", "//Bob", "//Charlie"} {
+		s, err := signer.NewKeyringSigner(signer.Sr25519, uri)
+		require.NoError(t, err)
+		devs = append(devs, s.PublicKey())
+	}
+	acc, err = MultisigAccount(devs, 2)
+	require.NoError(t, err)
+	address, err := utils.EncodePublicKeyAsSubstrateAccount(acc)
+	require.NoError(t, err)
+	assert.Equal(t, "5DjYJStmdZ2rcqXbXGX7TW85JsrW6uG4y9MUcLq2BoPMpRA7", address)
+
+	_, err = MultisigAccount([][]byte{alice, bob, alice}, 2)
+	assert.Error(t, err)
+	_, err = MultisigAccount([][]byte{alice, bob}, 3)
+	assert.Error(t, err)
+	_, err = MultisigAccount([][]byte{alice, bob[:31]}, 1)
+	assert.Error(t, err)
+}
+
+func TestSortSignatories(t *testing.T) {
+	a := bytes.Repeat([]byte{1}, 32)
+	b := bytes.Repeat([]byte{2}, 32)
+	sorted, err := sortSignatories([][]byte{b, a})
+	require.NoError(t, err)
+	assert.Equal(t, a, sorted[0][:])
+	assert.Equal(t, b, sorted[1][:])
+}
@@ -0,0 +1,202 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/utils"
+)
+
+// maxNonceRetries is the number of times a transaction is re-signed
+// with a fresh nonce after the node rejected its nonce
+const maxNonceRetries = 3
+
+// nonceFetcher returns the next nonce of the account known to the chain,
+// including the transactions in the transaction pool
+type nonceFetcher func(accountID []byte) (uint64, error)
+
+// nonceManager allocates transaction nonces locally, so that several
+// transactions of the same account can be in flight at the same time.
+// A nonce is in flight from its allocation until its transaction is done,
+// it is never handed out again in the meantime, even when the node reports
+// a lower nonce after a resync.
+type nonceManager struct {
+	lock     sync.Mutex
+	accounts map[string]*accountNonce
+}
+
+type accountNonce struct {
+	lock sync.Mutex
+	next uint64
+	// nonces allocated to transactions that are not done yet
+	inFlight map[uint64]bool
+	// free nonces below next, sorted, the lowest is handed out first
+	gaps []uint64
+	// the local nonce must be synced with the chain before the next allocation
+	stale atomic.Bool
+	// the local nonce is known to be wrong, the chain nonce replaces it
+	invalid bool
+}
+
+func newNonceManager() *nonceManager {
+	return &nonceManager{accounts: make(map[string]*accountNonce)}
+}
+
+func (m *nonceManager) account(accountID []byte) *accountNonce {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	acc, ok := m.accounts[string(accountID)]
+	if !ok {
+		acc = &accountNonce{inFlight: make(map[uint64]bool)}
+		acc.stale.Store(true)
+		m.accounts[string(accountID)] = acc
+	}
+	return acc
+}
+
+// acquire allocates the next nonce of the account
+func (m *nonceManager) acquire(accountID []byte, fetch nonceFetcher) (uint64, error) {
+	acc := m.account(accountID)
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	if acc.stale.Swap(false) || acc.invalid {
+		chainNonce, err := fetch(accountID)
+		if err != nil {
+			acc.stale.Store(true)
+			return 0, err
+		}
+		if acc.invalid {
+			acc.adopt(chainNonce)
+		} else if chainNonce > acc.next {
+			// after a reconnect the new node may not know our pending
+			// transactions yet, so never go back behind the local nonce
+			acc.next, acc.gaps = chainNonce, nil
+		}
+		acc.invalid = false
+	}
+	var nonce uint64
+	if len(acc.gaps) > 0 {
+		nonce, acc.gaps = acc.gaps[0], acc.gaps[1:]
+	} else {
+		nonce = acc.next
+		acc.next++
+	}
+	acc.inFlight[nonce] = true
+	return nonce, nil
+}
+
+// adopt replaces the local nonce with the chain nonce, except for the nonces in flight:
+// the next nonce stays after them and the nonces between the chain nonce and them are free
+func (acc *accountNonce) adopt(chainNonce uint64) {
+	next := chainNonce
+	for n := range acc.inFlight {
+		if n >= next {
+			next = n + 1
+		}
+	}
+	acc.gaps = acc.gaps[:0]
+	for n := chainNonce; n < next; n++ {
+		if !acc.inFlight[n] {
+			acc.gaps = append(acc.gaps, n)
+		}
+	}
+	acc.next = next
+}
+
+// release returns a nonce that was allocated but never reached the transaction pool
+func (m *nonceManager) release(accountID []byte, nonce uint64) {
+	acc := m.account(accountID)
+	acc.lock.Lock()
+	defer acc.lock.Unlock()
+	if !acc.inFlight[nonce] {
+		return
+	}
+	delete(acc.inFlight, nonce)
+	if nonce+1 != acc.next {
+		// a later nonce is in use, the next allocation fills the gap
+		k := sort.Search(len(acc.gaps), func(i int) bool { return acc.gaps[i] > nonce })
+		acc.gaps = append(acc.gaps, 0)
+		copy(acc.gaps[k+1:], acc.gaps[k:])
+		acc.gaps[k] = nonce
+		return
+	}
+	acc.next = nonce
+	for len(acc.gaps) > 0 && acc.gaps[len(acc.gaps)-1]+1 == acc.next {
+		acc.next--
+		acc.gaps = acc.gaps[:len(acc.gaps)-1]
+	}
+}
+
+// done ends the flight of the nonce, its transaction is in a block or has left the pool
+func (m *nonceManager) done(accountID []byte, nonce uint64) {
+	acc := m.account(accountID)
+	acc.lock.Lock()
+	delete(acc.inFlight, nonce)
+	acc.lock.Unlock()
+}
+
+// invalidate discards the local nonce of the account, the next allocation
+// uses the nonce known to the chain without reusing the nonces in flight
+func (m *nonceManager) invalidate(accountID []byte) {
+	acc := m.account(accountID)
+	acc.lock.Lock()
+	acc.invalid = true
+	acc.lock.Unlock()
+}
+
+// resync marks the nonces of all accounts to be synced with the chain
+// before their next allocation, e.g. after the rpc node has changed
+func (m *nonceManager) resync() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for _, acc := range m.accounts {
+		acc.stale.Store(true)
+	}
+}
+
+// fetchNonce queries the next nonce of the account, including the
+// transactions in the transaction pool of the node
+func (c *ChainClient) fetchNonce(accountID []byte) (uint64, error) {
+	acc, err := utils.EncodePublicKeyAsSubstrateAccount(accountID)
+	if err != nil {
+		return 0, err
+	}
+	var nonce types.U32
+	err = c.api.Client.Call(&nonce, RPC_SYS_AccountNextIndex, acc)
+	if err == nil {
+		return uint64(nonce), nil
+	}
+	if c.ctx.Err() != nil {
+		return 0, c.ctx.Err()
+	}
+
+	// fall back to the nonce in the account storage
+	accountInfo, err := QueryStorage[types.AccountInfo](c.ctx, c, System, Account, LatestBlock(), accountID)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(accountInfo.Nonce), nil
+}
+
+// isNonceError reports whether the node rejected the transaction because of its nonce
+func isNonceError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, ERR_PriorityIsTooLow) ||
+		strings.Contains(msg, "Transaction is outdated") ||
+		strings.Contains(msg, "Transaction is stale") ||
+		strings.Contains(msg, "Transaction Already Imported") ||
+		strings.Contains(msg, "Transaction has a bad nonce")
+}
@@ -0,0 +1,126 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	var fetched int
+	fetch := func(accountID []byte) (uint64, error) {
+		fetched++
+		return 10, nil
+	}
+	m := newNonceManager()
+	account := []byte{1}
+
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[uint64]bool)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := m.acquire(account, fetch)
+			assert.NoError(t, err)
+			lock.Lock()
+			seen[nonce] = true
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, fetched)
+	assert.Len(t, seen, 50)
+	for i := uint64(10); i < 60; i++ {
+		assert.True(t, seen[i], i)
+	}
+}
+
+func TestNonceManagerRecovery(t *testing.T) {
+	chainNonce := uint64(5)
+	fetched := 0
+	fetch := func(accountID []byte) (uint64, error) {
+		fetched++
+		return chainNonce, nil
+	}
+	m := newNonceManager()
+	account := []byte{1}
+
+	nonce, _ := m.acquire(account, fetch)
+	assert.Equal(t, uint64(5), nonce)
+
+	// an unused nonce is handed out again
+	m.release(account, nonce)
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(5), nonce)
+
+	// a released nonce below a nonce in flight fills the gap without a resync
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(6), nonce)
+	m.release(account, 5)
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(5), nonce)
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(7), nonce)
+	assert.Equal(t, 1, fetched)
+
+	// after a reconnect the local nonce is kept if the node is behind
+	m.resync()
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(8), nonce)
+	m.done(account, 5)
+	m.done(account, 6)
+	m.done(account, 7)
+	m.done(account, 8)
+
+	// a rejected nonce adopts the chain nonce when nothing is in flight
+	chainNonce = 3
+	m.invalidate(account)
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(3), nonce)
+}
+
+func TestNonceManagerInFlight(t *testing.T) {
+	chainNonce := uint64(5)
+	fetch := func(accountID []byte) (uint64, error) {
+		return chainNonce, nil
+	}
+	m := newNonceManager()
+	account := []byte{1}
+
+	// 5 and 6 are in flight, 6 reached the pool first
+	for want := uint64(5); want <= 7; want++ {
+		nonce, _ := m.acquire(account, fetch)
+		assert.Equal(t, want, nonce)
+	}
+	// 7 was dropped, the node does not know 5 and 6 yet
+	m.done(account, 7)
+	m.invalidate(account)
+	nonce, _ := m.acquire(account, fetch)
+	assert.Equal(t, uint64(7), nonce)
+
+	// the nonces in flight are never handed out again
+	seen := map[uint64]bool{5: true, 6: true, 7: true}
+	for i := 0; i < 3; i++ {
+		m.invalidate(account)
+		nonce, _ = m.acquire(account, fetch)
+		assert.False(t, seen[nonce], nonce)
+		seen[nonce] = true
+	}
+
+	// the nonces done below the nonces in flight are reused once the chain reports them free
+	m.done(account, 5)
+	m.invalidate(account)
+	nonce, _ = m.acquire(account, fetch)
+	assert.Equal(t, uint64(5), nonce)
+}
@@ -0,0 +1,277 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// DefaultOfflineTxLifetime is the default lifetime in blocks of a transaction
+// that is signed offline, the longest the runtime accepts: it is capped by
+// System.BlockHashCount, e.g. 2048 blocks or about 3 hours for 2400 block hashes
+const DefaultOfflineTxLifetime = MaxTxLifetime
+
+// UnsignedTx is a transaction built on an online machine to be signed
+// on an offline machine, it is encoded as json to be carried between them
+type UnsignedTx struct {
+	// name of the extrinsic, e.g. Balances.transfer_keep_alive
+	ExtrinsicName string `json:"extrinsic_name"`
+	// cess address of the account that signs the transaction
+	Account string `json:"account"`
+	// encoded call
+	Call  hexutil.Bytes `json:"call"`
+	Nonce uint64        `json:"nonce"`
+	// encoded era
+	Era hexutil.Bytes `json:"era"`
+	// hash of the block the era is anchored at, the genesis hash for immortal transactions
+	BlockHash hexutil.Bytes `json:"block_hash"`
+	// number of the block the era is anchored at
+	BlockNumber uint64 `json:"block_number"`
+	// number of blocks the transaction is valid for, 0 if it is immortal
+	Lifetime           uint32        `json:"lifetime"`
+	GenesisHash        hexutil.Bytes `json:"genesis_hash"`
+	Tip                string        `json:"tip"`
+	SpecVersion        uint32        `json:"spec_version"`
+	TransactionVersion uint32        `json:"transaction_version"`
+}
+
+// SignedTx is a transaction signed offline, to be submitted by the online machine
+type SignedTx struct {
+	// name of the extrinsic, e.g. Balances.transfer_keep_alive
+	ExtrinsicName string `json:"extrinsic_name"`
+	// hash of the extrinsic
+	ExtrinsicHash string `json:"extrinsic_hash"`
+	// cess address of the account that signed the transaction
+	Account string `json:"account"`
+	Nonce   uint64 `json:"nonce"`
+	// number of the block the era is anchored at
+	BlockNumber uint64 `json:"block_number"`
+	// number of blocks the transaction is valid for, 0 if it is immortal
+	Lifetime uint32 `json:"lifetime"`
+	// encoded signed extrinsic
+	Extrinsic hexutil.Bytes `json:"extrinsic"`
+}
+
+// BuildUnsignedTx builds a transaction of the call to be signed offline by the account.
+// The nonce is the next nonce of the account on chain unless it is set with WithNonce,
+// it is not reserved: the transactions submitted by the account in the meantime
+// must be taken into account by the caller.
+//   - accountID: account that signs the transaction
+//   - call: the call to sign
+//   - extrinsicName: name of the extrinsic
+//   - opts: transaction options, only the tip, the lifetime and the nonce are used
+//
+// Return:
+//   - UnsignedTx: unsigned transaction
+//   - error: error message
+//
+// Note:
+//   - the transaction methods build an unsigned transaction when called with ExportUnsigned
+func (c *ChainClient) BuildUnsignedTx(accountID []byte, call types.Call, extrinsicName string, opts ...TxOption) (UnsignedTx, error) {
+	txOpts, err := newTxOptions(c.defaultTxOptions(), opts)
+	if err != nil {
+		return UnsignedTx{}, err
+	}
+	return c.buildUnsignedTx(accountID, call, extrinsicName, txOpts)
+}
+
+func (c *ChainClient) buildUnsignedTx(accountID []byte, call types.Call, extrinsicName string, txOpts TxOptions) (UnsignedTx, error) {
+	if c.at != nil {
+		return UnsignedTx{}, ERR_PinnedClient
+	}
+	if !txOpts.lifetimeSet {
+		txOpts.Lifetime = DefaultOfflineTxLifetime
+	}
+	account, err := utils.EncodePublicKeyAsCessAccount(accountID)
+	if err != nil {
+		return UnsignedTx{}, errors.Wrap(err, "[EncodePublicKeyAsCessAccount]")
+	}
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return UnsignedTx{}, ERR_RPC_CONNECTION
+		}
+	}
+
+	rt := c.runtime.Load()
+	era, err := c.newTxEra(txOpts.Lifetime)
+	if err != nil {
+		return UnsignedTx{}, errors.Wrap(err, "[newTxEra]")
+	}
+	encodedEra, err := codec.Encode(era.era)
+	if err != nil {
+		return UnsignedTx{}, errors.Wrap(err, "[Encode era]")
+	}
+	encodedCall, err := codec.Encode(call)
+	if err != nil {
+		return UnsignedTx{}, errors.Wrap(err, "[Encode call]")
+	}
+	tip := "0"
+	if txOpts.Tip != nil {
+		tip = txOpts.Tip.String()
+	}
+
+	var nonce uint64
+	if txOpts.nonce != nil {
+		nonce = *txOpts.nonce
+	} else if nonce, err = c.fetchNonce(accountID); err != nil {
+		return UnsignedTx{}, errors.Wrap(err, "[fetchNonce]")
+	}
+	return UnsignedTx{
+		ExtrinsicName:      extrinsicName,
+		Account:            account,
+		Call:               encodedCall,
+		Nonce:              nonce,
+		Era:                encodedEra,
+		BlockHash:          era.blockHash[:],
+		BlockNumber:        era.birth,
+		Lifetime:           uint32(era.period),
+		GenesisHash:        c.genesisHash[:],
+		Tip:                tip,
+		SpecVersion:        uint32(rt.version.SpecVersion),
+		TransactionVersion: uint32(rt.version.TransactionVersion),
+	}, nil
+}
+
+// SignUnsignedTx signs the transaction, it needs no connection to the chain
+//   - tx: unsigned transaction
+//   - s: signer of the account of the transaction
+//
+// Return:
+//   - SignedTx: signed transaction
+//   - error: error message
+func SignUnsignedTx(tx UnsignedTx, s signer.Signer) (SignedTx, error) {
+	accountID, err := utils.ParsingPublickey(tx.Account)
+	if err != nil {
+		return SignedTx{}, errors.Wrap(err, "[ParsingPublickey]")
+	}
+	if !bytes.Equal(accountID, signer.AccountID(s)) {
+		return SignedTx{}, fmt.Errorf("signer is not the account %s", tx.Account)
+	}
+
+	if len(tx.Call) < 2 {
+		return SignedTx{}, errors.New("invalid call")
+	}
+	call := types.Call{
+		CallIndex: types.CallIndex{SectionIndex: tx.Call[0], MethodIndex: tx.Call[1]},
+		Args:      types.Args(tx.Call[2:]),
+	}
+	var era types.ExtrinsicEra
+	if err = codec.Decode(tx.Era, &era); err != nil {
+		return SignedTx{}, errors.Wrap(err, "[Decode era]")
+	}
+	if len(tx.BlockHash) != len(types.Hash{}) || len(tx.GenesisHash) != len(types.Hash{}) {
+		return SignedTx{}, errors.New("invalid block hash")
+	}
+	tip, ok := new(big.Int).SetString(tx.Tip, 10)
+	if !ok || tip.Sign() < 0 {
+		return SignedTx{}, errors.New("invalid tip")
+	}
+
+	ext := types.NewExtrinsic(call)
+	err = signExtrinsic(context.Background(), &ext, s, types.SignatureOptions{
+		BlockHash:          types.NewHash(tx.BlockHash),
+		Era:                era,
+		GenesisHash:        types.NewHash(tx.GenesisHash),
+		Nonce:              types.NewUCompactFromUInt(tx.Nonce),
+		SpecVersion:        types.U32(tx.SpecVersion),
+		Tip:                types.NewUCompact(tip),
+		TransactionVersion: types.U32(tx.TransactionVersion),
+	})
+	if err != nil {
+		return SignedTx{}, errors.Wrap(err, "[signExtrinsic]")
+	}
+	exthash, err := extrinsicHash(ext)
+	if err != nil {
+		return SignedTx{}, errors.Wrap(err, "[extrinsicHash]")
+	}
+	encoded, err := codec.Encode(ext)
+	if err != nil {
+		return SignedTx{}, errors.Wrap(err, "[Encode extrinsic]")
+	}
+	return SignedTx{
+		ExtrinsicName: tx.ExtrinsicName,
+		ExtrinsicHash: exthash.Hex(),
+		Account:       tx.Account,
+		Nonce:         tx.Nonce,
+		BlockNumber:   tx.BlockNumber,
+		Lifetime:      tx.Lifetime,
+		Extrinsic:     encoded,
+	}, nil
+}
+
+// SubmitSignedTx submits a transaction signed offline and waits for it
+// like SubmitExtrinsic, the transaction cannot be re-signed if the node rejects it
+//   - tx: signed transaction
+//   - opts: transaction options, the tip and the lifetime are not used
+//
+// Return:
+//   - TxReceipt: receipt of the transaction, filled as far as the transaction got
+//   - error: error message, the dispatch error if the extrinsic failed,
+//     a *TxStatusError if the transaction was not included or finalized
+func (c *ChainClient) SubmitSignedTx(tx SignedTx, opts ...TxOption) (TxReceipt, error) {
+	var receipt = TxReceipt{ExtrinsicName: tx.ExtrinsicName}
+	if c.at != nil {
+		return receipt, ERR_PinnedClient
+	}
+	txOpts, err := newTxOptions(c.defaultTxOptions(), opts)
+	if err != nil {
+		return receipt, err
+	}
+	var ext types.Extrinsic
+	if err = codec.Decode(tx.Extrinsic, &ext); err != nil {
+		return receipt, errors.Wrap(err, "[Decode extrinsic]")
+	}
+	if !ext.IsSigned() || !ext.Signature.Signer.IsID {
+		return receipt, errors.New("extrinsic is not signed")
+	}
+	exthash, err := extrinsicHash(ext)
+	if err != nil {
+		return receipt, errors.Wrap(err, "[extrinsicHash]")
+	}
+	receipt.ExtrinsicHash = exthash.Hex()
+	accountID := ext.Signature.Signer.AsID[:]
+
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return receipt, ERR_RPC_CONNECTION
+		}
+	}
+	// the transaction is waited for until its era expires
+	era := txEra{period: uint64(tx.Lifetime), birth: tx.BlockNumber}
+	var current uint64
+	if era.period > 0 {
+		header, err := c.api.RPC.Chain.GetHeaderLatest()
+		if err != nil {
+			return receipt, errors.Wrap(err, "[GetHeaderLatest]")
+		}
+		current = uint64(header.Number)
+	}
+	subscription, err := c.api.RPC.Author.SubmitAndWatchExtrinsic(ext)
+	if err != nil {
+		if isNonceError(err) {
+			c.nonces.invalidate(accountID)
+		}
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return receipt, fmt.Errorf(" SubmitAndWatchExtrinsic err: %v", err)
+	}
+	defer subscription.Unsubscribe()
+
+	return c.watchExtrinsic(subscription, receipt, exthash, accountID, tx.Nonce, era.inclusionTimeout(current, c.packingTime), txOpts)
+}
@@ -0,0 +1,89 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignUnsignedTx(t *testing.T) {
+	s, err := signer.NewKeyringSigner(signer.Sr25519, "//Alice")
+	require.NoError(t, err)
+	account, err := utils.EncodePublicKeyAsCessAccount(s.PublicKey())
+	require.NoError(t, err)
+
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 4, MethodIndex: 3}, Args: []byte{1, 2, 3}}
+	encodedCall, err := codec.Encode(call)
+	require.NoError(t, err)
+	era, _ := newMortalEra(1587, 64)
+	encodedEra, err := codec.Encode(era)
+	require.NoError(t, err)
+	hash := types.NewHash([]byte{1})
+
+	unsigned := UnsignedTx{
+		ExtrinsicName:      ExtName_Balances_transferKeepAlive,
+		Account:            account,
+		Call:               encodedCall,
+		Nonce:              5,
+		Era:                encodedEra,
+		BlockHash:          hash[:],
+		BlockNumber:        1587,
+		Lifetime:           64,
+		GenesisHash:        hash[:],
+		Tip:                "100",
+		SpecVersion:        100,
+		TransactionVersion: 1,
+	}
+	// the transaction is carried to the offline machine as json
+	data, err := json.Marshal(unsigned)
+	require.NoError(t, err)
+	var carried UnsignedTx
+	require.NoError(t, json.Unmarshal(data, &carried))
+
+	signed, err := SignUnsignedTx(carried, s)
+	require.NoError(t, err)
+	assert.Equal(t, account, signed.Account)
+	assert.Equal(t, uint64(5), signed.Nonce)
+	// the era is carried to wait for the transaction until it expires
+	assert.Equal(t, uint64(1587), signed.BlockNumber)
+	assert.Equal(t, uint32(64), signed.Lifetime)
+
+	var ext types.Extrinsic
+	require.NoError(t, codec.Decode(signed.Extrinsic, &ext))
+	assert.True(t, ext.IsSigned())
+	assert.Equal(t, call.CallIndex, ext.Method.CallIndex)
+	assert.Equal(t, era, ext.Signature.Era)
+	assert.Equal(t, s.PublicKey(), ext.Signature.Signer.AsID[:])
+	exthash, err := extrinsicHash(ext)
+	require.NoError(t, err)
+	assert.Equal(t, signed.ExtrinsicHash, exthash.Hex())
+
+	other, err := signer.NewKeyringSigner(signer.Sr25519, "//Bob")
+	require.NoError(t, err)
+	_, err = SignUnsignedTx(carried, other)
+	assert.Error(t, err)
+}
+
+func TestWithNonce(t *testing.T) {
+	o, err := newTxOptions(TxOptions{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, o.nonce)
+
+	o, err = newTxOptions(TxOptions{}, []TxOption{WithNonce(0)})
+	require.NoError(t, err)
+	require.NotNil(t, o.nonce)
+	assert.Equal(t, uint64(0), *o.nonce)
+}
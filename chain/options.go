@@ -0,0 +1,83 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+)
+
+// ClientOption configures a chain client when it is created
+type ClientOption func(c *ChainClient) error
+
+// WithHealthCheck configures the health checks of the rpc endpoints
+//   - interval: interval between two checks, 0 disables the background checks
+//   - maxLag: number of blocks an endpoint may lag behind the best endpoint
+//     before requests are no longer routed to it
+func WithHealthCheck(interval time.Duration, maxLag uint32) ClientOption {
+	return func(c *ChainClient) error {
+		if interval < 0 {
+			return errors.New("invalid health check interval")
+		}
+		c.healthInterval = interval
+		c.maxBlockLag = maxLag
+		return nil
+	}
+}
+
+// WithDefaultTip sets the tip paid for every transaction of the client,
+// it can be overridden per transaction with WithTip
+//   - tip: amount of the tip in the smallest unit, 1 CESS is "1000000000000000000"
+func WithDefaultTip(tip string) ClientOption {
+	return func(c *ChainClient) error {
+		t, err := parseTip(tip)
+		if err != nil {
+			return err
+		}
+		c.tip = t
+		return nil
+	}
+}
+
+// WithDefaultLifetime sets the number of blocks every transaction of the client
+// is valid for, it can be overridden per transaction with WithLifetime
+//   - blocks: lifetime in blocks, 0 makes the transactions immortal
+//
+// Note:
+//   - by default the lifetime covers the waiting time for transaction packing
+func WithDefaultLifetime(blocks uint32) ClientOption {
+	return func(c *ChainClient) error {
+		c.txLifetime = blocks
+		return nil
+	}
+}
+
+// WithSigner signs the transactions of the client with s instead of a mnemonic,
+// e.g. with a remote signer backed by an HSM or KMS
+func WithSigner(s signer.Signer) ClientOption {
+	return func(c *ChainClient) error {
+		if s == nil {
+			return errors.New("nil signer")
+		}
+		return c.setSigner(s)
+	}
+}
+
+// WithKeystore sets the keystore of the client, transactions choose their
+// account with WithAccount and share the connection of the client
+func WithKeystore(ks *signer.Keystore) ClientOption {
+	return func(c *ChainClient) error {
+		if ks == nil {
+			return errors.New("nil keystore")
+		}
+		c.keystore = ks
+		return nil
+	}
+}
@@ -25,39 +25,74 @@ import (
 	"golang.org/x/crypto/blake2b"
 )
 
-func (c *ChainClient) ParseBlockData(blocknumber uint64) (BlockData, error) {
-	var (
-		ok             bool
-		name           string
-		err            error
-		extBytes       []byte
-		extrinsicIndex int
-		blockdata      BlockData
-		extInfo        ExtrinsicsInfo
-		allGasFee      = new(big.Int)
-	)
+// ParseOptions configures the parsing of a block
+type ParseOptions struct {
+	// decode the call arguments of each extrinsic
+	CallArgs bool
+	// handlers run after the default handlers, which fill the fields of BlockData
+	Handlers []*BlockHandlers
+}
 
-	blockdata.BlockId = uint32(blocknumber)
+// ParseOption sets an option of ParseBlockData
+type ParseOption func(o *ParseOptions)
+
+// WithCallArgs decodes the call of each extrinsic into ExtrinsicsInfo.Call,
+// with the metadata of the runtime of the block. A call that cannot be decoded
+// is left nil and the error is set in ExtrinsicsInfo.CallErr.
+func WithCallArgs() ParseOption {
+	return func(o *ParseOptions) {
+		o.CallArgs = true
+	}
+}
+
+// WithHandlers passes the events and extrinsics of the block to the handlers,
+// their results are read with BlockResult
+func WithHandlers(h *BlockHandlers) ParseOption {
+	return func(o *ParseOptions) {
+		o.Handlers = append(o.Handlers, h)
+	}
+}
 
+// ParseBlockData parses the block of the number, blocks can be parsed in parallel
+func (c *ChainClient) ParseBlockData(blocknumber uint64, opts ...ParseOption) (BlockData, error) {
 	if !c.GetRpcState() {
 		if err := c.ReconnectRpc(); err != nil {
 			return BlockData{}, ERR_RPC_CONNECTION
 		}
 	}
 
-	c.chainLock.Lock()
-	defer c.chainLock.Unlock()
-
 	blockhash, err := c.api.RPC.Chain.GetBlockHash(blocknumber)
 	if err != nil {
-		return blockdata, err
+		return BlockData{BlockId: uint32(blocknumber)}, err
 	}
-	blockdata.BlockHash = blockhash.Hex()
+	return c.ParseBlockDataByHash(blockhash, opts...)
+}
 
+// ParseBlockDataByHash parses the block of the hash, which may be a block of a fork
+func (c *ChainClient) ParseBlockDataByHash(blockhash types.Hash, opts ...ParseOption) (BlockData, error) {
+	var (
+		err       error
+		extBytes  []byte
+		blockdata BlockData
+		parseOpts ParseOptions
+	)
+	for _, opt := range opts {
+		opt(&parseOpts)
+	}
+
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return BlockData{}, ERR_RPC_CONNECTION
+		}
+	}
+
+	blockdata.BlockHash = blockhash.Hex()
 	block, err := c.api.RPC.Chain.GetBlock(blockhash)
 	if err != nil {
 		return blockdata, err
 	}
+	blocknumber := uint64(block.Block.Header.Number)
+	blockdata.BlockId = uint32(blocknumber)
 	blockdata.PreHash = block.Block.Header.ParentHash.Hex()
 	blockdata.ExtHash = block.Block.Header.ExtrinsicsRoot.Hex()
 	blockdata.StHash = block.Block.Header.StateRoot.Hex()
@@ -76,448 +111,43 @@ func (c *ChainClient) ParseBlockData(blocknumber uint64) (BlockData, error) {
 		return blockdata, nil
 	}
 
-	events, err := c.eventRetriever.GetEvents(blockhash)
+	events, err := c.getEvents(blockhash)
 	if err != nil {
 		return blockdata, err
 	}
 
-	var eventsBuf = make([]string, 0)
-	var signer string
-	var fee string
-
-	for _, e := range events {
-		if e.Phase.IsApplyExtrinsic {
-			if strings.Contains(e.Name, "MultiBlockMigrations.") {
+	var decoder *callDecoder
+	if parseOpts.CallArgs {
+		if decoder, err = c.callDecoderAt(blockhash); err != nil {
+			return blockdata, errors.Wrap(err, "[callDecoderAt]")
+		}
+		for k, v := range block.Block.Extrinsics {
+			call, err := decoder.decode(v.Method)
+			if err != nil {
+				blockdata.Extrinsics[k].CallErr = err.Error()
 				continue
 			}
-			if name, ok = ExtrinsicsName[block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.CallIndex]; ok {
-				if extrinsicIndex >= len(blockdata.Extrinsics) {
-					return blockdata, errors.New("The number of extrinsics hashes does not equal the number of extrinsics")
-				}
-				if name == ExtName_Timestamp_set {
-					extInfo = ExtrinsicsInfo{}
-					timestamp, err := scale.NewDecoder(bytes.NewReader(block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.Args)).DecodeUintCompact()
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.Timestamp = timestamp.Int64()
-					blockdata.Extrinsics[extrinsicIndex].Name = name
-					blockdata.Extrinsics[extrinsicIndex].Events = []string{e.Name}
-					blockdata.Extrinsics[extrinsicIndex].Result = true
-					extrinsicIndex++
-					continue
-				}
-				eventsBuf = append(eventsBuf, e.Name)
-
-				switch e.Name {
-				case TransactionPaymentTransactionFeePaid, EvmAccountMappingTransactionFeePaid:
-					signer, fee, err = parseSignerAndFeePaidFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					tmp, ok := new(big.Int).SetString(fee, 10)
-					if ok {
-						allGasFee = allGasFee.Add(allGasFee, tmp)
-					}
-				case BalancesTransfer:
-					from, to, amount, err := ParseTransferInfoFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.TransferInfo = append(blockdata.TransferInfo, TransferInfo{
-						ExtrinsicName: name,
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						From:          from,
-						To:            to,
-						Amount:        amount,
-						Result:        true,
-					})
-					if name == ExtName_Audit_submit_verify_service_result {
-						blockdata.Punishment = append(blockdata.Punishment, Punishment{
-							ExtrinsicName: name,
-							ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-							From:          from,
-							To:            to,
-							Amount:        amount,
-						})
-					}
-				case SminerRegistered:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.MinerReg = append(blockdata.MinerReg, MinerRegInfo{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Account:       acc,
-					})
-				case SystemNewAccount:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.NewAccounts = append(blockdata.NewAccounts, acc)
-				case FileBankUploadDeclaration:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					fid, err := ParseStringFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.UploadDecInfo = append(blockdata.UploadDecInfo, UploadDecInfo{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Owner:         acc,
-						Fid:           fid,
-					})
-				case FileBankDeleteFile:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					fid, err := ParseStringFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.DeleteFileInfo = append(blockdata.DeleteFileInfo, DeleteFileInfo{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Owner:         acc,
-						Fid:           fid,
-					})
-				case StorageHandlerMintTerritory:
-					token, name, size, err := parseTerritoryInfoFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.MintTerritory = append(blockdata.MintTerritory, MintTerritory{
-						ExtrinsicHash:  blockdata.Extrinsics[extrinsicIndex].Hash,
-						TerritoryToken: token,
-						TerritoryName:  name,
-						TerritorySize:  size,
-					})
-				case AuditSubmitIdleProof:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.SubmitIdleProve = append(blockdata.SubmitIdleProve, SubmitIdleProve{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Miner:         acc,
-					})
-				case AuditSubmitServiceProof:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.SubmitServiceProve = append(blockdata.SubmitServiceProve, SubmitServiceProve{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Miner:         acc,
-					})
-				case AuditSubmitIdleVerifyResult:
-					acc, result, err := ParseChallResultFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.SubmitIdleResult = append(blockdata.SubmitIdleResult, SubmitIdleResult{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Miner:         acc,
-						Result:        result,
-					})
-				case AuditSubmitServiceVerifyResult:
-					acc, result, err := ParseChallResultFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.SubmitServiceResult = append(blockdata.SubmitServiceResult, SubmitServiceResult{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Miner:         acc,
-						Result:        result,
-					})
-				case SminerRegisterPoisKey:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.MinerRegPoiskeys = append(blockdata.MinerRegPoiskeys, MinerRegPoiskey{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Miner:         acc,
-					})
-				case OssOssRegister:
-					acc, err := ParseAccountFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.GatewayReg = append(blockdata.GatewayReg, GatewayReg{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Account:       acc,
-					})
-				case FileBankStorageCompleted:
-					fid, err := ParseStringFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.StorageCompleted = append(blockdata.StorageCompleted, fid)
-				case StakingPayoutStarted:
-					eraIndex, validatorStash, err := ParseStakingPayoutStartedFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.StakingPayouts = append(blockdata.StakingPayouts, StakingPayout{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						EraIndex:      eraIndex,
-						ClaimedAcc:    validatorStash,
-					})
-				case StakingRewarded:
-					acc, amount, err := ParseStakingRewardedFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					for i := 0; i < len(blockdata.StakingPayouts); i++ {
-						if blockdata.StakingPayouts[i].ClaimedAcc == acc &&
-							blockdata.StakingPayouts[i].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-							blockdata.StakingPayouts[i].Amount = amount
-							break
-						}
-					}
-				case StakingUnbonded:
-					acc, amount, err := ParseStakingRewardedFromEvent(e)
-					if err != nil {
-						return blockdata, err
-					}
-					blockdata.Unbonded = append(blockdata.Unbonded, Unbonded{
-						ExtrinsicHash: blockdata.Extrinsics[extrinsicIndex].Hash,
-						Account:       acc,
-						Amount:        amount,
-					})
-				case SystemExtrinsicSuccess:
-					extInfo.Events = append(make([]string, 0), eventsBuf...)
-					extInfo.Name = name
-					extInfo.Signer = signer
-					extInfo.FeePaid = fee
-					extInfo.Result = true
-					extInfo.Hash = blockdata.Extrinsics[extrinsicIndex].Hash
-					blockdata.Extrinsics[extrinsicIndex] = extInfo
-					for i := 0; i < len(blockdata.MintTerritory); i++ {
-						if blockdata.MintTerritory[i].ExtrinsicHash == extInfo.Hash {
-							blockdata.MintTerritory[i].Account = signer
-							break
-						}
-					}
-					eventsBuf = make([]string, 0)
-					extInfo = ExtrinsicsInfo{}
-					extrinsicIndex++
-				case SystemExtrinsicFailed:
-					switch name {
-					case ExtName_FileBank_upload_declaration:
-						for m := 0; m < len(blockdata.UploadDecInfo); m++ {
-							if blockdata.UploadDecInfo[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.UploadDecInfo) == 1 {
-									blockdata.UploadDecInfo = nil
-								} else {
-									blockdata.UploadDecInfo = append(blockdata.UploadDecInfo[:m], blockdata.UploadDecInfo[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_FileBank_delete_file:
-						for m := 0; m < len(blockdata.DeleteFileInfo); m++ {
-							if blockdata.DeleteFileInfo[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.DeleteFileInfo) == 1 {
-									blockdata.DeleteFileInfo = nil
-								} else {
-									blockdata.DeleteFileInfo = append(blockdata.DeleteFileInfo[:m], blockdata.DeleteFileInfo[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Sminer_regnstk:
-						for m := 0; m < len(blockdata.MinerReg); m++ {
-							if blockdata.MinerReg[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.MinerReg) == 1 {
-									blockdata.MinerReg = nil
-								} else {
-									blockdata.MinerReg = append(blockdata.MinerReg[:m], blockdata.MinerReg[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Sminer_register_pois_key:
-						for m := 0; m < len(blockdata.MinerRegPoiskeys); m++ {
-							if blockdata.MinerRegPoiskeys[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.MinerRegPoiskeys) == 1 {
-									blockdata.MinerRegPoiskeys = nil
-								} else {
-									blockdata.MinerRegPoiskeys = append(blockdata.MinerRegPoiskeys[:m], blockdata.MinerRegPoiskeys[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Audit_submit_idle_proof:
-						for m := 0; m < len(blockdata.SubmitIdleProve); m++ {
-							if blockdata.SubmitIdleProve[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.SubmitIdleProve) == 1 {
-									blockdata.SubmitIdleProve = nil
-								} else {
-									blockdata.SubmitIdleProve = append(blockdata.SubmitIdleProve[:m], blockdata.SubmitIdleProve[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Audit_submit_service_proof:
-						for m := 0; m < len(blockdata.SubmitServiceProve); m++ {
-							if blockdata.SubmitServiceProve[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.SubmitServiceProve) == 1 {
-									blockdata.SubmitServiceProve = nil
-								} else {
-									blockdata.SubmitServiceProve = append(blockdata.SubmitServiceProve[:m], blockdata.SubmitServiceProve[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Audit_submit_verify_idle_result:
-						for m := 0; m < len(blockdata.SubmitIdleResult); m++ {
-							if blockdata.SubmitIdleResult[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.SubmitIdleResult) == 1 {
-									blockdata.SubmitIdleResult = nil
-								} else {
-									blockdata.SubmitIdleResult = append(blockdata.SubmitIdleResult[:m], blockdata.SubmitIdleResult[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Audit_submit_verify_service_result:
-						for m := 0; m < len(blockdata.SubmitServiceResult); m++ {
-							if blockdata.SubmitServiceResult[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.SubmitServiceResult) == 1 {
-									blockdata.SubmitServiceResult = nil
-								} else {
-									blockdata.SubmitServiceResult = append(blockdata.SubmitServiceResult[:m], blockdata.SubmitServiceResult[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Oss_register:
-						for m := 0; m < len(blockdata.GatewayReg); m++ {
-							if blockdata.GatewayReg[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.GatewayReg) == 1 {
-									blockdata.GatewayReg = nil
-								} else {
-									blockdata.GatewayReg = append(blockdata.GatewayReg[:m], blockdata.GatewayReg[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Staking_payout_stakers:
-						for m := 0; m < len(blockdata.StakingPayouts); m++ {
-							if blockdata.StakingPayouts[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.StakingPayouts) == 1 {
-									blockdata.StakingPayouts = nil
-								} else {
-									blockdata.StakingPayouts = append(blockdata.StakingPayouts[:m], blockdata.StakingPayouts[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Balances_transfer_all, ExtName_Balances_transferKeepAlive:
-						for m := 0; m < len(blockdata.TransferInfo); m++ {
-							if blockdata.TransferInfo[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.TransferInfo) == 1 {
-									blockdata.TransferInfo = nil
-								} else {
-									blockdata.TransferInfo = append(blockdata.TransferInfo[:m], blockdata.TransferInfo[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_Staking_unbond:
-						for m := 0; m < len(blockdata.Unbonded); m++ {
-							if blockdata.Unbonded[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.Unbonded) == 1 {
-									blockdata.Unbonded = nil
-								} else {
-									blockdata.Unbonded = append(blockdata.Unbonded[:m], blockdata.Unbonded[m+1:]...)
-								}
-								break
-							}
-						}
-					case ExtName_StorageHandler_mint_territory:
-						for m := 0; m < len(blockdata.MintTerritory); m++ {
-							if blockdata.MintTerritory[m].ExtrinsicHash == blockdata.Extrinsics[extrinsicIndex].Hash {
-								if len(blockdata.MintTerritory) == 1 {
-									blockdata.MintTerritory = nil
-								} else {
-									blockdata.MintTerritory = append(blockdata.MintTerritory[:m], blockdata.MintTerritory[m+1:]...)
-								}
-								break
-							}
-						}
-					}
+			blockdata.Extrinsics[k].Call = call
+		}
+	}
 
-					extInfo.Events = append(make([]string, 0), eventsBuf...)
-					extInfo.Name = name
-					extInfo.Signer = signer
-					extInfo.FeePaid = fee
-					extInfo.Result = false
-					extInfo.Hash = blockdata.Extrinsics[extrinsicIndex].Hash
-					blockdata.Extrinsics[extrinsicIndex] = extInfo
-					eventsBuf = make([]string, 0)
-					extInfo = ExtrinsicsInfo{}
-					extrinsicIndex++
-
-				default:
-				}
-			}
-		} else {
-			blockdata.SysEvents = append(blockdata.SysEvents, e.Name)
-			switch e.Name {
-			case StakingStakersElected:
-				blockdata.IsNewEra = true
-			case SystemNewAccount:
-				acc, err := ParseAccountFromEvent(e)
-				if err != nil {
-					return blockdata, err
-				}
-				blockdata.NewAccounts = append(blockdata.NewAccounts, acc)
-			case AuditGenerateChallenge:
-				acc, err := ParseAccountFromEvent(e)
-				if err != nil {
-					return blockdata, err
-				}
-				blockdata.GenChallenge = append(blockdata.GenChallenge, acc)
-			case StakingEraPaid:
-				eraIndex, validatorPayout, remainder, err := ParseStakingEraPaidFromEvent(e)
-				if err != nil {
-					return blockdata, err
-				}
-				blockdata.EraPaid = EraPaid{
-					HaveValue:       true,
-					EraIndex:        eraIndex,
-					ValidatorPayout: validatorPayout,
-					Remainder:       remainder,
-				}
-			case BalancesTransfer:
-				from, to, amount, err := ParseTransferInfoFromEvent(e)
-				if err != nil {
-					return blockdata, err
-				}
-				blockdata.TransferInfo = append(blockdata.TransferInfo, TransferInfo{
-					From:   from,
-					To:     to,
-					Amount: amount,
-					Result: true,
-				})
-				if to == TreasuryAccount {
-					blockdata.Punishment = append(blockdata.Punishment, Punishment{
-						From:   from,
-						To:     to,
-						Amount: amount,
-					})
-				}
-			default:
+	blockdata.AllGasFee = "0"
+	handlers := append([]*BlockHandlers{defaultBlockHandlers}, parseOpts.Handlers...)
+	// the calls are named after the runtime of the block
+	callName := func(index types.CallIndex) (string, bool, error) {
+		if decoder == nil {
+			d, err := c.callDecoderAt(blockhash)
+			if err != nil {
+				return "", false, errors.Wrap(err, "[callDecoderAt]")
 			}
+			decoder = d
 		}
+		name, ok := decoder.extrinsicName(index, uint32(c.runtime.Load().version.SpecVersion))
+		return name, ok, nil
+	}
+	if err = handleBlockEvents(&blockdata, block, blocknumber, events, callName, handlers); err != nil {
+		return blockdata, err
 	}
-	blockdata.AllGasFee = allGasFee.String()
 	return blockdata, nil
 }
 
@@ -554,7 +184,7 @@ func (c *ChainClient) ParseFileInBlock(blocknumber uint64) (FileDataInBlock, err
 		return filedata, nil
 	}
 
-	events, err := c.eventRetriever.GetEvents(blockhash)
+	events, err := c.getEvents(blockhash)
 	if err != nil {
 		return filedata, err
 	}
@@ -566,7 +196,7 @@ func (c *ChainClient) ParseFileInBlock(blocknumber uint64) (FileDataInBlock, err
 			if strings.Contains(e.Name, "MultiBlockMigrations.") {
 				continue
 			}
-			if name, ok = ExtrinsicsName[block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.CallIndex]; ok {
+			if name, ok = LookupExtrinsicName(block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.CallIndex); ok {
 				if name == ExtName_Timestamp_set {
 					timestamp, err := scale.NewDecoder(bytes.NewReader(block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.Args)).DecodeUintCompact()
 					if err != nil {
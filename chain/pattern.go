@@ -8,6 +8,7 @@
 package chain
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
@@ -71,8 +72,12 @@ const (
 	EVM = "EVM"
 	// FileBank
 	FileBank = "FileBank"
+	// Multisig
+	Multisig = "Multisig"
 	// Oss
 	Oss = "Oss"
+	// Proxy
+	Proxy = "Proxy"
 
 	// SchedulerCredit
 	SchedulerCredit = "SchedulerCredit"
@@ -123,10 +128,16 @@ const (
 	UserBucketList      = "UserBucketList"
 	UserHoldFileList    = "UserHoldFileList"
 
+	// Multisig
+	Multisigs = "Multisigs"
+
 	// Oss
 	// Oss
 	AuthorityList = "AuthorityList"
 
+	// Proxy
+	Proxies = "Proxies"
+
 	// SchedulerCredit
 	CurrentCounters = "CurrentCounters"
 
@@ -188,6 +199,7 @@ const (
 	RPC_Chain_getBlock         = "chain_getBlock"
 	RPC_Chain_getBlockHash     = "chain_getBlockHash"
 	RPC_Chain_getFinalizedHead = "chain_getFinalizedHead"
+	RPC_Chain_getHeader        = "chain_getHeader"
 
 	//Net
 	RPC_NET_Listening = "net_listening"
@@ -197,6 +209,18 @@ const (
 	RPC_SYS_SyncState  = "system_syncState"
 	RPC_SYS_Version    = "system_version"
 	RPC_SYS_Chain      = "system_chain"
+
+	RPC_SYS_AccountNextIndex = "system_accountNextIndex"
+	RPC_SYS_DryRun           = "system_dryRun"
+
+	// State
+	RPC_State_call         = "state_call"
+	RPC_State_getKeysPaged = "state_getKeysPaged"
+)
+
+// Runtime API
+const (
+	RuntimeApi_TransactionPayment_queryInfo = "TransactionPaymentApi_query_info"
 )
 
 const (
@@ -233,6 +257,10 @@ var (
 	ERR_RPC_IP_FORMAT    = errors.New("unsupported ip format")
 	ERR_RPC_TIMEOUT      = errors.New("timeout")
 	ERR_RPC_EMPTY_VALUE  = errors.New("empty")
+	ERR_NoSigner         = errors.New("no signature account configured")
+	ERR_InsufficientFee  = errors.New("balance cannot cover the transaction fee")
+	ERR_RuntimeUpgraded  = errors.New("runtime upgraded during the transaction")
+	ERR_PinnedClient     = errors.New("the client reads at a pinned block and cannot submit transactions")
 	ERR_IdleProofIsEmpty = errors.New("idle data proof is empty")
 )
 
@@ -352,6 +380,54 @@ type OssInfo struct {
 	Domain types.Bytes
 }
 
+// Multisig
+type MultisigInfo struct {
+	CallHash  types.Hash
+	When      types.TimePoint
+	Deposit   types.U128
+	Depositor types.AccountID
+	Approvals []types.AccountID
+}
+
+type multisigOnChain struct {
+	When      types.TimePoint
+	Deposit   types.U128
+	Depositor types.AccountID
+	Approvals []types.AccountID
+}
+
+// Proxy
+type ProxyType string
+
+const (
+	ProxyAny         ProxyType = "Any"
+	ProxyNonTransfer ProxyType = "NonTransfer"
+	ProxyGovernance  ProxyType = "Governance"
+	ProxyStaking     ProxyType = "Staking"
+)
+
+type ProxyDefinition struct {
+	Delegate  types.AccountID
+	ProxyType ProxyType
+	Delay     uint32
+}
+
+type ProxyInfo struct {
+	Proxies []ProxyDefinition
+	Deposit types.U128
+}
+
+type proxyDefinitionOnChain struct {
+	Delegate  types.AccountID
+	ProxyType types.U8
+	Delay     types.U32
+}
+
+type proxiesOnChain struct {
+	Proxies []proxyDefinitionOnChain
+	Deposit types.U128
+}
+
 // FileBank
 type StorageOrder struct {
 	FileSize     types.U128
@@ -743,6 +819,9 @@ type BlockData struct {
 	StakingPayouts      []StakingPayout
 	Unbonded            []Unbonded
 	MintTerritory       []MintTerritory
+
+	// results of the handlers passed with WithHandlers
+	results map[reflect.Type]any
 }
 
 type FileDataInBlock struct {
@@ -760,6 +839,10 @@ type ExtrinsicsInfo struct {
 	FeePaid string
 	Result  bool
 	Events  []string
+	// arguments of the call, set with WithCallArgs before the handlers run
+	Call *DecodedCall
+	// error decoding the arguments of the call with WithCallArgs, Call is nil then
+	CallErr string
 }
 
 type TransferInfo struct {
@@ -0,0 +1,171 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/pkg/errors"
+)
+
+// QueryProxies query the proxies of an account
+//   - accountID: the account that delegates to the proxies
+//   - block: block to read at, LatestBlock() for the latest block
+//
+// Return:
+//   - ProxyInfo: proxies of the account and the deposit reserved for them
+//   - error: error message
+func (c *ChainClient) QueryProxies(accountID []byte, block BlockRef) (ProxyInfo, error) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println(utils.RecoverError(err))
+		}
+	}()
+
+	data, err := QueryStorage[proxiesOnChain](c.ctx, c, Proxy, Proxies, block, accountID)
+	if err != nil {
+		return ProxyInfo{}, err
+	}
+
+	proxyTypes, err := variantTypeOf(c.GetMetadata(), "ProxyType")
+	if err != nil {
+		return ProxyInfo{}, err
+	}
+	info := ProxyInfo{Proxies: make([]ProxyDefinition, len(data.Proxies)), Deposit: data.Deposit}
+	for k, v := range data.Proxies {
+		info.Proxies[k] = ProxyDefinition{
+			Delegate:  v.Delegate,
+			ProxyType: ProxyType(variantName(proxyTypes, uint8(v.ProxyType))),
+			Delay:     uint32(v.Delay),
+		}
+	}
+	return info, nil
+}
+
+// AddProxy registers an account as a proxy of the signer
+//   - delegate: account of the proxy
+//   - proxyType: the calls the proxy is allowed to make
+//   - delay: number of blocks a call of the proxy must be announced in advance, 0 for none
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) AddProxy(delegate []byte, proxyType ProxyType, delay uint32, opts ...TxOption) (TxReceipt, error) {
+	return c.proxyTx(ExtName_Proxy_add_proxy, delegate, proxyType, delay, opts)
+}
+
+// RemoveProxy unregisters a proxy of the signer
+//   - delegate: account of the proxy
+//   - proxyType: proxy type the proxy was added with
+//   - delay: delay the proxy was added with
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) RemoveProxy(delegate []byte, proxyType ProxyType, delay uint32, opts ...TxOption) (TxReceipt, error) {
+	return c.proxyTx(ExtName_Proxy_remove_proxy, delegate, proxyType, delay, opts)
+}
+
+// RemoveProxies unregisters all proxies of the signer and unreserves the deposit
+//
+// Return:
+//   - TxReceipt: transaction receipt
+//   - error: error message
+func (c *ChainClient) RemoveProxies(opts ...TxOption) (TxReceipt, error) {
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Proxy_remove_proxies)
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Proxy_remove_proxies, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, ExtName_Proxy_remove_proxies, opts...)
+	if err != nil {
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), ExtName_Proxy_remove_proxies, err)
+	}
+	return receipt, nil
+}
+
+func (c *ChainClient) proxyTx(extName string, delegate []byte, proxyType ProxyType, delay uint32, opts []TxOption) (TxReceipt, error) {
+	address, err := types.NewMultiAddressFromAccountID(delegate)
+	if err != nil {
+		return TxReceipt{}, errors.Wrap(err, "[NewMultiAddressFromAccountID]")
+	}
+	index, err := c.proxyTypeIndex(proxyType)
+	if err != nil {
+		return TxReceipt{}, err
+	}
+
+	newcall, err := types.NewCall(c.GetMetadata(), extName, address, index, types.NewU32(delay))
+	if err != nil {
+		return TxReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), extName, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, extName, opts...)
+	if err != nil {
+		return receipt, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), extName, err)
+	}
+	return receipt, nil
+}
+
+// proxyCall wraps the call into Proxy.proxy, it is dispatched on behalf of the real account
+func (c *ChainClient) proxyCall(call types.Call, real []byte, forceType ProxyType) (types.Call, error) {
+	address, err := types.NewMultiAddressFromAccountID(real)
+	if err != nil {
+		return types.Call{}, errors.Wrap(err, "[NewMultiAddressFromAccountID]")
+	}
+	force := types.NewEmptyOption[types.U8]()
+	if forceType != "" {
+		index, err := c.proxyTypeIndex(forceType)
+		if err != nil {
+			return types.Call{}, err
+		}
+		force = types.NewOption(index)
+	}
+	newcall, err := types.NewCall(c.GetMetadata(), ExtName_Proxy_proxy, address, force, call)
+	if err != nil {
+		return types.Call{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), ExtName_Proxy_proxy, err)
+	}
+	return newcall, nil
+}
+
+// proxyTypeIndex returns the index of the proxy type in the runtime
+func (c *ChainClient) proxyTypeIndex(proxyType ProxyType) (types.U8, error) {
+	proxyTypes, err := variantTypeOf(c.GetMetadata(), "ProxyType")
+	if err != nil {
+		return 0, err
+	}
+	for _, v := range proxyTypes.Variants {
+		if string(v.Name) == string(proxyType) {
+			return v.Index, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown proxy type: %s", proxyType)
+}
+
+// variantTypeOf returns the enum of the metadata whose type path ends with name
+func variantTypeOf(meta *types.Metadata, name string) (types.Si1TypeDefVariant, error) {
+	for _, typ := range meta.AsMetadataV14.Lookup.Types {
+		path := typ.Type.Path
+		if len(path) > 0 && string(path[len(path)-1]) == name && typ.Type.Def.IsVariant {
+			return typ.Type.Def.Variant, nil
+		}
+	}
+	return types.Si1TypeDefVariant{}, fmt.Errorf("type %s not found in metadata", name)
+}
+
+// variantName returns the name of the variant, or its index if it is unknown
+func variantName(def types.Si1TypeDefVariant, index uint8) string {
+	for _, v := range def.Variants {
+		if uint8(v.Index) == index {
+			return string(v.Name)
+		}
+	}
+	return fmt.Sprint(index)
+}
@@ -0,0 +1,47 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariantTypeOf(t *testing.T) {
+	proxyTypes := types.Si1TypeDefVariant{Variants: []types.Si1Variant{
+		{Name: "Any", Index: 0},
+		{Name: "NonTransfer", Index: 1},
+		{Name: "Staking", Index: 3},
+	}}
+	meta := &types.Metadata{AsMetadataV14: types.MetadataV14{Lookup: types.PortableRegistryV14{Types: []types.PortableTypeV14{
+		{Type: types.Si1Type{Path: types.Si1Path{"pallet_proxy", "ProxyDefinition"}, Def: types.Si1TypeDef{IsComposite: true}}},
+		{Type: types.Si1Type{Path: types.Si1Path{"cess_node_runtime", "ProxyType"}, Def: types.Si1TypeDef{IsVariant: true, Variant: proxyTypes}}},
+	}}}}
+
+	def, err := variantTypeOf(meta, "ProxyType")
+	require.NoError(t, err)
+	assert.Equal(t, string(ProxyStaking), variantName(def, 3))
+	assert.Equal(t, "2", variantName(def, 2))
+
+	_, err = variantTypeOf(meta, "ProxyDefinition")
+	assert.Error(t, err)
+}
+
+func TestDispatchErrorFromResult(t *testing.T) {
+	dispatchErr := &DispatchError{Kind: "BadOrigin"}
+	// Proxy.ProxyExecuted { result: Err(BadOrigin) }
+	fields := registry.DecodedFields{{Name: "result", Value: registry.DecodedFields{{Value: dispatchErr}}}}
+	assert.Equal(t, dispatchErr, dispatchErrorFromEvent(fields))
+	// Proxy.ProxyExecuted { result: Ok(()) }
+	fields = registry.DecodedFields{{Name: "result", Value: registry.DecodedFields{{Value: []any{}}}}}
+	assert.Nil(t, dispatchErrorFromEvent(fields))
+}
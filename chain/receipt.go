@@ -0,0 +1,136 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+// TxReceipt is the receipt of a transaction included in a block
+type TxReceipt struct {
+	// name of the extrinsic, e.g. FileBank.upload_declaration
+	ExtrinsicName string
+	// hash of the extrinsic
+	ExtrinsicHash string
+	// hash of the block that includes the extrinsic
+	BlockHash string
+	// number of the block that includes the extrinsic
+	BlockNumber uint32
+	// index of the extrinsic in the block
+	ExtrinsicIndex uint32
+	// account that paid the fee of the extrinsic
+	Signer string
+	// fee actually paid for the extrinsic
+	FeePaid string
+	// whether the extrinsic was dispatched successfully
+	Success bool
+	// events emitted by the extrinsic
+	Events []*parser.Event
+	// reason of the failure, nil if the extrinsic was dispatched successfully
+	DispatchError *DispatchError
+}
+
+// EventNames returns the names of the events emitted by the extrinsic
+func (r TxReceipt) EventNames() []string {
+	names := make([]string, len(r.Events))
+	for k, e := range r.Events {
+		names[k] = e.Name
+	}
+	return names
+}
+
+// extrinsicHash calculates the hash of the extrinsic
+func extrinsicHash(ext types.Extrinsic) (types.Hash, error) {
+	buf, err := codec.Encode(ext)
+	if err != nil {
+		return types.Hash{}, err
+	}
+	h := blake2b.Sum256(buf)
+	return types.NewHash(h[:]), nil
+}
+
+// getTxReceipt builds the receipt of the extrinsic included in the given block.
+// If the extrinsic failed, the receipt is returned together with the dispatch error.
+func (c *ChainClient) getTxReceipt(blockhash types.Hash, exthash types.Hash, extrinsicName string) (TxReceipt, error) {
+	receipt := TxReceipt{
+		ExtrinsicName: extrinsicName,
+		ExtrinsicHash: exthash.Hex(),
+		BlockHash:     blockhash.Hex(),
+	}
+
+	block, err := c.api.RPC.Chain.GetBlock(blockhash)
+	if err != nil {
+		return receipt, errors.Wrap(err, "[GetBlock]")
+	}
+	receipt.BlockNumber = uint32(block.Block.Header.Number)
+
+	found := false
+	for k, ext := range block.Block.Extrinsics {
+		h, err := extrinsicHash(ext)
+		if err != nil {
+			return receipt, errors.Wrap(err, "[extrinsicHash]")
+		}
+		if h == exthash {
+			receipt.ExtrinsicIndex = uint32(k)
+			if receipt.ExtrinsicName == "" {
+				receipt.ExtrinsicName, _ = LookupExtrinsicName(ext.Method.CallIndex)
+			}
+			found = true
+			break
+		}
+	}
+	if !found {
+		return receipt, fmt.Errorf("extrinsic %s not found in block %s", receipt.ExtrinsicHash, receipt.BlockHash)
+	}
+
+	events, err := c.getEvents(blockhash)
+	if err != nil {
+		return receipt, errors.Wrap(err, "[getEvents]")
+	}
+
+	failed := false
+	for _, e := range events {
+		if !e.Phase.IsApplyExtrinsic || e.Phase.AsApplyExtrinsic != receipt.ExtrinsicIndex {
+			continue
+		}
+		receipt.Events = append(receipt.Events, e)
+		switch e.Name {
+		case TransactionPaymentTransactionFeePaid, EvmAccountMappingTransactionFeePaid:
+			receipt.Signer, receipt.FeePaid, _ = parseSignerAndFeePaidFromEvent(e)
+		case SystemExtrinsicSuccess:
+			receipt.Success = true
+		case SystemExtrinsicFailed:
+			failed = true
+			receipt.DispatchError = dispatchErrorFromEvent(e.Fields)
+		case ProxyProxyExecuted, MultisigMultisigExecuted:
+			// the extrinsic succeeds even if the wrapped call fails
+			if dispatchErr := dispatchErrorFromEvent(e.Fields); dispatchErr != nil {
+				failed = true
+				receipt.DispatchError = dispatchErr
+			}
+		}
+	}
+
+	if failed {
+		receipt.Success = false
+		if receipt.DispatchError != nil {
+			return receipt, receipt.DispatchError
+		}
+		return receipt, errors.New(SystemExtrinsicFailed)
+	}
+	if !receipt.Success {
+		return receipt, fmt.Errorf("result of extrinsic %s not found", receipt.ExtrinsicHash)
+	}
+	return receipt, nil
+}
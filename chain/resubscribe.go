@@ -0,0 +1,56 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// minResubscribeDelay is the delay before the first attempt to renew a lost subscription
+	minResubscribeDelay = time.Second
+	// maxResubscribeDelay is the longest delay between two attempts to renew a subscription
+	maxResubscribeDelay = 30 * time.Second
+)
+
+// resubscribeDelay returns the delay before an attempt to renew a subscription,
+// it doubles with each failed attempt from minResubscribeDelay up to maxResubscribeDelay
+func resubscribeDelay(attempt int) time.Duration {
+	delay := minResubscribeDelay
+	for i := 0; i < attempt && delay < maxResubscribeDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxResubscribeDelay {
+		delay = maxResubscribeDelay
+	}
+	return delay
+}
+
+// resubscribe renews a lost subscription, it calls subscribe after a growing delay until it succeeds
+//   - ctx: the attempts stop when ctx is done
+//   - subscribe: opens the subscription
+//
+// Return:
+//   - T: the subscription
+//   - error: ctx.Err() if ctx is done before the subscription is renewed
+func resubscribe[T any](ctx context.Context, subscribe func() (T, error)) (T, error) {
+	for attempt := 0; ; attempt++ {
+		timer := time.NewTimer(resubscribeDelay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			var zero T
+			return zero, ctx.Err()
+		case <-timer.C:
+		}
+		if sub, err := subscribe(); err == nil {
+			return sub, nil
+		}
+	}
+}
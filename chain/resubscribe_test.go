@@ -0,0 +1,23 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResubscribeDelay(t *testing.T) {
+	assert.Equal(t, time.Second, resubscribeDelay(0))
+	assert.Equal(t, 2*time.Second, resubscribeDelay(1))
+	assert.Equal(t, 16*time.Second, resubscribeDelay(4))
+	assert.Equal(t, 30*time.Second, resubscribeDelay(5))
+	assert.Equal(t, 30*time.Second, resubscribeDelay(100))
+}
@@ -10,12 +10,48 @@ package chain
 import (
 	"fmt"
 
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/state"
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
 	"github.com/pkg/errors"
 )
 
+// getEvents retrieves and parses all events of the given block.
+// If the events cannot be parsed with the current metadata, e.g. the block
+// was produced by an older runtime, the metadata of that block is used.
+func (c *ChainClient) getEvents(blockhash types.Hash) ([]*parser.Event, error) {
+	eventProvider := state.NewEventProvider(c.api.RPC.State)
+	eventParser := parser.NewEventParser()
+
+	rt := c.runtime.Load()
+	storageEvents, err := eventProvider.GetStorageEvents(rt.metadata, blockhash)
+	if err == nil {
+		events, err := eventParser.ParseEvents(rt.eventRegistry, storageEvents)
+		if err == nil {
+			return events, nil
+		}
+	}
+	if c.ctx.Err() != nil {
+		return nil, c.ctx.Err()
+	}
+
+	meta, err := c.api.RPC.State.GetMetadata(blockhash)
+	if err != nil {
+		return nil, errors.Wrap(err, "[GetMetadata]")
+	}
+	eventRegistry, err := newEventRegistry(meta)
+	if err != nil {
+		return nil, err
+	}
+	storageEvents, err = eventProvider.GetStorageEvents(meta, blockhash)
+	if err != nil {
+		return nil, errors.Wrap(err, "[GetStorageEvents]")
+	}
+	return eventParser.ParseEvents(eventRegistry, storageEvents)
+}
+
 func (c *ChainClient) RetrieveAllEventName(blockhash types.Hash) ([]string, error) {
-	events, err := c.eventRetriever.GetEvents(blockhash)
+	events, err := c.getEvents(blockhash)
 	if err != nil {
 		return nil, err
 	}
@@ -27,7 +63,7 @@ func (c *ChainClient) RetrieveAllEventName(blockhash types.Hash) ([]string, erro
 }
 
 func (c *ChainClient) RetrieveEvent(blockhash types.Hash, extrinsic_name, signer string) error {
-	if len(ExtrinsicsName) <= 0 {
+	if !extrinsicsNameInitialised() {
 		return errors.New("please call InitExtrinsicsName method first")
 	}
 
@@ -44,7 +80,7 @@ func (c *ChainClient) RetrieveEvent(blockhash types.Hash, extrinsic_name, signer
 		return err
 	}
 
-	events, err := c.eventRetriever.GetEvents(blockhash)
+	events, err := c.getEvents(blockhash)
 	if err != nil {
 		return err
 	}
@@ -62,7 +98,7 @@ func (c *ChainClient) RetrieveEvent(blockhash types.Hash, extrinsic_name, signer
 		}
 		if name == "" {
 			//fmt.Println(" name==nil")
-			name, ok = ExtrinsicsName[block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.CallIndex]
+			name, ok = LookupExtrinsicName(block.Block.Extrinsics[e.Phase.AsApplyExtrinsic].Method.CallIndex)
 			if !ok {
 				//fmt.Println(" continue2")
 				continue
@@ -94,6 +130,9 @@ func (c *ChainClient) RetrieveEvent(blockhash types.Hash, extrinsic_name, signer
 			name = ""
 			if extrinsic_signer == signer {
 				//fmt.Println(" failed")
+				if dispatchErr := dispatchErrorFromEvent(e.Fields); dispatchErr != nil {
+					return dispatchErr
+				}
 				return errors.New(SystemExtrinsicFailed)
 			}
 		}
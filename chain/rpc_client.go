@@ -0,0 +1,161 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+
+	gsrpc "github.com/AstaFrode/go-substrate-rpc-client/v4"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/client"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/config"
+	gethrpc "github.com/AstaFrode/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/author"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/beefy"
+	rpcchain "github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/chain"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/mmr"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/offchain"
+	rpcstate "github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/system"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+)
+
+// rpcConn is a raw websocket connection to one rpc node
+type rpcConn struct {
+	*gethrpc.Client
+	url string
+}
+
+var _ client.Client = (*rpcConn)(nil)
+
+// dialRpc connects to the rpc node at url, the dial is aborted when ctx is done
+func dialRpc(ctx context.Context, url string) (*rpcConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, config.Default().DialTimeout)
+	defer cancel()
+	c, err := gethrpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &rpcConn{Client: c, url: url}, nil
+}
+
+// URL returns the rpc address of the connection
+func (r *rpcConn) URL() string {
+	return r.url
+}
+
+// rpcDispatcher implements client.Client on top of the endpoint pool
+// of the chain client. Every call and subscription
+// handshake is bound to the context of the chain client it belongs to,
+// so that cancelling that context aborts the in-flight request.
+type rpcDispatcher struct {
+	st  *clientState
+	ctx context.Context
+	// block the state reads without a block hash are pinned to, nil for the latest block
+	at *types.Hash
+}
+
+var _ client.Client = (*rpcDispatcher)(nil)
+
+// newSubstrateAPI creates a substrate api whose requests are bound to ctx
+func newSubstrateAPI(ctx context.Context, st *clientState) *gsrpc.SubstrateAPI {
+	return newSubstrateAPIAt(ctx, st, nil)
+}
+
+// newSubstrateAPIAt creates a substrate api whose requests are bound to ctx
+// and whose state reads of the latest block read at the block at instead
+func newSubstrateAPIAt(ctx context.Context, st *clientState, at *types.Hash) *gsrpc.SubstrateAPI {
+	cl := &rpcDispatcher{st: st, ctx: ctx, at: at}
+	return &gsrpc.SubstrateAPI{
+		RPC: &rpc.RPC{
+			Author:   author.NewAuthor(cl),
+			Beefy:    beefy.NewBeefy(cl),
+			Chain:    rpcchain.NewChain(cl),
+			MMR:      mmr.NewMMR(cl),
+			Offchain: offchain.NewOffchain(cl),
+			State:    rpcstate.NewState(cl),
+			System:   system.NewSystem(cl),
+		},
+		Client: cl,
+	}
+}
+
+// bind merges the context passed by the rpc library with the context of the chain client
+func (d *rpcDispatcher) bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == nil || ctx.Done() == nil {
+		return d.ctx, func() {}
+	}
+	merged, cancel := context.WithCancel(d.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+	return merged, func() {
+		stop()
+		cancel()
+	}
+}
+
+func (d *rpcDispatcher) Call(result interface{}, method string, args ...interface{}) error {
+	return d.CallContext(d.ctx, result, method, args...)
+}
+
+// CallContext sends the request to the current endpoint of the pool,
+// if the connection to that endpoint fails the request is retried on the next healthy endpoint
+func (d *rpcDispatcher) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := d.bind(ctx)
+	defer cancel()
+	args = pinBlock(d.at, method, args)
+	var tried []*rpcEndpoint
+	for {
+		ep, conn := d.st.pool.pick(tried)
+		if ep == nil {
+			return ERR_RPC_CONNECTION
+		}
+		d.st.pool.notifySwitch()
+		err := conn.CallContext(ctx, result, method, args...)
+		d.st.pool.report(ep, conn, err)
+		if !isTransportError(err) || ctx.Err() != nil {
+			return err
+		}
+		tried = append(tried, ep)
+	}
+}
+
+// Subscribe subscribes on the current endpoint of the pool,
+// the subscription stays on that endpoint until it is unsubscribed
+func (d *rpcDispatcher) Subscribe(
+	ctx context.Context,
+	namespace, subscribeMethodSuffix, unsubscribeMethodSuffix,
+	notificationMethodSuffix string,
+	channel interface{},
+	args ...interface{},
+) (*gethrpc.ClientSubscription, error) {
+	ctx, cancel := d.bind(ctx)
+	defer cancel()
+	var tried []*rpcEndpoint
+	for {
+		ep, conn := d.st.pool.pick(tried)
+		if ep == nil {
+			return nil, ERR_RPC_CONNECTION
+		}
+		d.st.pool.notifySwitch()
+		sub, err := conn.Subscribe(ctx, namespace, subscribeMethodSuffix, unsubscribeMethodSuffix, notificationMethodSuffix, channel, args...)
+		d.st.pool.report(ep, conn, err)
+		if !isTransportError(err) || ctx.Err() != nil {
+			return sub, err
+		}
+		tried = append(tried, ep)
+	}
+}
+
+func (d *rpcDispatcher) URL() string {
+	return d.st.pool.currentAddr()
+}
+
+// Close closes the connections to all endpoints
+func (d *rpcDispatcher) Close() {
+	d.st.pool.close()
+}
@@ -0,0 +1,173 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/utils"
+	"github.com/pkg/errors"
+)
+
+// runtimeState is everything the client derives from the runtime, it is
+// replaced as a whole after a runtime upgrade so that an operation that
+// loaded it once uses a consistent metadata and version
+type runtimeState struct {
+	metadata      *types.Metadata
+	version       *types.RuntimeVersion
+	eventRegistry registry.EventRegistry
+}
+
+// RuntimeUpgrade describes a runtime upgrade seen by the client
+type RuntimeUpgrade struct {
+	Previous types.RuntimeVersion
+	Current  types.RuntimeVersion
+}
+
+// runtimeListeners are the callbacks notified of runtime upgrades
+type runtimeListeners struct {
+	lock  sync.Mutex
+	next  int
+	funcs map[int]func(RuntimeUpgrade)
+}
+
+func newRuntimeListeners() *runtimeListeners {
+	return &runtimeListeners{funcs: make(map[int]func(RuntimeUpgrade))}
+}
+
+func (l *runtimeListeners) add(fn func(RuntimeUpgrade)) func() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	id := l.next
+	l.next++
+	l.funcs[id] = fn
+	return func() {
+		l.lock.Lock()
+		delete(l.funcs, id)
+		l.lock.Unlock()
+	}
+}
+
+func (l *runtimeListeners) notify(upgrade RuntimeUpgrade) {
+	l.lock.Lock()
+	funcs := make([]func(RuntimeUpgrade), 0, len(l.funcs))
+	for _, fn := range l.funcs {
+		funcs = append(funcs, fn)
+	}
+	l.lock.Unlock()
+	for _, fn := range funcs {
+		func() {
+			defer func() {
+				if err := recover(); err != nil {
+					log.Println(utils.RecoverError(err))
+				}
+			}()
+			fn(upgrade)
+		}()
+	}
+}
+
+// OnRuntimeUpgrade registers fn to be called after the client switched to an upgraded runtime
+//   - fn: called with the previous and the current runtime version
+//
+// Return:
+//   - func(): removes the listener
+func (c *ChainClient) OnRuntimeUpgrade(fn func(RuntimeUpgrade)) func() {
+	if fn == nil {
+		return func() {}
+	}
+	return c.listeners.add(fn)
+}
+
+// GetRuntimeVersion get the runtime version the client signs transactions for
+func (c *ChainClient) GetRuntimeVersion() types.RuntimeVersion {
+	rt := c.runtime.Load()
+	if rt == nil {
+		return types.RuntimeVersion{}
+	}
+	return *rt.version
+}
+
+// reloadRuntime loads the metadata of the runtime version and swaps it in,
+// the transaction names are rebuilt and the listeners are notified if the version changed
+func (c *ChainClient) reloadRuntime(version types.RuntimeVersion) error {
+	c.runtimeLock.Lock()
+	defer c.runtimeLock.Unlock()
+
+	previous := c.runtime.Load()
+	if previous != nil && sameRuntime(*previous.version, version) {
+		return nil
+	}
+	metadata, err := c.api.RPC.State.GetMetadataLatest()
+	if err != nil {
+		return errors.Wrap(err, "[GetMetadataLatest]")
+	}
+	eventRegistry, err := newEventRegistry(metadata)
+	if err != nil {
+		return err
+	}
+	types.SetSerDeOptions(types.SerDeOptionsFromMetadata(metadata))
+	c.runtime.Store(&runtimeState{metadata: metadata, version: &version, eventRegistry: eventRegistry})
+	if previous == nil {
+		return nil
+	}
+
+	extrinsicsNameLock.RLock()
+	init := c.extrinsicsNameInit
+	extrinsicsNameLock.RUnlock()
+	if init != nil {
+		if err = init(c); err != nil {
+			log.Println("rebuild extrinsics name after runtime upgrade: ", err)
+		}
+	}
+	go c.listeners.notify(RuntimeUpgrade{Previous: *previous.version, Current: version})
+	return nil
+}
+
+func sameRuntime(a, b types.RuntimeVersion) bool {
+	return a.SpecName == b.SpecName && a.SpecVersion == b.SpecVersion && a.TransactionVersion == b.TransactionVersion
+}
+
+// watchRuntime follows the runtime version of the chain until ctx is done
+func (c *ChainClient) watchRuntime(ctx context.Context) {
+	subscribe := c.api.RPC.State.SubscribeRuntimeVersion
+	sub, err := subscribe()
+	for {
+		if err == nil {
+			c.followRuntime(ctx, sub)
+		}
+		if sub, err = resubscribe(ctx, subscribe); err != nil {
+			return
+		}
+	}
+}
+
+// followRuntime reloads the runtime on each version reported by the
+// subscription, it returns when the subscription fails or ctx is done
+func (c *ChainClient) followRuntime(ctx context.Context, sub *state.RuntimeVersionSubscription) {
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sub.Err():
+			return
+		case version := <-sub.Chan():
+			if err := c.reloadRuntime(version); err != nil {
+				log.Println("reload runtime: ", err)
+				// the next subscription reports the version again
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// failingStateService refuses the subscriptions to the runtime version
+type failingStateService struct {
+	attempts atomic.Int32
+}
+
+func (f *failingStateService) SubscribeRuntimeVersion() (string, error) {
+	f.attempts.Add(1)
+	return "", errors.New("subscription refused")
+}
+
+func TestRuntimeListeners(t *testing.T) {
+	l := newRuntimeListeners()
+	var got []uint32
+	remove := l.add(func(u RuntimeUpgrade) {
+		got = append(got, uint32(u.Current.SpecVersion))
+	})
+	// a panicking listener does not stop the others
+	l.add(func(RuntimeUpgrade) { panic("listener") })
+
+	l.notify(RuntimeUpgrade{Current: types.RuntimeVersion{SpecVersion: 101}})
+	remove()
+	l.notify(RuntimeUpgrade{Current: types.RuntimeVersion{SpecVersion: 102}})
+	assert.Equal(t, []uint32{101}, got)
+}
+
+func TestSameRuntime(t *testing.T) {
+	v := types.RuntimeVersion{SpecName: "cess-node", SpecVersion: 100, TransactionVersion: 1}
+	assert.True(t, sameRuntime(v, v))
+	upgraded := v
+	upgraded.SpecVersion = 101
+	assert.False(t, sameRuntime(v, upgraded))
+	upgraded = v
+	upgraded.TransactionVersion = 2
+	assert.False(t, sameRuntime(v, upgraded))
+}
+
+func TestWatchRuntimeBackoff(t *testing.T) {
+	node := newFakeNode(t, 1, 100)
+	state := &failingStateService{}
+	assert.NoError(t, node.rpc.RegisterName("state", state))
+
+	st := &clientState{pool: newEndpointPool([]string{node.url}, time.Second, 3)}
+	defer st.pool.close()
+	st.pool.checkAll(context.Background())
+	c := &ChainClient{clientState: st, api: newSubstrateAPI(context.Background(), st)}
+
+	// the retries do not depend on the health check interval
+	ctx, cancel := context.WithTimeout(context.Background(), 1500*time.Millisecond)
+	defer cancel()
+	c.watchRuntime(ctx)
+	// the first attempt and a retry after 1s
+	assert.Equal(t, int32(2), state.attempts.Load())
+}
@@ -8,65 +8,24 @@
 package chain
 
 import (
-	"fmt"
 	"log"
 
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
 	"github.com/CESSProject/cess-go-sdk/utils"
 )
 
 // QueryCurrentCounters query the validator's credit score
 //   - accountId: validator's account id
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - SchedulerCounterEntry: validator's credit score
 //   - error: error message
-func (c *ChainClient) QueryCurrentCounters(accountId []byte, block int32) (SchedulerCounterEntry, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return SchedulerCounterEntry{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), SchedulerCredit, CurrentCounters, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryCurrentCounters(accountId []byte, block BlockRef) (SchedulerCounterEntry, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data SchedulerCounterEntry
-
-	key, err := types.CreateStorageKey(c.metadata, SchedulerCredit, CurrentCounters, accountId)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), SchedulerCredit, CurrentCounters, err)
-		return data, err
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), SchedulerCredit, CurrentCounters, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), SchedulerCredit, CurrentCounters, err)
-		c.SetRpcState(false)
-		return data, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[SchedulerCounterEntry](c.ctx, c, SchedulerCredit, CurrentCounters, block, accountId)
 }
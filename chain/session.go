@@ -8,7 +8,6 @@
 package chain
 
 import (
-	"fmt"
 	"log"
 
 	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
@@ -16,56 +15,17 @@ import (
 )
 
 // QueryValidators query validators account (waiting nodes not included)
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - []types.AccountID: validators account
 //   - error: error message
-func (c *ChainClient) QueryValidators(block int32) ([]types.AccountID, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []types.AccountID{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), Session, Validators, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryValidators(block BlockRef) ([]types.AccountID, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data []types.AccountID
-
-	key, err := types.CreateStorageKey(c.metadata, Session, Validators)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] CreateStorageKey: %v", c.GetCurrentRpcAddr(), Session, Validators, err)
-		return data, err
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), Session, Validators, err)
-			c.SetRpcState(false)
-			return data, err
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageLatest: %v", c.GetCurrentRpcAddr(), Session, Validators, err)
-		c.SetRpcState(false)
-		return data, err
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
-	}
-	return data, nil
+	return QueryStorage[[]types.AccountID](c.ctx, c, Session, Validators, block)
 }
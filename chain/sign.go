@@ -0,0 +1,102 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"golang.org/x/crypto/blake2b"
+)
+
+// signExtrinsic signs the extrinsic with s, it is the counterpart of
+// types.Extrinsic.Sign for keys that are not held by a keyring pair,
+// a signer.ContextSigner stops signing when ctx is done
+func signExtrinsic(ctx context.Context, ext *types.Extrinsic, s signer.Signer, o types.SignatureOptions) error {
+	if ext.Type() != types.ExtrinsicVersion4 {
+		return fmt.Errorf("unsupported extrinsic version: %v", ext.Version)
+	}
+
+	mb, err := codec.Encode(ext.Method)
+	if err != nil {
+		return err
+	}
+	era := o.Era
+	if !o.Era.IsMortalEra {
+		era = types.ExtrinsicEra{IsImmortalEra: true}
+	}
+	payload := types.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: types.ExtrinsicPayloadV3{
+			Method:      mb,
+			Era:         era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+			GenesisHash: o.GenesisHash,
+			BlockHash:   o.BlockHash,
+		},
+		TransactionVersion: o.TransactionVersion,
+	}
+	data, err := codec.Encode(payload)
+	if err != nil {
+		return err
+	}
+	// payloads longer than 256 bytes are signed by their hash
+	if len(data) > 256 {
+		h := blake2b.Sum256(data)
+		data = h[:]
+	}
+
+	sig, err := signer.SignContext(ctx, s, data)
+	if err != nil {
+		return err
+	}
+	multiSig, err := newMultiSignature(s.Scheme(), sig)
+	if err != nil {
+		return err
+	}
+	address, err := types.NewMultiAddressFromAccountID(signer.AccountID(s))
+	if err != nil {
+		return err
+	}
+
+	ext.Signature = types.ExtrinsicSignatureV4{
+		Signer:    address,
+		Signature: multiSig,
+		Era:       era,
+		Nonce:     o.Nonce,
+		Tip:       o.Tip,
+	}
+	ext.Version |= types.ExtrinsicBitSigned
+	return nil
+}
+
+// newMultiSignature wraps the signature of the given scheme
+func newMultiSignature(scheme signer.Scheme, sig []byte) (types.MultiSignature, error) {
+	switch scheme {
+	case signer.Sr25519:
+		if len(sig) != 64 {
+			return types.MultiSignature{}, fmt.Errorf("invalid sr25519 signature length: %d", len(sig))
+		}
+		return types.MultiSignature{IsSr25519: true, AsSr25519: types.NewSignature(sig)}, nil
+	case signer.Ed25519:
+		if len(sig) != 64 {
+			return types.MultiSignature{}, fmt.Errorf("invalid ed25519 signature length: %d", len(sig))
+		}
+		return types.MultiSignature{IsEd25519: true, AsEd25519: types.NewSignature(sig)}, nil
+	case signer.Ecdsa:
+		if len(sig) != 65 {
+			return types.MultiSignature{}, fmt.Errorf("invalid ecdsa signature length: %d", len(sig))
+		}
+		return types.MultiSignature{IsEcdsa: true, AsEcdsa: types.NewEcdsaSignature(sig)}, nil
+	}
+	return types.MultiSignature{}, signer.ErrUnknownScheme
+}
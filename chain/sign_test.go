@@ -0,0 +1,93 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignExtrinsic(t *testing.T) {
+	call := types.Call{CallIndex: types.CallIndex{SectionIndex: 4, MethodIndex: 3}, Args: []byte{1, 2, 3}}
+	o := types.SignatureOptions{
+		Era:                types.ExtrinsicEra{IsImmortalEra: true},
+		Nonce:              types.NewUCompactFromUInt(7),
+		Tip:                types.NewUCompactFromUInt(0),
+		SpecVersion:        100,
+		TransactionVersion: 1,
+	}
+	payload, err := codec.Encode(types.ExtrinsicPayloadV4{
+		ExtrinsicPayloadV3: types.ExtrinsicPayloadV3{
+			Method:      []byte{4, 3, 1, 2, 3},
+			Era:         o.Era,
+			Nonce:       o.Nonce,
+			Tip:         o.Tip,
+			SpecVersion: o.SpecVersion,
+		},
+		TransactionVersion: o.TransactionVersion,
+	})
+	require.NoError(t, err)
+
+	for _, scheme := range []signer.Scheme{signer.Sr25519, signer.Ed25519, signer.Ecdsa} {
+		s, err := signer.NewKeyringSigner(scheme, "//Alice")
+		require.NoError(t, err)
+
+		ext := types.NewExtrinsic(call)
+		require.NoError(t, signExtrinsic(context.Background(), &ext, s, o))
+		assert.True(t, ext.IsSigned())
+		assert.Equal(t, signer.AccountID(s), ext.Signature.Signer.AsID[:])
+
+		var sig []byte
+		switch scheme {
+		case signer.Sr25519:
+			sig = ext.Signature.Signature.AsSr25519[:]
+		case signer.Ed25519:
+			sig = ext.Signature.Signature.AsEd25519[:]
+		case signer.Ecdsa:
+			sig = ext.Signature.Signature.AsEcdsa[:]
+		}
+		ok, err := signer.Verify(scheme, s.PublicKey(), payload, sig)
+		require.NoError(t, err)
+		assert.True(t, ok, scheme.String())
+	}
+}
+
+func TestTxSigner(t *testing.T) {
+	c := &ChainClient{clientState: &clientState{}}
+	_, err := c.txSigner(TxOptions{})
+	assert.ErrorIs(t, err, ERR_NoSigner)
+
+	alice, err := signer.NewKeyringSigner(signer.Sr25519, "//Alice")
+	require.NoError(t, err)
+	require.NoError(t, WithSigner(alice)(c))
+	ks := signer.NewKeystore()
+	bob, err := ks.Derive("bob", signer.Ed25519, "", "//Bob")
+	require.NoError(t, err)
+	require.NoError(t, WithKeystore(ks)(c))
+
+	s, err := c.txSigner(TxOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, alice, s)
+
+	o, err := newTxOptions(TxOptions{}, []TxOption{WithAccount("bob")})
+	require.NoError(t, err)
+	s, err = c.txSigner(o)
+	require.NoError(t, err)
+	assert.Equal(t, bob, s)
+
+	o, err = newTxOptions(TxOptions{}, []TxOption{WithAccount("carol")})
+	require.NoError(t, err)
+	_, err = c.txSigner(o)
+	assert.ErrorIs(t, err, signer.ErrAccountNotFound)
+}
@@ -0,0 +1,299 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/scale"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+)
+
+// StorageEntry is an entry of a storage map with its raw key and value
+type StorageEntry struct {
+	Key   types.StorageKey
+	Value types.StorageDataRaw
+}
+
+// KeyValue is an entry of a storage map with its decoded key and value
+type KeyValue[K, V any] struct {
+	Key   K
+	Value V
+}
+
+// QueryStorage reads a storage item and decodes it into T
+//   - ctx: context of the query, nil uses the context of the client
+//   - c: chain client
+//   - pallet: pallet name, e.g. FileBank
+//   - item: storage item name, e.g. File
+//   - block: block to read at, LatestBlock() for the latest block
+//   - keys: keys of a storage map, []byte keys are used as they are encoded,
+//     other keys are SCALE encoded
+//
+// Return:
+//   - T: the value
+//   - error: error message, ERR_RPC_EMPTY_VALUE if the item is empty
+func QueryStorage[T any](ctx context.Context, c Chainer, pallet, item string, block BlockRef, keys ...any) (T, error) {
+	var value T
+	data, err := c.GetStorageRaw(ctx, pallet, item, block, keys...)
+	if err != nil {
+		return value, err
+	}
+	if err = codec.Decode(data, &value); err != nil {
+		return value, fmt.Errorf("rpc err: [%s] [st] [%s.%s] Decode: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+	}
+	return value, nil
+}
+
+// QueryMap reads all entries of a storage map and decodes their keys into K and values into V
+//   - ctx: context of the query, nil uses the context of the client
+//   - c: chain client
+//   - pallet: pallet name, e.g. Sminer
+//   - item: storage map name, e.g. MinerItems
+//   - block: block to read at, LatestBlock() for the latest block
+//   - prefixKeys: the first keys of a map with several keys, only the entries under them are read
+//
+// Return:
+//   - []KeyValue[K, V]: entries of the map, K is a struct with a field per key
+//     if more than one key follows the prefix keys
+//   - error: error message
+//
+// Note:
+//   - the keys can only be decoded if they are hashed with a concat hasher
+//     like Blake2_128Concat and Twox64Concat, or not hashed
+func QueryMap[K, V any](ctx context.Context, c Chainer, pallet, item string, block BlockRef, prefixKeys ...any) ([]KeyValue[K, V], error) {
+	entries, err := c.GetStorageEntriesRaw(ctx, pallet, item, block, prefixKeys...)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := storageEntry(c.GetMetadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	prefix, err := encodeStorageKeys(prefixKeys)
+	if err != nil {
+		return nil, err
+	}
+	prefixKey, err := entryKey(pallet, item, entry, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]KeyValue[K, V], 0, len(entries))
+	for _, e := range entries {
+		var kv KeyValue[K, V]
+		if err = decodeMapKey(entry, len(prefix), e.Key[len(prefixKey):], &kv.Key); err != nil {
+			return nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] decode key: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+		if err = codec.Decode(e.Value, &kv.Value); err != nil {
+			return nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] Decode: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+		result = append(result, kv)
+	}
+	return result, nil
+}
+
+// GetStorageRaw reads the raw value of a storage item, see QueryStorage
+func (c *ChainClient) GetStorageRaw(ctx context.Context, pallet, item string, block BlockRef, keys ...any) (types.StorageDataRaw, error) {
+	c = c.withQueryContext(ctx)
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), pallet, item, ERR_RPC_CONNECTION.Error())
+		}
+	}
+
+	entry, err := storageEntry(c.GetMetadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := encodeStorageKeys(keys)
+	if err != nil {
+		return nil, err
+	}
+	if entry.IsMap() && len(encoded) != len(entry.Type.AsMap.Hashers) {
+		return nil, fmt.Errorf("%s.%s requires %d keys, got %d", pallet, item, len(entry.Type.AsMap.Hashers), len(encoded))
+	}
+	key, err := entryKey(pallet, item, entry, encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *types.StorageDataRaw
+	if block.kind == blockRefLatest {
+		data, err = c.api.RPC.State.GetStorageRawLatest(key)
+		if err != nil {
+			if isTransportError(err) {
+				c.SetRpcState(false)
+			}
+			return nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageRawLatest: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+	} else {
+		blockhash, err := c.ResolveBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		data, err = c.api.RPC.State.GetStorageRaw(key, blockhash)
+		if err != nil {
+			if isTransportError(err) {
+				c.SetRpcState(false)
+			}
+			return nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetStorageRaw: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+	}
+	if len(*data) == 0 {
+		return nil, ERR_RPC_EMPTY_VALUE
+	}
+	return *data, nil
+}
+
+// GetStorageEntriesRaw reads the raw entries of a storage map page by page, see QueryMap
+func (c *ChainClient) GetStorageEntriesRaw(ctx context.Context, pallet, item string, block BlockRef, prefixKeys ...any) ([]StorageEntry, error) {
+	c = c.withQueryContext(ctx)
+	opts, err := c.iterOptionsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	var result []StorageEntry
+	err = c.IterateStorage(c.ctx, pallet, item, opts, func(e StorageEntry) error {
+		result = append(result, e)
+		return nil
+	}, prefixKeys...)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// withQueryContext returns a client bound to ctx, or c if ctx is nil
+func (c *ChainClient) withQueryContext(ctx context.Context) *ChainClient {
+	if ctx == nil || ctx == c.ctx {
+		return c
+	}
+	return c.WithContext(ctx).(*ChainClient)
+}
+
+// storageEntry returns the metadata of the storage item
+func storageEntry(meta *types.Metadata, pallet, item string) (types.StorageEntryMetadataV14, error) {
+	if meta == nil {
+		return types.StorageEntryMetadataV14{}, errors.New("metadata is not loaded")
+	}
+	for _, p := range meta.AsMetadataV14.Pallets {
+		if !p.HasStorage || string(p.Storage.Prefix) != pallet {
+			continue
+		}
+		for _, entry := range p.Storage.Items {
+			if string(entry.Name) == item {
+				return entry, nil
+			}
+		}
+		return types.StorageEntryMetadataV14{}, fmt.Errorf("storage %s not found in pallet %s", item, pallet)
+	}
+	return types.StorageEntryMetadataV14{}, fmt.Errorf("pallet %s not found in metadata", pallet)
+}
+
+// encodeStorageKeys encodes the keys of a storage map,
+// []byte keys are taken as encoded
+func encodeStorageKeys(keys []any) ([][]byte, error) {
+	encoded := make([][]byte, len(keys))
+	for k, key := range keys {
+		if b, ok := key.([]byte); ok {
+			encoded[k] = b
+			continue
+		}
+		b, err := codec.Encode(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[Encode key %d]", k)
+		}
+		encoded[k] = b
+	}
+	return encoded, nil
+}
+
+// entryKey builds the storage key of the item, the keys may be
+// the first keys of the map to build the prefix of its entries
+func entryKey(pallet, item string, entry types.StorageEntryMetadataV14, keys [][]byte) (types.StorageKey, error) {
+	key := CreatePrefixedKey(pallet, item)
+	if !entry.IsMap() {
+		if len(keys) > 0 {
+			return nil, fmt.Errorf("%s.%s is not a map", pallet, item)
+		}
+		return key, nil
+	}
+	hashers := entry.Type.AsMap.Hashers
+	if len(keys) > len(hashers) {
+		return nil, fmt.Errorf("%s.%s has %d keys, got %d", pallet, item, len(hashers), len(keys))
+	}
+	for k, arg := range keys {
+		h, err := hashers[k].HashFunc()
+		if err != nil {
+			return nil, err
+		}
+		if _, err = h.Write(arg); err != nil {
+			return nil, err
+		}
+		key = append(key, h.Sum(nil)...)
+	}
+	return key, nil
+}
+
+// hasherPrefixLen returns the length of the hash that precedes the key,
+// ok is false if the hasher does not keep the key
+func hasherPrefixLen(h types.StorageHasherV10) (n int, ok bool) {
+	switch {
+	case h.IsBlake2_128Concat:
+		return 16, true
+	case h.IsTwox64Concat:
+		return 8, true
+	case h.IsIdentity:
+		return 0, true
+	}
+	return 0, false
+}
+
+// decodeMapKey decodes the keys that follow the first skip keys from
+// the end of a storage key into target, a struct with a field per key
+// if there are several keys
+func decodeMapKey(entry types.StorageEntryMetadataV14, skip int, data []byte, target any) error {
+	hashers := entry.Type.AsMap.Hashers[skip:]
+	if len(hashers) == 0 {
+		return nil
+	}
+	targets := []any{target}
+	if len(hashers) > 1 {
+		v := reflect.ValueOf(target).Elem()
+		if v.Kind() != reflect.Struct || v.NumField() != len(hashers) {
+			return fmt.Errorf("the key type must be a struct with %d fields", len(hashers))
+		}
+		targets = make([]any, len(hashers))
+		for k := range hashers {
+			targets[k] = v.Field(k).Addr().Interface()
+		}
+	}
+
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+	for k, h := range hashers {
+		n, ok := hasherPrefixLen(h)
+		if !ok {
+			return fmt.Errorf("key %d is hashed and cannot be decoded", skip+k)
+		}
+		if n > 0 {
+			if err := decoder.Read(make([]byte, n)); err != nil {
+				return err
+			}
+		}
+		if err := decoder.Decode(targets[k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+)
+
+// DefaultStoragePageSize is the number of keys read per request when iterating a storage map
+const DefaultStoragePageSize = 512
+
+// IterOptions configures the iteration of a storage map
+type IterOptions struct {
+	// number of entries read per request, DefaultStoragePageSize if 0
+	PageSize uint32
+	// the iteration resumes after this storage key, e.g. the key of the last entry received
+	StartKey types.StorageKey
+	// block the iteration reads the map at, if nil the block the client is pinned to,
+	// or the latest block when the iteration starts
+	BlockHash *types.Hash
+}
+
+// IterateStorage reads the entries of a storage map page by page and passes them to fn,
+// all pages are read at the same block so the iteration is consistent
+//   - ctx: context of the iteration, nil uses the context of the client
+//   - pallet: pallet name, e.g. System
+//   - item: storage map name, e.g. Account
+//   - opts: page size, start key and block of the iteration
+//   - fn: called with each entry in key order, the iteration stops at the first error it returns
+//   - prefixKeys: the first keys of a map with several keys, only the entries under them are read
+//
+// Return:
+//   - error: error message, or the error returned by fn
+func (c *ChainClient) IterateStorage(ctx context.Context, pallet, item string, opts IterOptions, fn func(StorageEntry) error, prefixKeys ...any) error {
+	c = c.withQueryContext(ctx)
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), pallet, item, ERR_RPC_CONNECTION.Error())
+		}
+	}
+
+	entry, err := storageEntry(c.GetMetadata(), pallet, item)
+	if err != nil {
+		return err
+	}
+	if !entry.IsMap() {
+		return fmt.Errorf("%s.%s is not a map", pallet, item)
+	}
+	encoded, err := encodeStorageKeys(prefixKeys)
+	if err != nil {
+		return err
+	}
+	prefix, err := entryKey(pallet, item, entry, encoded)
+	if err != nil {
+		return err
+	}
+
+	var blockhash types.Hash
+	if opts.BlockHash != nil {
+		blockhash = *opts.BlockHash
+	} else if c.at != nil {
+		blockhash = *c.at
+	} else {
+		blockhash, err = c.api.RPC.Chain.GetBlockHashLatest()
+		if err != nil {
+			if isTransportError(err) {
+				c.SetRpcState(false)
+			}
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetBlockHashLatest: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+	}
+	pageSize := opts.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultStoragePageSize
+	}
+
+	start := opts.StartKey
+	for {
+		if err = c.ctx.Err(); err != nil {
+			return err
+		}
+		keys, entries, err := c.storagePage(pallet, item, prefix, pageSize, start, blockhash)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			if err = fn(e); err != nil {
+				return err
+			}
+		}
+		if len(keys) < int(pageSize) {
+			return nil
+		}
+		start = keys[len(keys)-1]
+	}
+}
+
+// StreamStorage reads the entries of a storage map like IterateStorage and sends them on a channel,
+// the entries channel is closed at the end of the iteration and the error channel then
+// receives the error of the iteration, nil if it completed. Cancel ctx to stop it early.
+//   - ctx: context of the iteration, nil uses the context of the client
+//   - pallet: pallet name, e.g. System
+//   - item: storage map name, e.g. Account
+//   - opts: page size, start key and block of the iteration
+//   - prefixKeys: the first keys of a map with several keys, only the entries under them are read
+//
+// Return:
+//   - <-chan StorageEntry: entries of the map in key order
+//   - <-chan error: result of the iteration
+func (c *ChainClient) StreamStorage(ctx context.Context, pallet, item string, opts IterOptions, prefixKeys ...any) (<-chan StorageEntry, <-chan error) {
+	c = c.withQueryContext(ctx)
+	entries := make(chan StorageEntry)
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+		err := c.IterateStorage(c.ctx, pallet, item, opts, func(e StorageEntry) error {
+			select {
+			case entries <- e:
+				return nil
+			case <-c.ctx.Done():
+				return c.ctx.Err()
+			}
+		}, prefixKeys...)
+		close(entries)
+		result <- err
+	}()
+	return entries, result
+}
+
+// IterateMap reads the entries of a storage map like IterateStorage and decodes
+// their keys into K and values into V, see QueryMap for the keys
+//   - ctx: context of the iteration, nil uses the context of the client
+//   - c: chain client
+//   - pallet: pallet name, e.g. System
+//   - item: storage map name, e.g. Account
+//   - opts: page size, start key and block of the iteration
+//   - fn: called with each entry in key order, the iteration stops at the first error it returns
+//   - prefixKeys: the first keys of a map with several keys, only the entries under them are read
+//
+// Return:
+//   - error: error message, or the error returned by fn
+func IterateMap[K, V any](ctx context.Context, c Chainer, pallet, item string, opts IterOptions, fn func(KeyValue[K, V]) error, prefixKeys ...any) error {
+	entry, err := storageEntry(c.GetMetadata(), pallet, item)
+	if err != nil {
+		return err
+	}
+	prefix, err := encodeStorageKeys(prefixKeys)
+	if err != nil {
+		return err
+	}
+	prefixKey, err := entryKey(pallet, item, entry, prefix)
+	if err != nil {
+		return err
+	}
+	return c.IterateStorage(ctx, pallet, item, opts, func(e StorageEntry) error {
+		var kv KeyValue[K, V]
+		if err := decodeMapKey(entry, len(prefix), e.Key[len(prefixKey):], &kv.Key); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] decode key: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+		if err := codec.Decode(e.Value, &kv.Value); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] Decode: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+		return fn(kv)
+	}, prefixKeys...)
+}
+
+// storagePage reads the keys that follow start under the prefix and their values,
+// the page is read again once if the connection is lost
+func (c *ChainClient) storagePage(pallet, item string, prefix types.StorageKey, count uint32, start types.StorageKey, blockhash types.Hash) ([]types.StorageKey, []StorageEntry, error) {
+	keys, entries, err := c.readStoragePage(pallet, item, prefix, count, start, blockhash)
+	if err == nil || c.GetRpcState() {
+		return keys, entries, err
+	}
+	if c.ReconnectRpc() != nil {
+		return nil, nil, err
+	}
+	return c.readStoragePage(pallet, item, prefix, count, start, blockhash)
+}
+
+func (c *ChainClient) readStoragePage(pallet, item string, prefix types.StorageKey, count uint32, start types.StorageKey, blockhash types.Hash) ([]types.StorageKey, []StorageEntry, error) {
+	var startKey any
+	if len(start) > 0 {
+		startKey = start.Hex()
+	}
+	var res []string
+	err := c.api.Client.Call(&res, RPC_State_getKeysPaged, prefix.Hex(), count, startKey, blockhash.Hex())
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return nil, nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s: %v", c.GetCurrentRpcAddr(), pallet, item, RPC_State_getKeysPaged, err)
+	}
+	if len(res) == 0 {
+		return nil, nil, nil
+	}
+	keys := make([]types.StorageKey, len(res))
+	for k, r := range res {
+		if keys[k], err = codec.HexDecodeString(r); err != nil {
+			return nil, nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s: %v", c.GetCurrentRpcAddr(), pallet, item, RPC_State_getKeysPaged, err)
+		}
+	}
+
+	set, err := c.api.RPC.State.QueryStorageAt(keys, blockhash)
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return nil, nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAt: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+	}
+	var entries []StorageEntry
+	for _, elem := range set {
+		for _, change := range elem.Changes {
+			// the keys are read at the block, they all have a value there
+			if !change.HasStorageData {
+				return nil, nil, fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAt: no value for key %s", c.GetCurrentRpcAddr(), pallet, item, change.StorageKey.Hex())
+			}
+			entries = append(entries, StorageEntry{Key: change.StorageKey, Value: change.StorageData})
+		}
+	}
+	return keys, entries, nil
+}
+
+// iterOptionsAt returns the options to iterate a storage map at the block
+func (c *ChainClient) iterOptionsAt(block BlockRef) (IterOptions, error) {
+	if block.kind == blockRefLatest {
+		return IterOptions{}, nil
+	}
+	blockhash, err := c.ResolveBlock(block)
+	if err != nil {
+		return IterOptions{}, err
+	}
+	return IterOptions{BlockHash: &blockhash}, nil
+}
+
+// queryAllValues reads the values of all entries of a storage map page by page,
+// it fails on the first value that cannot be decoded
+func queryAllValues[V any](c *ChainClient, pallet, item string, block BlockRef) ([]V, error) {
+	opts, err := c.iterOptionsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	var result []V
+	err = c.IterateStorage(c.ctx, pallet, item, opts, func(e StorageEntry) error {
+		var data V
+		if err := codec.Decode(e.Value, &data); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] Decode %s: %v", c.GetCurrentRpcAddr(), pallet, item, e.Key.Hex(), err)
+		}
+		result = append(result, data)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// queryAllEntries reads all entries of a storage map page by page and decodes
+// their keys, it fails on the first entry that cannot be decoded
+func queryAllEntries[K, V any](c *ChainClient, pallet, item string, block BlockRef) ([]KeyValue[K, V], error) {
+	opts, err := c.iterOptionsAt(block)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := storageEntry(c.GetMetadata(), pallet, item)
+	if err != nil {
+		return nil, err
+	}
+	prefixLen := len(CreatePrefixedKey(pallet, item))
+	var result []KeyValue[K, V]
+	err = c.IterateStorage(c.ctx, pallet, item, opts, func(e StorageEntry) error {
+		var kv KeyValue[K, V]
+		if err := codec.Decode(e.Value, &kv.Value); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] Decode %s: %v", c.GetCurrentRpcAddr(), pallet, item, e.Key.Hex(), err)
+		}
+		if err := decodeMapKey(entry, 0, e.Key[prefixLen:], &kv.Key); err != nil {
+			return fmt.Errorf("rpc err: [%s] [st] [%s.%s] decode key: %v", c.GetCurrentRpcAddr(), pallet, item, err)
+		}
+		result = append(result, kv)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,192 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStateService serves the state_* rpc methods used by the storage iteration
+type fakeStateService struct {
+	storage map[string]string
+	pages   atomic.Int32
+	// block of the last storage read, empty for the latest block
+	lastAt atomic.Value
+}
+
+func (f *fakeStateService) GetStorage(key string, at *string) *string {
+	f.lastAt.Store("")
+	if at != nil {
+		f.lastAt.Store(*at)
+	}
+	value, ok := f.storage[key]
+	if !ok {
+		return nil
+	}
+	return &value
+}
+
+func (f *fakeStateService) GetKeysPaged(prefix string, count uint32, startKey *string, at string) []string {
+	f.pages.Add(1)
+	var keys []string
+	for k := range f.storage {
+		if strings.HasPrefix(k, prefix) && (startKey == nil || k > *startKey) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	if len(keys) > int(count) {
+		keys = keys[:count]
+	}
+	return keys
+}
+
+func (f *fakeStateService) QueryStorageAt(keys []string, at string) []map[string]interface{} {
+	changes := make([][]interface{}, len(keys))
+	for k, key := range keys {
+		changes[k] = []interface{}{key, f.storage[key]}
+		if f.storage[key] == "" {
+			// a key without data
+			changes[k] = []interface{}{key}
+		}
+	}
+	return []map[string]interface{}{{"block": at, "changes": changes}}
+}
+
+func newStorageTestClient(t *testing.T, storage map[string]string) (*ChainClient, *fakeStateService) {
+	node := newFakeNode(t, 1, 100)
+	state := &fakeStateService{storage: storage}
+	require.NoError(t, node.rpc.RegisterName("state", state))
+
+	st := &clientState{pool: newEndpointPool([]string{node.url}, time.Second, 3)}
+	t.Cleanup(st.pool.close)
+	st.pool.checkAll(context.Background())
+	st.runtime.Store(&runtimeState{metadata: testStorageMetadata()})
+	ctx := context.Background()
+	return &ChainClient{clientState: st, ctx: ctx, api: newSubstrateAPI(ctx, st)}, state
+}
+
+func TestIterateStorage(t *testing.T) {
+	meta := testStorageMetadata()
+	entry, err := storageEntry(meta, Multisig, Multisigs)
+	require.NoError(t, err)
+
+	storage := make(map[string]string)
+	multisigs := [][]byte{bytes.Repeat([]byte{1}, 32), bytes.Repeat([]byte{2}, 32)}
+	for _, multisig := range multisigs {
+		for i := byte(0); i < 5; i++ {
+			key, err := entryKey(Multisig, Multisigs, entry, [][]byte{multisig, bytes.Repeat([]byte{i}, 32)})
+			require.NoError(t, err)
+			value, err := codec.Encode(types.NewU32(uint32(i)))
+			require.NoError(t, err)
+			storage[key.Hex()] = codec.HexEncodeToString(value)
+		}
+	}
+	c, state := newStorageTestClient(t, storage)
+	at := types.NewHash(bytes.Repeat([]byte{9}, 32))
+
+	var entries []StorageEntry
+	opts := IterOptions{PageSize: 2, BlockHash: &at}
+	err = c.IterateStorage(nil, Multisig, Multisigs, opts, func(e StorageEntry) error {
+		entries = append(entries, e)
+		return nil
+	}, multisigs[0])
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+	assert.Equal(t, int32(3), state.pages.Load())
+
+	// resume after the third entry
+	var resumed []StorageEntry
+	opts.StartKey = entries[2].Key
+	err = c.IterateStorage(nil, Multisig, Multisigs, opts, func(e StorageEntry) error {
+		resumed = append(resumed, e)
+		return nil
+	}, multisigs[0])
+	require.NoError(t, err)
+	assert.Equal(t, entries[3:], resumed)
+
+	// the keys are decoded from the storage keys
+	var values []KeyValue[types.Hash, types.U32]
+	err = IterateMap(nil, c, Multisig, Multisigs, IterOptions{PageSize: 3, BlockHash: &at}, func(kv KeyValue[types.Hash, types.U32]) error {
+		values = append(values, kv)
+		return nil
+	}, multisigs[1])
+	require.NoError(t, err)
+	require.Len(t, values, 5)
+	// the entries are ordered by the hashes of their keys
+	for _, kv := range values {
+		assert.Equal(t, types.NewHash(bytes.Repeat([]byte{byte(kv.Value)}, 32)), kv.Key)
+	}
+
+	stream, result := c.StreamStorage(nil, Multisig, Multisigs, IterOptions{PageSize: 4, BlockHash: &at})
+	var count int
+	for range stream {
+		count++
+	}
+	assert.NoError(t, <-result)
+	assert.Equal(t, 10, count)
+}
+
+func TestQueryAllEntries(t *testing.T) {
+	entry, err := storageEntry(testStorageMetadata(), System, Account)
+	require.NoError(t, err)
+
+	storage := make(map[string]string)
+	for i := byte(1); i <= 3; i++ {
+		key, err := entryKey(System, Account, entry, [][]byte{bytes.Repeat([]byte{i}, 32)})
+		require.NoError(t, err)
+		value, err := codec.Encode(types.NewU32(uint32(i)))
+		require.NoError(t, err)
+		storage[key.Hex()] = codec.HexEncodeToString(value)
+	}
+	c, _ := newStorageTestClient(t, storage)
+	result, err := queryAllEntries[types.AccountID, types.U32](c, System, Account, BlockNumber(0))
+	require.NoError(t, err)
+	require.Len(t, result, 3)
+	for _, kv := range result {
+		assert.Equal(t, bytes.Repeat([]byte{byte(kv.Value)}, 32), kv.Key.ToBytes())
+	}
+	values, err := queryAllValues[types.U32](c, System, Account, BlockNumber(0))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []types.U32{1, 2, 3}, values)
+
+	// a value that cannot be decoded fails the query instead of being skipped
+	key, err := entryKey(System, Account, entry, [][]byte{bytes.Repeat([]byte{4}, 32)})
+	require.NoError(t, err)
+	storage[key.Hex()] = "0x04"
+	c, _ = newStorageTestClient(t, storage)
+	at := types.NewHash(bytes.Repeat([]byte{9}, 32))
+	err = IterateMap(nil, c, System, Account, IterOptions{BlockHash: &at}, func(kv KeyValue[types.AccountID, types.U32]) error {
+		return nil
+	})
+	assert.Error(t, err)
+	_, err = queryAllEntries[types.AccountID, types.U32](c, System, Account, BlockNumber(0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), key.Hex())
+	_, err = queryAllValues[types.U32](c, System, Account, BlockNumber(0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), key.Hex())
+
+	// a key without a value fails the iteration
+	storage[key.Hex()] = ""
+	c, _ = newStorageTestClient(t, storage)
+	_, err = queryAllEntries[types.AccountID, types.U32](c, System, Account, BlockNumber(0))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no value for key "+key.Hex())
+}
@@ -0,0 +1,126 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/xxhash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testStorageMetadata() *types.Metadata {
+	multisigs := types.StorageEntryMetadataV14{
+		Name: Multisigs,
+		Type: types.StorageEntryTypeV14{IsMap: true, AsMap: types.MapTypeV14{Hashers: []types.StorageHasherV10{
+			{IsTwox64Concat: true},
+			{IsBlake2_128Concat: true},
+		}}},
+	}
+	plain := types.StorageEntryMetadataV14{Name: "Counter", Type: types.StorageEntryTypeV14{IsPlainType: true}}
+	account := types.StorageEntryMetadataV14{
+		Name: Account,
+		Type: types.StorageEntryTypeV14{IsMap: true, AsMap: types.MapTypeV14{Hashers: []types.StorageHasherV10{{IsBlake2_128Concat: true}}}},
+	}
+	return &types.Metadata{AsMetadataV14: types.MetadataV14{Pallets: []types.PalletMetadataV14{
+		{Name: Multisig, HasStorage: true, Storage: types.StorageMetadataV14{Prefix: Multisig, Items: []types.StorageEntryMetadataV14{multisigs, plain}}},
+		{Name: System, HasStorage: true, Storage: types.StorageMetadataV14{Prefix: System, Items: []types.StorageEntryMetadataV14{account}}},
+	}}}
+}
+
+func TestStorageEntry(t *testing.T) {
+	meta := testStorageMetadata()
+	entry, err := storageEntry(meta, Multisig, Multisigs)
+	require.NoError(t, err)
+	assert.True(t, entry.IsMap())
+
+	_, err = storageEntry(meta, Multisig, "Unknown")
+	assert.Error(t, err)
+	_, err = storageEntry(meta, Proxy, Proxies)
+	assert.Error(t, err)
+	_, err = storageEntry(nil, Multisig, Multisigs)
+	assert.Error(t, err)
+}
+
+func TestEntryKey(t *testing.T) {
+	meta := testStorageMetadata()
+	entry, err := storageEntry(meta, Multisig, Multisigs)
+	require.NoError(t, err)
+
+	multisig := bytes.Repeat([]byte{1}, 32)
+	callHash := types.NewHash(bytes.Repeat([]byte{2}, 32))
+	keys, err := encodeStorageKeys([]any{multisig, callHash})
+	require.NoError(t, err)
+
+	key, err := entryKey(Multisig, Multisigs, entry, keys)
+	require.NoError(t, err)
+	assert.Len(t, key, 32+8+32+16+32)
+
+	// the prefix of the entries of one multisig account
+	prefix, err := entryKey(Multisig, Multisigs, entry, keys[:1])
+	require.NoError(t, err)
+	assert.Equal(t, append(CreatePrefixedKey(Multisig, Multisigs), xxhash.New64Concat(multisig).Sum(nil)...), []byte(prefix))
+	assert.True(t, bytes.HasPrefix(key, prefix))
+
+	_, err = entryKey(Multisig, Multisigs, entry, append(keys, []byte{3}))
+	assert.Error(t, err)
+	plain, err := storageEntry(meta, Multisig, "Counter")
+	require.NoError(t, err)
+	_, err = entryKey(Multisig, "Counter", plain, keys[:1])
+	assert.Error(t, err)
+
+	// the keys are decoded back from the storage key
+	var both struct {
+		Multisig types.AccountID
+		CallHash types.Hash
+	}
+	prefixKey := CreatePrefixedKey(Multisig, Multisigs)
+	require.NoError(t, decodeMapKey(entry, 0, key[len(prefixKey):], &both))
+	assert.Equal(t, multisig, both.Multisig.ToBytes())
+	assert.Equal(t, callHash, both.CallHash)
+
+	var hash types.Hash
+	require.NoError(t, decodeMapKey(entry, 1, key[len(prefix):], &hash))
+	assert.Equal(t, callHash, hash)
+
+	var single types.Hash
+	assert.Error(t, decodeMapKey(entry, 0, key[len(prefixKey):], &single))
+}
+
+func TestEncodeStorageKeys(t *testing.T) {
+	keys, err := encodeStorageKeys([]any{[]byte{1, 2}, types.NewU32(3), "fid"})
+	require.NoError(t, err)
+	fid, err := codec.Encode("fid")
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{{1, 2}, {3, 0, 0, 0}, fid}, keys)
+}
+
+func TestQueryAccountInfoByAccountID(t *testing.T) {
+	entry, err := storageEntry(testStorageMetadata(), System, Account)
+	require.NoError(t, err)
+	accountID := bytes.Repeat([]byte{1}, 32)
+	key, err := entryKey(System, Account, entry, [][]byte{accountID})
+	require.NoError(t, err)
+	value, err := codec.Encode(types.AccountInfo{Nonce: 7})
+	require.NoError(t, err)
+
+	c, _ := newStorageTestClient(t, map[string]string{key.Hex(): codec.HexEncodeToString(value)})
+	info, err := c.QueryAccountInfoByAccountID(accountID, LatestBlock())
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(7), info.Nonce)
+
+	_, err = c.QueryAccountInfoByAccountID(bytes.Repeat([]byte{2}, 32), LatestBlock())
+	assert.ErrorIs(t, err, ERR_RPC_EMPTY_VALUE)
+
+	_, err = c.QueryAccountInfoByAccountID([]byte{1}, LatestBlock())
+	assert.Error(t, err)
+}
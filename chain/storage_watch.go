@@ -0,0 +1,253 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/rpc/state"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types/codec"
+	"github.com/pkg/errors"
+)
+
+// WatchKey identifies a storage item to watch
+type WatchKey struct {
+	// pallet name, e.g. FileBank
+	Pallet string
+	// storage item name, e.g. File
+	Item string
+	// all keys of a storage map, see QueryStorage
+	Keys []any
+}
+
+// StorageChange is a change of a watched storage item
+type StorageChange struct {
+	Key WatchKey
+	// block the item changed in
+	Block types.Hash
+	// encoded values before and after the block, nil if the item does not exist
+	Old types.StorageDataRaw
+	New types.StorageDataRaw
+}
+
+// ValueChange is a change of a watched storage item with its decoded values
+type ValueChange[T any] struct {
+	Key WatchKey
+	// block the item changed in
+	Block types.Hash
+	// values before and after the block, nil if the item does not exist
+	// or if the value cannot be decoded
+	Old *T
+	New *T
+	// error decoding the values, the raw change is delivered by WatchStorage
+	Err error
+}
+
+// WatchStorage subscribes to the changes of the storage items, the subscription
+// is renewed on another rpc endpoint if the connection is lost.
+// The channel is closed when ctx is done.
+//   - ctx: context of the subscription, nil uses the context of the client
+//   - keys: the storage items to watch, each item once
+//
+// Return:
+//   - <-chan StorageChange: the changes of the items, block by block
+//   - error: error message
+//
+// Note:
+//   - the current values are not delivered, read them with the queries
+//   - the changes made while the connection was lost are not delivered one by one,
+//     an item that changed meanwhile is reported once with its net change,
+//     attributed to the block the subscription is renewed at
+func (c *ChainClient) WatchStorage(ctx context.Context, keys ...WatchKey) (<-chan StorageChange, error) {
+	c = c.withQueryContext(ctx)
+	if len(keys) == 0 {
+		return nil, errors.New("no storage item to watch")
+	}
+	if !c.GetRpcState() {
+		if err := c.ReconnectRpc(); err != nil {
+			return nil, fmt.Errorf("rpc err: [%s] [sub] [state_subscribeStorage] %s", c.GetCurrentRpcAddr(), ERR_RPC_CONNECTION.Error())
+		}
+	}
+
+	w, err := newStorageWatcher(c.GetMetadata(), keys)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := c.api.RPC.State.SubscribeStorageRaw(w.storageKeys)
+	if err != nil {
+		if isTransportError(err) {
+			c.SetRpcState(false)
+		}
+		return nil, fmt.Errorf("rpc err: [%s] [sub] [state_subscribeStorage] %v", c.GetCurrentRpcAddr(), err)
+	}
+	changes := make(chan StorageChange)
+	go c.watchStorage(sub, w, changes)
+	return changes, nil
+}
+
+// WatchStorageValues subscribes to the changes of the storage items like
+// WatchStorage and decodes their values into T, the changes whose values cannot
+// be decoded are delivered with the decoding error in Err
+//   - ctx: context of the subscription, nil uses the context of the client
+//   - c: chain client
+//   - keys: the storage items to watch, their values must all be of type T
+//
+// Return:
+//   - <-chan ValueChange[T]: the changes of the items, block by block
+//   - error: error message
+func WatchStorageValues[T any](ctx context.Context, c Chainer, keys ...WatchKey) (<-chan ValueChange[T], error) {
+	if ctx == nil {
+		ctx = c.Context()
+	}
+	raw, err := c.WatchStorage(ctx, keys...)
+	if err != nil {
+		return nil, err
+	}
+	changes := make(chan ValueChange[T])
+	go func() {
+		defer close(changes)
+		for change := range raw {
+			select {
+			case changes <- decodeValueChange[T](change):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// decodeValueChange decodes the values of the change, Err is set if one of them cannot be decoded
+func decodeValueChange[T any](change StorageChange) ValueChange[T] {
+	v := ValueChange[T]{Key: change.Key, Block: change.Block}
+	var err error
+	if v.Old, err = decodeWatchedValue[T](change.Old); err != nil {
+		v.Err = fmt.Errorf("[%s.%s] decode old value: %v", change.Key.Pallet, change.Key.Item, err)
+	}
+	if v.New, err = decodeWatchedValue[T](change.New); err != nil && v.Err == nil {
+		v.Err = fmt.Errorf("[%s.%s] decode new value: %v", change.Key.Pallet, change.Key.Item, err)
+	}
+	return v
+}
+
+func decodeWatchedValue[T any](data types.StorageDataRaw) (*T, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var value T
+	if err := codec.Decode(data, &value); err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+// watchStorage delivers the changes reported by the subscription until
+// the context of the client is done, it subscribes again when the subscription fails
+func (c *ChainClient) watchStorage(sub *state.StorageSubscription, w *storageWatcher, changes chan<- StorageChange) {
+	defer close(changes)
+	for {
+		if sub == nil {
+			var err error
+			if sub, err = resubscribe(c.ctx, func() (*state.StorageSubscription, error) {
+				return c.api.RPC.State.SubscribeStorageRaw(w.storageKeys)
+			}); err != nil {
+				return
+			}
+		}
+		select {
+		case <-c.ctx.Done():
+			sub.Unsubscribe()
+			return
+		case <-sub.Err():
+			sub.Unsubscribe()
+			sub = nil
+		case set, ok := <-sub.Chan():
+			if !ok {
+				sub = nil
+				continue
+			}
+			for _, change := range w.apply(set) {
+				select {
+				case changes <- change:
+				case <-c.ctx.Done():
+					sub.Unsubscribe()
+					return
+				}
+			}
+		}
+	}
+}
+
+// storageWatcher keeps the last values of the watched items to report their changes
+type storageWatcher struct {
+	keys        []WatchKey
+	storageKeys []types.StorageKey
+	// index of the item of each storage key
+	index map[string]int
+	// last values of the items, nil if the item does not exist
+	values map[string]types.StorageDataRaw
+}
+
+func newStorageWatcher(meta *types.Metadata, keys []WatchKey) (*storageWatcher, error) {
+	w := &storageWatcher{
+		keys:        keys,
+		storageKeys: make([]types.StorageKey, len(keys)),
+		index:       make(map[string]int, len(keys)),
+		values:      make(map[string]types.StorageDataRaw, len(keys)),
+	}
+	for k, key := range keys {
+		entry, err := storageEntry(meta, key.Pallet, key.Item)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := encodeStorageKeys(key.Keys)
+		if err != nil {
+			return nil, err
+		}
+		if entry.IsMap() && len(encoded) != len(entry.Type.AsMap.Hashers) {
+			return nil, fmt.Errorf("%s.%s requires %d keys, got %d", key.Pallet, key.Item, len(entry.Type.AsMap.Hashers), len(encoded))
+		}
+		w.storageKeys[k], err = entryKey(key.Pallet, key.Item, entry, encoded)
+		if err != nil {
+			return nil, err
+		}
+		hex := w.storageKeys[k].Hex()
+		if _, ok := w.index[hex]; ok {
+			return nil, fmt.Errorf("%s.%s is watched twice with the same keys", key.Pallet, key.Item)
+		}
+		w.index[hex] = k
+	}
+	return w, nil
+}
+
+// apply records the values of the change set and returns the changes of the items,
+// the first value reported for an item is recorded without being returned
+func (w *storageWatcher) apply(set types.StorageChangeSet) []StorageChange {
+	var changes []StorageChange
+	for _, change := range set.Changes {
+		hex := change.StorageKey.Hex()
+		k, ok := w.index[hex]
+		if !ok {
+			continue
+		}
+		var value types.StorageDataRaw
+		if change.HasStorageData && len(change.StorageData) > 0 {
+			value = change.StorageData
+		}
+		old, seen := w.values[hex]
+		w.values[hex] = value
+		if !seen || bytes.Equal(old, value) {
+			continue
+		}
+		changes = append(changes, StorageChange{Key: w.keys[k], Block: set.Block, Old: old, New: value})
+	}
+	return changes
+}
@@ -0,0 +1,84 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageWatcher(t *testing.T) {
+	alice := bytes.Repeat([]byte{1}, 32)
+	bob := bytes.Repeat([]byte{2}, 32)
+	keys := []WatchKey{
+		{Pallet: System, Item: Account, Keys: []any{alice}},
+		{Pallet: System, Item: Account, Keys: []any{bob}},
+	}
+	w, err := newStorageWatcher(testStorageMetadata(), keys)
+	require.NoError(t, err)
+	require.Len(t, w.storageKeys, 2)
+
+	block := func(b byte, changes ...types.KeyValueOption) types.StorageChangeSet {
+		return types.StorageChangeSet{Block: types.NewHash(bytes.Repeat([]byte{b}, 32)), Changes: changes}
+	}
+	value := func(k int, data ...byte) types.KeyValueOption {
+		return types.KeyValueOption{StorageKey: w.storageKeys[k], HasStorageData: data != nil, StorageData: data}
+	}
+
+	// the values at subscription are not changes
+	assert.Empty(t, w.apply(block(1, value(0, 1), value(1))))
+
+	changes := w.apply(block(2, value(0, 2), value(1, 5)))
+	require.Len(t, changes, 2)
+	assert.Equal(t, keys[0], changes[0].Key)
+	assert.Equal(t, types.StorageDataRaw{1}, changes[0].Old)
+	assert.Equal(t, types.StorageDataRaw{2}, changes[0].New)
+	assert.Nil(t, changes[1].Old)
+	assert.Equal(t, types.StorageDataRaw{5}, changes[1].New)
+
+	// a renewed subscription reports the current values again
+	changes = w.apply(block(3, value(0, 2), value(1)))
+	require.Len(t, changes, 1)
+	assert.Equal(t, keys[1], changes[0].Key)
+	assert.Nil(t, changes[0].New)
+
+	_, err = newStorageWatcher(testStorageMetadata(), []WatchKey{{Pallet: System, Item: Account}})
+	assert.Error(t, err)
+	// an item watched twice
+	_, err = newStorageWatcher(testStorageMetadata(), append(keys, WatchKey{Pallet: System, Item: Account, Keys: []any{alice}}))
+	assert.Error(t, err)
+}
+
+func TestDecodeWatchedValue(t *testing.T) {
+	v, err := decodeWatchedValue[types.U32](nil)
+	require.NoError(t, err)
+	assert.Nil(t, v)
+	v, err = decodeWatchedValue[types.U32](types.StorageDataRaw{7, 0, 0, 0})
+	require.NoError(t, err)
+	assert.Equal(t, types.U32(7), *v)
+}
+
+func TestDecodeValueChange(t *testing.T) {
+	key := WatchKey{Pallet: System, Item: Account}
+	v := decodeValueChange[types.U32](StorageChange{Key: key, New: types.StorageDataRaw{7, 0, 0, 0}})
+	require.NoError(t, v.Err)
+	assert.Nil(t, v.Old)
+	assert.Equal(t, types.U32(7), *v.New)
+
+	// the change is delivered with the error
+	v = decodeValueChange[types.U32](StorageChange{Key: key, Old: types.StorageDataRaw{7, 0, 0, 0}, New: types.StorageDataRaw{1}})
+	require.Error(t, v.Err)
+	assert.Contains(t, v.Err.Error(), "decode new value")
+	assert.Equal(t, key, v.Key)
+	assert.Equal(t, types.U32(7), *v.Old)
+	assert.Nil(t, v.New)
+}
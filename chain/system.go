@@ -58,12 +58,12 @@ func (c *ChainClient) QueryBlockNumber(blockhash string) (uint32, error) {
 
 // QueryAccountInfo query account info
 //   - account: account
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - types.AccountInfo: account info
 //   - error: error message
-func (c *ChainClient) QueryAccountInfo(account string, block int32) (types.AccountInfo, error) {
+func (c *ChainClient) QueryAccountInfo(account string, block BlockRef) (types.AccountInfo, error) {
 	puk, err := utils.ParsingPublickey(account)
 	if err != nil {
 		return types.AccountInfo{}, err
@@ -73,120 +73,37 @@ func (c *ChainClient) QueryAccountInfo(account string, block int32) (types.Accou
 
 // QueryAccountInfoByAccountID query account info
 //   - accountID: account id
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
 //   - types.AccountInfo: account info
 //   - error: error message
-func (c *ChainClient) QueryAccountInfoByAccountID(accountID []byte, block int32) (types.AccountInfo, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return types.AccountInfo{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), System, Account, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryAccountInfoByAccountID(accountID []byte, block BlockRef) (types.AccountInfo, error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var data types.AccountInfo
 	acc, err := types.NewAccountID(accountID)
 	if err != nil {
-		return data, errors.Wrap(err, "[NewAccountID]")
-	}
-
-	b, err := codec.Encode(*acc)
-	if err != nil {
-		return data, errors.Wrap(err, "[EncodeToBytes]")
-	}
-
-	key, err := types.CreateStorageKey(c.metadata, System, Account, b)
-	if err != nil {
-		return data, errors.Wrap(err, "[CreateStorageKey]")
-	}
-
-	if block < 0 {
-		ok, err := c.api.RPC.State.GetStorageLatest(key, &data)
-		if err != nil {
-			return data, errors.Wrap(err, "[GetStorageLatest]")
-		}
-		if !ok {
-			return data, ERR_RPC_EMPTY_VALUE
-		}
-		return data, nil
-	}
-	blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-	if err != nil {
-		return data, err
-	}
-	ok, err := c.api.RPC.State.GetStorage(key, &data, blockhash)
-	if err != nil {
-		return data, errors.Wrap(err, "[GetStorage]")
-	}
-	if !ok {
-		return data, ERR_RPC_EMPTY_VALUE
+		return types.AccountInfo{}, errors.Wrap(err, "[NewAccountID]")
 	}
-	return data, nil
+	return QueryStorage[types.AccountInfo](c.ctx, c, System, Account, block, *acc)
 }
 
 // QueryAllAccountInfo query all account info
-//   - block: block number, less than 0 indicates the latest block
+//   - block: block to read at, LatestBlock() for the latest block
 //
 // Return:
-//   - []types.AccountInfo: all account info
+//   - []KeyValue[types.AccountID, types.AccountInfo]: all accounts with their info
 //   - error: error message
-func (c *ChainClient) QueryAllAccountInfo(block int32) ([]types.AccountInfo, error) {
-	if !c.GetRpcState() {
-		if err := c.ReconnectRpc(); err != nil {
-			return []types.AccountInfo{}, fmt.Errorf("rpc err: [%s] [st] [%s.%s] %s", c.GetCurrentRpcAddr(), System, Account, ERR_RPC_CONNECTION.Error())
-		}
-	}
-
+func (c *ChainClient) QueryAllAccountInfo(block BlockRef) ([]KeyValue[types.AccountID, types.AccountInfo], error) {
 	defer func() {
 		if err := recover(); err != nil {
 			log.Println(utils.RecoverError(err))
 		}
 	}()
 
-	var result []types.AccountInfo
-	key := CreatePrefixedKey(System, Account)
-	keys, err := c.api.RPC.State.GetKeysLatest(key)
-	if err != nil {
-		err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] GetKeysLatest: %v", c.GetCurrentRpcAddr(), System, Account, err)
-		c.SetRpcState(false)
-		return nil, err
-	}
-
-	var set []types.StorageChangeSet
-	if block < 0 {
-		set, err = c.api.RPC.State.QueryStorageAtLatest(keys)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAtLatest: %v", c.GetCurrentRpcAddr(), System, Account, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	} else {
-		blockhash, err := c.api.RPC.Chain.GetBlockHash(uint64(block))
-		if err != nil {
-			return nil, err
-		}
-		set, err = c.api.RPC.State.QueryStorageAt(keys, blockhash)
-		if err != nil {
-			err = fmt.Errorf("rpc err: [%s] [st] [%s.%s] QueryStorageAtLatest: %v", c.GetCurrentRpcAddr(), System, Account, err)
-			c.SetRpcState(false)
-			return nil, err
-		}
-	}
-	for _, elem := range set {
-		for _, change := range elem.Changes {
-			var data types.AccountInfo
-			if err := codec.Decode(change.StorageData, &data); err != nil {
-				continue
-			}
-			result = append(result, data)
-		}
-	}
-	return result, nil
+	return queryAllEntries[types.AccountID, types.AccountInfo](c, System, Account, block)
 }
@@ -0,0 +1,219 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+)
+
+// DefaultFinalizationTimeout is the default time to wait for the finalization
+// of the block that includes a transaction
+const DefaultFinalizationTimeout = BlockInterval * 20
+
+// TxOptions are the options of a single transaction
+type TxOptions struct {
+	// wait until the block that includes the transaction is finalized
+	WaitFinalized bool
+	// time to wait for the finalization after the transaction is included in a block
+	FinalizationTimeout time.Duration
+	// called each time the transaction reaches a new stage
+	OnStatus func(TxStatus)
+	// receives each stage the transaction reaches, it is not closed
+	StatusCh chan<- TxStatus
+	// tip paid to the block author to prioritise the transaction
+	Tip *big.Int
+	// number of blocks the transaction is valid for, 0 makes it immortal
+	Lifetime uint32
+
+	lifetimeSet   bool
+	account       string
+	callTo        *types.Call
+	signer        signer.Signer
+	exportTo      *UnsignedTx
+	exportAccount []byte
+	proxyReal     []byte
+	proxyType     ProxyType
+	dryRun        bool
+	nonce         *uint64
+}
+
+// TxOption configures a single transaction
+type TxOption func(o *TxOptions) error
+
+func newTxOptions(o TxOptions, opts []TxOption) (TxOptions, error) {
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(&o); err != nil {
+			return o, err
+		}
+	}
+	return o, nil
+}
+
+// WaitForFinalization waits until the block that includes the transaction is finalized
+//   - timeout: time to wait for the finalization after the inclusion, 0 uses the default
+func WaitForFinalization(timeout time.Duration) TxOption {
+	return func(o *TxOptions) error {
+		if timeout < 0 {
+			return errors.New("invalid finalization timeout")
+		}
+		o.WaitFinalized = true
+		if timeout > 0 {
+			o.FinalizationTimeout = timeout
+		}
+		return nil
+	}
+}
+
+// WithStatusCallback calls fn each time the transaction reaches a new stage
+func WithStatusCallback(fn func(TxStatus)) TxOption {
+	return func(o *TxOptions) error {
+		o.OnStatus = fn
+		return nil
+	}
+}
+
+// WithStatusChannel sends each stage the transaction reaches to ch,
+// the transaction waits until the status is received
+func WithStatusChannel(ch chan<- TxStatus) TxOption {
+	return func(o *TxOptions) error {
+		o.StatusCh = ch
+		return nil
+	}
+}
+
+// WithTip pays a tip to the block author to prioritise the transaction
+//   - tip: amount of the tip in the smallest unit, 1 CESS is "1000000000000000000"
+func WithTip(tip string) TxOption {
+	return func(o *TxOptions) error {
+		t, err := parseTip(tip)
+		if err != nil {
+			return err
+		}
+		o.Tip = t
+		return nil
+	}
+}
+
+// WithLifetime sets the number of blocks the transaction is valid for,
+// it is rounded up to a power of two between MinTxLifetime and MaxTxLifetime,
+// and capped by System.BlockHashCount of the runtime
+//   - blocks: lifetime in blocks, 0 makes the transaction immortal
+func WithLifetime(blocks uint32) TxOption {
+	return func(o *TxOptions) error {
+		o.Lifetime = blocks
+		o.lifetimeSet = true
+		return nil
+	}
+}
+
+// WithAccount signs the transaction with the named account of the keystore of the client
+//   - name: account name in the keystore
+func WithAccount(name string) TxOption {
+	return func(o *TxOptions) error {
+		if name == "" {
+			return errors.New("empty account name")
+		}
+		o.account = name
+		return nil
+	}
+}
+
+// WithTxSigner signs the transaction with s instead of the signer of the client
+func WithTxSigner(s signer.Signer) TxOption {
+	return func(o *TxOptions) error {
+		if s == nil {
+			return errors.New("nil signer")
+		}
+		o.signer = s
+		return nil
+	}
+}
+
+// BuildCall builds the call of the transaction without submitting it,
+// the transaction method returns an empty receipt and stores the call in out,
+// e.g. to submit several calls with Batch
+func BuildCall(out *types.Call) TxOption {
+	return func(o *TxOptions) error {
+		if out == nil {
+			return errors.New("nil call")
+		}
+		o.callTo = out
+		return nil
+	}
+}
+
+// ExportUnsigned builds the transaction for an offline signer instead of submitting it,
+// the transaction method returns an empty receipt and stores the transaction in out.
+// The lifetime of the transaction is DefaultOfflineTxLifetime unless it is set with WithLifetime.
+//   - accountID: account that signs the transaction offline
+//   - out: receives the unsigned transaction
+func ExportUnsigned(accountID []byte, out *UnsignedTx) TxOption {
+	return func(o *TxOptions) error {
+		if len(accountID) != 32 {
+			return errors.New("invalid account id")
+		}
+		if out == nil {
+			return errors.New("nil unsigned transaction")
+		}
+		o.exportTo = out
+		o.exportAccount = accountID
+		return nil
+	}
+}
+
+// WithNonce sets the nonce of a transaction built with ExportUnsigned or BuildUnsignedTx,
+// e.g. to build several transactions of the account before any is submitted.
+// It is ignored by the submitted transactions, whose nonce is allocated by the client.
+//   - nonce: nonce of the transaction
+func WithNonce(nonce uint64) TxOption {
+	return func(o *TxOptions) error {
+		o.nonce = &nonce
+		return nil
+	}
+}
+
+// ViaProxy submits the transaction through Proxy.proxy on behalf of the real account,
+// the signer must be a proxy of the real account
+//   - real: account the call is dispatched for
+//   - forceType: proxy type the signer is registered with, empty to use the first matching proxy
+func ViaProxy(real []byte, forceType ProxyType) TxOption {
+	return func(o *TxOptions) error {
+		if len(real) != 32 {
+			return errors.New("invalid account id")
+		}
+		o.proxyReal = real
+		o.proxyType = forceType
+		return nil
+	}
+}
+
+// WithDryRun refuses to submit the transaction if its dry run fails
+// or if the free balance of the signer cannot cover the fee and the tip,
+// the node must expose the unsafe system_dryRun rpc
+func WithDryRun() TxOption {
+	return func(o *TxOptions) error {
+		o.dryRun = true
+		return nil
+	}
+}
+
+func parseTip(tip string) (*big.Int, error) {
+	t, ok := new(big.Int).SetString(tip, 10)
+	if !ok || t.Sign() < 0 {
+		return nil, errors.New("invalid tip")
+	}
+	return t, nil
+}
@@ -0,0 +1,116 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// TxStage is a stage a submitted transaction goes through
+type TxStage uint8
+
+const (
+	// the transaction is in the future queue of the transaction pool
+	TxStageFuture TxStage = iota
+	// the transaction is in the ready queue of the transaction pool
+	TxStageReady
+	// the transaction was broadcast to other nodes
+	TxStageBroadcast
+	// the transaction was included in a block
+	TxStageInBlock
+	// the block including the transaction was retracted
+	TxStageRetracted
+	// the block including the transaction was finalized
+	TxStageFinalized
+	// the block including the transaction was not finalized in time
+	TxStageFinalityTimeout
+	// the transaction was replaced by another transaction with the same nonce
+	TxStageUsurped
+	// the transaction was dropped from the transaction pool
+	TxStageDropped
+	// the transaction is no longer valid
+	TxStageInvalid
+)
+
+var txStageNames = map[TxStage]string{
+	TxStageFuture:          "future",
+	TxStageReady:           "ready",
+	TxStageBroadcast:       "broadcast",
+	TxStageInBlock:         "inBlock",
+	TxStageRetracted:       "retracted",
+	TxStageFinalized:       "finalized",
+	TxStageFinalityTimeout: "finalityTimeout",
+	TxStageUsurped:         "usurped",
+	TxStageDropped:         "dropped",
+	TxStageInvalid:         "invalid",
+}
+
+// String returns the name of the stage
+func (s TxStage) String() string {
+	if name, ok := txStageNames[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// TxStatus is reported each time a submitted transaction reaches a new stage
+type TxStatus struct {
+	Stage TxStage
+	// hash of the extrinsic
+	ExtrinsicHash string
+	// hash of the block the stage refers to, empty for pool stages
+	BlockHash string
+	// receipt of the transaction, only set for the inBlock and finalized stages
+	Receipt *TxReceipt
+	// error of the receipt, e.g. the dispatch error of a failed extrinsic
+	Err error
+}
+
+var (
+	ErrTxRetracted       = errors.New("transaction block retracted")
+	ErrTxFinalityTimeout = errors.New("transaction block not finalized in time")
+	ErrTxUsurped         = errors.New("transaction usurped")
+	ErrTxDropped         = errors.New("transaction dropped")
+	ErrTxInvalid         = errors.New("transaction invalid")
+)
+
+// TxStatusError is returned when a transaction ends in a stage other than
+// inBlock or finalized, it matches the corresponding ErrTx* error with errors.Is
+type TxStatusError struct {
+	Stage TxStage
+	// hash of the extrinsic
+	ExtrinsicHash string
+	// hash of the block the stage refers to, if any
+	BlockHash string
+}
+
+// Error returns the description of the error
+func (e *TxStatusError) Error() string {
+	if e.BlockHash != "" {
+		return fmt.Sprintf("extrinsic %s %s in block %s", e.ExtrinsicHash, e.Stage, e.BlockHash)
+	}
+	return fmt.Sprintf("extrinsic %s %s", e.ExtrinsicHash, e.Stage)
+}
+
+// Unwrap returns the ErrTx* error of the stage
+func (e *TxStatusError) Unwrap() error {
+	switch e.Stage {
+	case TxStageRetracted:
+		return ErrTxRetracted
+	case TxStageFinalityTimeout:
+		return ErrTxFinalityTimeout
+	case TxStageUsurped:
+		return ErrTxUsurped
+	case TxStageDropped:
+		return ErrTxDropped
+	case TxStageInvalid:
+		return ErrTxInvalid
+	}
+	return nil
+}
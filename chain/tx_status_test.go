@@ -0,0 +1,64 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxStatusErrorIs(t *testing.T) {
+	var err error = &TxStatusError{Stage: TxStageUsurped, ExtrinsicHash: "0x01"}
+	assert.True(t, errors.Is(err, ErrTxUsurped))
+	assert.False(t, errors.Is(err, ErrTxDropped))
+	assert.Equal(t, "extrinsic 0x01 usurped", err.Error())
+
+	err = &TxStatusError{Stage: TxStageFinalityTimeout, ExtrinsicHash: "0x01", BlockHash: "0x02"}
+	assert.True(t, errors.Is(err, ErrTxFinalityTimeout))
+	assert.Equal(t, "extrinsic 0x01 finalityTimeout in block 0x02", err.Error())
+
+	var statusErr *TxStatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, TxStageFinalityTimeout, statusErr.Stage)
+}
+
+func TestTxStageOf(t *testing.T) {
+	hash := types.NewHash([]byte{1})
+	stage, blockhash := txStageOf(types.ExtrinsicStatus{IsInBlock: true, AsInBlock: hash})
+	assert.Equal(t, TxStageInBlock, stage)
+	assert.Equal(t, hash, blockhash)
+
+	stage, blockhash = txStageOf(types.ExtrinsicStatus{IsRetracted: true, AsRetracted: hash})
+	assert.Equal(t, TxStageRetracted, stage)
+	assert.Equal(t, hash, blockhash)
+
+	stage, _ = txStageOf(types.ExtrinsicStatus{IsDropped: true})
+	assert.Equal(t, TxStageDropped, stage)
+
+	stage, _ = txStageOf(types.ExtrinsicStatus{IsInvalid: true})
+	assert.Equal(t, TxStageInvalid, stage)
+}
+
+func TestNewTxOptions(t *testing.T) {
+	o, err := newTxOptions(TxOptions{FinalizationTimeout: DefaultFinalizationTimeout}, nil)
+	assert.NoError(t, err)
+	assert.False(t, o.WaitFinalized)
+	assert.Equal(t, DefaultFinalizationTimeout, o.FinalizationTimeout)
+
+	o, err = newTxOptions(TxOptions{}, []TxOption{WaitForFinalization(time.Minute)})
+	assert.NoError(t, err)
+	assert.True(t, o.WaitFinalized)
+	assert.Equal(t, time.Minute, o.FinalizationTimeout)
+
+	_, err = newTxOptions(TxOptions{}, []TxOption{WaitForFinalization(-1)})
+	assert.Error(t, err)
+}
@@ -0,0 +1,121 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/pkg/errors"
+)
+
+// BatchMode decides how a batch handles a failed call
+type BatchMode uint8
+
+const (
+	// Utility.batch_all: all calls are dispatched or none of them
+	BatchAll BatchMode = iota
+	// Utility.batch: the calls are dispatched until the first failed call
+	BatchInterruptible
+	// Utility.force_batch: all calls are dispatched, failed calls are skipped
+	BatchForce
+)
+
+// BatchItemStatus is the result of a call in a batch
+type BatchItemStatus uint8
+
+const (
+	BatchItemNotExecuted BatchItemStatus = iota
+	BatchItemCompleted
+	BatchItemFailed
+)
+
+// BatchItem is the result of a call in a batch
+type BatchItem struct {
+	Status BatchItemStatus
+	// reason of the failure of the call
+	Error *DispatchError
+}
+
+// BatchReceipt is the receipt of a batch transaction
+type BatchReceipt struct {
+	TxReceipt
+	// results of the calls, in the order of the calls
+	Items []BatchItem
+}
+
+// Batch submits several calls in one transaction, the calls are built by the
+// transaction methods with the BuildCall option
+//   - calls: calls to dispatch
+//   - mode: how the batch handles a failed call
+//   - opts: transaction options
+//
+// Return:
+//   - BatchReceipt: receipt of the batch with the result of each call
+//   - error: error message
+func (c *ChainClient) Batch(calls []types.Call, mode BatchMode, opts ...TxOption) (BatchReceipt, error) {
+	var extName string
+	switch mode {
+	case BatchAll:
+		extName = ExtName_Utility_batch_all
+	case BatchInterruptible:
+		extName = ExtName_Utility_batch
+	case BatchForce:
+		extName = ExtName_Utility_force_batch
+	default:
+		return BatchReceipt{}, fmt.Errorf("invalid batch mode: %d", mode)
+	}
+	if len(calls) == 0 {
+		return BatchReceipt{}, errors.New("empty calls")
+	}
+
+	newcall, err := types.NewCall(c.GetMetadata(), extName, calls)
+	if err != nil {
+		return BatchReceipt{}, fmt.Errorf("rpc err: [%s] [tx] [%s] NewCall: %v", c.GetCurrentRpcAddr(), extName, err)
+	}
+
+	receipt, err := c.SubmitExtrinsic(newcall, extName, opts...)
+	result := BatchReceipt{TxReceipt: receipt, Items: batchItems(receipt.Events, len(calls))}
+	if err != nil {
+		return result, fmt.Errorf("rpc err: [%s] [tx] [%s] SubmitExtrinsic: %w", c.GetCurrentRpcAddr(), extName, err)
+	}
+	return result, nil
+}
+
+// batchItems returns the result of each call from the events of a batch
+func batchItems(events []*parser.Event, n int) []BatchItem {
+	items := make([]BatchItem, n)
+	k := 0
+	for _, e := range events {
+		switch e.Name {
+		case UtilityItemCompleted:
+			if k < n {
+				items[k].Status = BatchItemCompleted
+				k++
+			}
+		case UtilityItemFailed:
+			if k < n {
+				items[k] = BatchItem{Status: BatchItemFailed, Error: dispatchErrorFromEvent(e.Fields)}
+				k++
+			}
+		case UtilityBatchInterrupted:
+			index := k
+			for _, field := range e.Fields {
+				if v, ok := field.Value.(types.U32); ok && strings.Contains(field.Name, "index") {
+					index = int(v)
+				}
+			}
+			if index < n {
+				items[index] = BatchItem{Status: BatchItemFailed, Error: dispatchErrorFromEvent(e.Fields)}
+			}
+		}
+	}
+	return items
+}
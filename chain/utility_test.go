@@ -0,0 +1,46 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package chain
+
+import (
+	"testing"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchItems(t *testing.T) {
+	dispatchErr := &DispatchError{Kind: "BadOrigin"}
+
+	items := batchItems([]*parser.Event{
+		{Name: UtilityItemCompleted},
+		{Name: UtilityItemFailed, Fields: registry.DecodedFields{{Name: "error", Value: dispatchErr}}},
+		{Name: UtilityItemCompleted},
+		{Name: UtilityBatchCompletedWithErrors},
+	}, 3)
+	assert.Equal(t, []BatchItem{
+		{Status: BatchItemCompleted},
+		{Status: BatchItemFailed, Error: dispatchErr},
+		{Status: BatchItemCompleted},
+	}, items)
+
+	items = batchItems([]*parser.Event{
+		{Name: UtilityItemCompleted},
+		{Name: UtilityBatchInterrupted, Fields: registry.DecodedFields{
+			{Name: "index", Value: types.U32(1)},
+			{Name: "error", Value: dispatchErr},
+		}},
+	}, 3)
+	assert.Equal(t, []BatchItem{
+		{Status: BatchItemCompleted},
+		{Status: BatchItemFailed, Error: dispatchErr},
+		{Status: BatchItemNotExecuted},
+	}, items)
+}
@@ -7,8 +7,32 @@
 
 package process
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+)
+
 type RespType struct {
 	Code int    `json:"code"`
 	Msg  string `json:"msg"`
 	Data any    `json:"data"`
 }
+
+var errEmptySigner = errors.New("empty signer")
+
+// signMessage signs the message of a request to a gateway or miner,
+// they verify the signature as sr25519 signature of the account
+func signMessage(s signer.Signer, message string) ([]byte, error) {
+	if s == nil {
+		return nil, errEmptySigner
+	}
+	if s.Scheme() != signer.Sr25519 {
+		return nil, fmt.Errorf("unsupported signature scheme: %s", s.Scheme())
+	}
+	if len(message) <= 0 {
+		return nil, errors.New("empty msg")
+	}
+	return s.Sign([]byte(message))
+}
@@ -20,8 +20,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/signature"
 	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 	"github.com/CESSProject/cess-go-sdk/utils"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/pkg/errors"
@@ -45,7 +45,7 @@ type CansRequestParams struct {
 //
 // Receive parameter:
 //   - url: the address of the gateway.
-//   - mnemonic: the space owner's CESS account mnemonic
+//   - accSigner: signer of the space owner's CESS account
 //   - chunksDir: directory path to store file chunks, please do not mix it elsewhere.
 //   - territory: the territory(a space block) in which you would like your data to be stored
 //   - bucket: the bucket name to store user data.
@@ -57,7 +57,11 @@ type CansRequestParams struct {
 // Return parameter:
 //   - response: file's FID(if all chunks are uploaded successfully).
 //   - error: error message.
-func UploadFileChunks(url, mnemonic, chunksDir, territory, bucket, fname, cipher string, chunksNum int, totalSize int64) (string, error) {
+func UploadFileChunks(url string, accSigner signer.Signer, chunksDir, territory, bucket, fname, cipher string, chunksNum int, totalSize int64) (string, error) {
+	if accSigner == nil {
+		return "", errEmptySigner
+	}
+
 	entries, err := os.ReadDir(chunksDir)
 	if err != nil {
 		return "", errors.Wrap(err, "upload file chunk error")
@@ -71,7 +75,7 @@ func UploadFileChunks(url, mnemonic, chunksDir, territory, bucket, fname, cipher
 	var res string
 	for i := chunksNum - len(entries); i < chunksNum; i++ {
 		file := filepath.Join(chunksDir, fmt.Sprintf("chunk-%d", i))
-		res, err = UploadFileChunk(url, mnemonic, file, territory, bucket,
+		res, err = UploadFileChunk(url, accSigner, file, territory, bucket,
 			AddUploadChunkRequestHeader(fname, cipher, chunksNum, i, totalSize))
 		if err != nil {
 			return res, errors.Wrap(err, "upload file chunks error")
@@ -155,7 +159,7 @@ func AddFileRequestHeader(territory, bucket, account, message, sig, contentType
 //
 // Receive parameter:
 //   - url: the address of the gateway.
-//   - mnemonic: the space owner's CESS account mnemonic
+//   - accSigner: signer of the space owner's CESS account
 //   - file: file path to store file chunks.
 //   - territory: the territory(a space block) in which you would like your data to be stored
 //   - bucket: the bucket name to store user data.
@@ -167,7 +171,10 @@ func AddFileRequestHeader(territory, bucket, account, message, sig, contentType
 // Return parameter:
 //   - response: chunk ID or file's FID(if all chunks are uploaded successfully).
 //   - error: error message.
-func UploadFileChunk(url, mnemonic, file, territory, bucket string, addExtendHeader func(*http.Request)) (string, error) {
+func UploadFileChunk(url string, accSigner signer.Signer, file, territory, bucket string, addExtendHeader func(*http.Request)) (string, error) {
+	if accSigner == nil {
+		return "", errEmptySigner
+	}
 
 	fstat, err := os.Stat(file)
 	if err != nil {
@@ -186,21 +193,16 @@ func UploadFileChunk(url, mnemonic, file, territory, bucket string, addExtendHea
 		return "", errors.New("invalid bucket name")
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return "", fmt.Errorf("[KeyringPairFromSecret] %v", err)
-	}
-
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return "", fmt.Errorf("[EncodePublicKeyAsCessAccount] %v", err)
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
-		return "", fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return "", fmt.Errorf("[signMessage] %v", err)
 	}
 
 	body := new(bytes.Buffer)
@@ -341,7 +343,7 @@ func SplitFile(fpath, chunksDir string, chunkSize int64, filling bool) (int64, i
 //
 // Receive parameter:
 //   - url: the address of the gateway.
-//   - mnemonic: the space owner's CESS account mnemonic
+//   - accSigner: signer of the space owner's CESS account
 //   - filesDir: directory path to store file chunks, please do not mix it elsewhere.
 //   - territory: the territory(a space block) in which you would like your data to be stored
 //   - bucket: the bucket name to store user data.
@@ -352,7 +354,11 @@ func SplitFile(fpath, chunksDir string, chunkSize int64, filling bool) (int64, i
 // Return parameter:
 //   - response: file's FID(if all chunks are uploaded successfully).
 //   - error: error message.
-func UploadFilesWithCansProto(url, mnemonic, filesDir, territory, bucket, archiveFormat, cipher string, isSplit bool) (string, error) {
+func UploadFilesWithCansProto(url string, accSigner signer.Signer, filesDir, territory, bucket, archiveFormat, cipher string, isSplit bool) (string, error) {
+	if accSigner == nil {
+		return "", errEmptySigner
+	}
+
 	entries, err := os.ReadDir(filesDir)
 	if err != nil {
 		return "", errors.Wrap(err, "upload file with CANS PROTOCOL error")
@@ -387,7 +393,7 @@ func UploadFilesWithCansProto(url, mnemonic, filesDir, territory, bucket, archiv
 		}
 		fpath := filepath.Join(filesDir, entry.Name())
 		res, err = UploadFileChunk(
-			url, mnemonic, fpath, territory, bucket,
+			url, accSigner, fpath, territory, bucket,
 			AddCansProtoRequestHeader(
 				filename, cipher, fileNum, count, totalSize, isSplit, archiveFormat,
 			),
@@ -404,7 +410,7 @@ func UploadFilesWithCansProto(url, mnemonic, filesDir, territory, bucket, archiv
 //
 // Receive parameter:
 //   - url: the address of the gateway.
-//   - mnemonic: user's CESS account mnemonic.
+//   - accSigner: signer of the user's CESS account.
 //   - savepath: file path to store downloaded file.
 //   - fid: file's FID on chain metadata.
 //   - filename: name of sub file in cans, if it is an empty string, the specified segment(can) is downloaded.
@@ -414,7 +420,11 @@ func UploadFilesWithCansProto(url, mnemonic, filesDir, territory, bucket, archiv
 // Return parameter:
 //   - response: file(if successful).
 //   - error: error message.
-func DownloadCanFile(url, mnemonic, savepath, fid, filename, cipher string, sid int) error {
+func DownloadCanFile(url string, accSigner signer.Signer, savepath, fid, filename, cipher string, sid int) error {
+	if accSigner == nil {
+		return errEmptySigner
+	}
+
 	url, err := u.JoinPath(url, fid)
 	if err != nil {
 		return errors.Wrap(err, "download can file error")
@@ -434,19 +444,14 @@ func DownloadCanFile(url, mnemonic, savepath, fid, filename, cipher string, sid
 		return errors.Wrap(err, "download can file error")
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return errors.Wrap(err, "download can file error")
-	}
-
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return errors.Wrap(err, "download can file error")
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
 		return errors.Wrap(err, "download can file error")
 	}
@@ -17,7 +17,7 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/AstaFrode/go-substrate-rpc-client/v4/signature"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 	"github.com/CESSProject/cess-go-sdk/utils"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/pkg/errors"
@@ -33,7 +33,7 @@ var globalTransport = &http.Transport{
 //   - url: gateway url
 //   - file: stored file
 //   - territory: territory name
-//   - mnemonic: polkadot account mnemonic
+//   - accSigner: signer of the polkadot account
 //
 // Return parameter:
 //   - string: [fid] unique identifier for the file.
@@ -45,8 +45,12 @@ var globalTransport = &http.Transport{
 //     refer to the [AuthorizeSpace] interface.
 //
 // Explanation:
-//   - Account refers to the account where you configured mnemonic when creating an SDK.
-func StoreFile(url, file, territory, mnemonic string) (string, error) {
+//   - Account refers to the account of the signer.
+func StoreFile(url, file, territory string, accSigner signer.Signer) (string, error) {
+	if accSigner == nil {
+		return "", errEmptySigner
+	}
+
 	fstat, err := os.Stat(file)
 	if err != nil {
 
@@ -61,21 +65,16 @@ func StoreFile(url, file, territory, mnemonic string) (string, error) {
 		return "", errors.New("empty file")
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return "", fmt.Errorf("[KeyringPairFromSecret] %v", err)
-	}
-
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return "", fmt.Errorf("[EncodePublicKeyAsCessAccount] %v", err)
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
-		return "", fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return "", fmt.Errorf("[signMessage] %v", err)
 	}
 
 	body := new(bytes.Buffer)
@@ -140,7 +139,7 @@ func StoreFile(url, file, territory, mnemonic string) (string, error) {
 // Receive parameter:
 //   - url: gateway url
 //   - territory: territory name
-//   - mnemonic: polkadot account mnemonic
+//   - accSigner: signer of the polkadot account
 //   - reader: strings, byte data, file streams, network streams, etc
 //
 // Return parameter:
@@ -153,23 +152,22 @@ func StoreFile(url, file, territory, mnemonic string) (string, error) {
 //     refer to the [AuthorizeSpace] interface.
 //
 // Explanation:
-//   - Account refers to the account where you configured mnemonic when creating an SDK.
-func StoreObject(url string, territory, mnemonic string, reader io.Reader) (string, error) {
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return "", fmt.Errorf("[KeyringPairFromSecret] %v", err)
+//   - Account refers to the account of the signer.
+func StoreObject(url string, territory string, accSigner signer.Signer, reader io.Reader) (string, error) {
+	if accSigner == nil {
+		return "", errEmptySigner
 	}
 
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return "", fmt.Errorf("[EncodePublicKeyAsCessAccount] %v", err)
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
-		return "", fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return "", fmt.Errorf("[signMessage] %v", err)
 	}
 
 	req, err := http.NewRequest(http.MethodPut, url, reader)
@@ -209,13 +207,17 @@ func StoreObject(url string, territory, mnemonic string, reader io.Reader) (stri
 // RetrieveFile downloads files from the gateway
 //   - url: gateway url
 //   - fid: fid
-//   - mnemonic: polkadot account mnemonic
+//   - accSigner: signer of the polkadot account
 //   - savepath: file save path
 //
 // Return:
 //   - string: fid
 //   - error: error message
-func RetrieveFile(url, fid, mnemonic, savepath string) error {
+func RetrieveFile(url, fid string, accSigner signer.Signer, savepath string) error {
+	if accSigner == nil {
+		return errEmptySigner
+	}
+
 	fstat, err := os.Stat(savepath)
 	if err == nil {
 		if fstat.IsDir() {
@@ -245,21 +247,16 @@ func RetrieveFile(url, fid, mnemonic, savepath string) error {
 		return err
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return fmt.Errorf("[KeyringPairFromSecret] %v", err)
-	}
-
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return fmt.Errorf("[EncodePublicKeyAsCessAccount] %v", err)
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
-		return fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return fmt.Errorf("[signMessage] %v", err)
 	}
 
 	req.Header.Set("Message", message)
@@ -292,12 +289,16 @@ func RetrieveFile(url, fid, mnemonic, savepath string) error {
 // RetrieveObject gets the object from the gateway
 //   - url: gateway url
 //   - fid: fid
-//   - mnemonic: polkadot account mnemonic
+//   - accSigner: signer of the polkadot account
 //
 // Return:
 //   - io.ReadCloser: object
 //   - error: error message
-func RetrieveObject(url, fid, mnemonic string) (io.ReadCloser, error) {
+func RetrieveObject(url, fid string, accSigner signer.Signer) (io.ReadCloser, error) {
+	if accSigner == nil {
+		return nil, errEmptySigner
+	}
+
 	if url == "" {
 		return nil, errors.New("empty url")
 	}
@@ -311,21 +312,16 @@ func RetrieveObject(url, fid, mnemonic string) (io.ReadCloser, error) {
 		return nil, err
 	}
 
-	keyringPair, err := signature.KeyringPairFromSecret(mnemonic, 0)
-	if err != nil {
-		return nil, fmt.Errorf("[KeyringPairFromSecret] %v", err)
-	}
-
-	acc, err := utils.EncodePublicKeyAsCessAccount(keyringPair.PublicKey)
+	acc, err := utils.EncodePublicKeyAsCessAccount(signer.AccountID(accSigner))
 	if err != nil {
 		return nil, fmt.Errorf("[EncodePublicKeyAsCessAccount] %v", err)
 	}
 
 	// sign message
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(keyringPair.URI, message)
+	sig, err := signMessage(accSigner, message)
 	if err != nil {
-		return nil, fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return nil, fmt.Errorf("[signMessage] %v", err)
 	}
 
 	req.Header.Set("Message", message)
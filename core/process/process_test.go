@@ -38,3 +38,18 @@ func TestFullProcessing(t *testing.T) {
 		}
 	}
 }
+
+func TestNilSigner(t *testing.T) {
+	_, err := StoreObject("http://127.0.0.1:1/", "territory", nil, nil)
+	assert.ErrorIs(t, err, errEmptySigner)
+	_, err = StoreFile("http://127.0.0.1:1/", "./process.go", "territory", nil)
+	assert.ErrorIs(t, err, errEmptySigner)
+	err = RetrieveFile("http://127.0.0.1:1/", "fid", nil, t.TempDir())
+	assert.ErrorIs(t, err, errEmptySigner)
+	_, err = RetrieveObject("http://127.0.0.1:1/", "fid", nil)
+	assert.ErrorIs(t, err, errEmptySigner)
+	_, err = UploadFileChunk("http://127.0.0.1:1/", nil, "./process.go", "territory", "", nil)
+	assert.ErrorIs(t, err, errEmptySigner)
+	err = DownloadCanFile("http://127.0.0.1:1/", nil, t.TempDir(), "fid", "name", "", 0)
+	assert.ErrorIs(t, err, errEmptySigner)
+}
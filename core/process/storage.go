@@ -26,6 +26,7 @@ import (
 	"github.com/CESSProject/cess-go-sdk/chain"
 	"github.com/CESSProject/cess-go-sdk/core/crypte"
 	"github.com/CESSProject/cess-go-sdk/core/erasure"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 	"github.com/CESSProject/cess-go-sdk/utils"
 )
 
@@ -33,7 +34,7 @@ import (
 //
 // Receive parameter:
 //   - file: stored file
-//   - mnemonic: account mnemonic
+//   - accSigner: signer of the account
 //   - territory: territory name
 //   - timeout: timeout for waiting for block transaction to complete
 //   - rpcs: rpc address list
@@ -47,7 +48,7 @@ import (
 //  1. your account needs to have money, and will be automatically created if the territory you specify does not exist.
 //  2. if the number of miners you specify is less than 12, file storage will be exited if even one fails.
 //  3. if the number of miners you specify is greater than 11, no other miners will be found for storage.
-func StoreFileToMiners(file string, mnemonic string, territory string, timeout time.Duration, rpcs []string, wantMiner []string) (string, error) {
+func StoreFileToMiners(file string, accSigner signer.Signer, territory string, timeout time.Duration, rpcs []string, wantMiner []string) (string, error) {
 	size, err := CheckFile(file)
 	if err != nil {
 		return "", err
@@ -68,11 +69,11 @@ func StoreFileToMiners(file string, mnemonic string, territory string, timeout t
 		return "", err
 	}
 
-	if mnemonic == "" {
-		return fid, errors.New("empty mnemonic")
+	if accSigner == nil {
+		return fid, errors.New("empty signer")
 	}
 
-	cli, err := chain.NewChainClient(context.Background(), "", rpcs, mnemonic, timeout)
+	cli, err := chain.NewChainClient(context.Background(), "", rpcs, "", timeout, chain.WithSigner(accSigner))
 	if err != nil {
 		return fid, err
 	}
@@ -89,12 +90,12 @@ func StoreFileToMiners(file string, mnemonic string, territory string, timeout t
 	}
 	var fragmentGroup = make([][]string, 0)
 	var sucMiner = make([]string, 0)
-	fmeta, err := cli.QueryFile(fid, -1)
+	fmeta, err := cli.QueryFile(fid, chain.LatestBlock())
 	if err != nil {
 		if !errors.Is(err, chain.ERR_RPC_EMPTY_VALUE) {
 			return fid, err
 		}
-		dealmap, err := cli.QueryDealMap(fid, -1)
+		dealmap, err := cli.QueryDealMap(fid, chain.LatestBlock())
 		if err != nil {
 			if !errors.Is(err, chain.ERR_RPC_EMPTY_VALUE) {
 				return fid, err
@@ -172,14 +173,14 @@ func StoreFileToMiners(file string, mnemonic string, territory string, timeout t
 //
 // Preconditions:
 //  1. the file to be downloaded needs to have been stored in the miner
-func RetrieveFileFromMiners(rpcs []string, mnemonic, fid, cipher, savedir string) error {
-	cli, err := chain.NewChainClient(context.Background(), "", rpcs, mnemonic, 0)
+func RetrieveFileFromMiners(rpcs []string, accSigner signer.Signer, fid, cipher, savedir string) error {
+	cli, err := chain.NewChainClient(context.Background(), "", rpcs, "", 0, chain.WithSigner(accSigner))
 	if err != nil {
 		return err
 	}
 	defer cli.Close()
 
-	metaInfo, err := cli.QueryFile(fid, -1)
+	metaInfo, err := cli.QueryFile(fid, chain.LatestBlock())
 	if err != nil {
 		if errors.Is(err, chain.ERR_RPC_EMPTY_VALUE) {
 			return errors.New("not found")
@@ -358,7 +359,7 @@ func DownloadSegment(cli chain.Chainer, savedir string, fid, segmentHash string,
 }
 
 func DownloadFragmentFromMiner(cli chain.Chainer, minerpuk []byte, fid, fragment string, start, end uint64) ([]byte, error) {
-	minerInfo, err := cli.QueryMinerItems(minerpuk, -1)
+	minerInfo, err := cli.QueryMinerItems(minerpuk, chain.LatestBlock())
 	if err != nil {
 		return nil, err
 	}
@@ -379,9 +380,9 @@ func DownloadFragmentFromMiner(cli chain.Chainer, minerpuk []byte, fid, fragment
 	}
 
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(cli.GetURI(), message)
+	sig, err := signMessage(cli.GetSigner(), message)
 	if err != nil {
-		return nil, fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return nil, fmt.Errorf("[signMessage] %v", err)
 	}
 
 	if start < end && end < chain.FragmentSize && end > 0 {
@@ -438,7 +439,7 @@ func StorageToMiners(cli chain.Chainer, fragmentGroup [][]string, fid string, co
 		}
 	}
 
-	allminers, err := cli.QueryAllMiner(-1)
+	allminers, err := cli.QueryAllMiner(chain.LatestBlock())
 	if err != nil {
 		return err
 	}
@@ -504,7 +505,7 @@ func StoreBatchFragmentsToMiner(cli chain.Chainer, fragments []string, fid, acco
 		fmt.Println("ParsingPublickey: ", err)
 		return err
 	}
-	minerInfo, err := cli.QueryMinerItems(puk, -1)
+	minerInfo, err := cli.QueryMinerItems(puk, chain.LatestBlock())
 	if err != nil {
 		fmt.Println("QueryMinerItems: ", err)
 		return err
@@ -533,9 +534,9 @@ func StoreBatchFragmentsToMiner(cli chain.Chainer, fragments []string, fid, acco
 
 func UploadFragmentToMiner(cli chain.Chainer, addr string, fid string, file string) error {
 	message := utils.GetRandomcode(16)
-	sig, err := utils.SignedSR25519WithMnemonic(cli.GetURI(), message)
+	sig, err := signMessage(cli.GetSigner(), message)
 	if err != nil {
-		return fmt.Errorf("[SignedSR25519WithMnemonic] %v", err)
+		return fmt.Errorf("[signMessage] %v", err)
 	}
 
 	body := new(bytes.Buffer)
@@ -612,7 +613,7 @@ func UploadFragmentToMiner(cli chain.Chainer, addr string, fid string, file stri
 
 func CheckAccount(cli chain.Chainer, territory string, size int64) error {
 	useSpace := CalcUsedSpace(size)
-	territoryInfo, err := cli.QueryTerritory(cli.GetSignatureAccPulickey(), territory, -1)
+	territoryInfo, err := cli.QueryTerritory(cli.GetSignatureAccPulickey(), territory, chain.LatestBlock())
 	if err != nil {
 		if !errors.Is(err, chain.ERR_RPC_EMPTY_VALUE) {
 			return err
@@ -626,7 +627,7 @@ func CheckAccount(cli chain.Chainer, territory string, size int64) error {
 			return err
 		}
 		time.Sleep(chain.BlockInterval)
-		territoryInfo, err = cli.QueryTerritory(cli.GetSignatureAccPulickey(), territory, -1)
+		territoryInfo, err = cli.QueryTerritory(cli.GetSignatureAccPulickey(), territory, chain.LatestBlock())
 		if err != nil {
 			return err
 		}
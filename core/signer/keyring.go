@@ -0,0 +1,54 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	subkey "github.com/vedhavyas/go-subkey/v2"
+)
+
+// KeyringSigner signs with a key held in memory
+type KeyringSigner struct {
+	scheme Scheme
+	pair   subkey.KeyPair
+}
+
+var _ Signer = (*KeyringSigner)(nil)
+
+// NewKeyringSigner creates an in-memory signer
+//   - scheme: signature scheme of the key
+//   - suri: mnemonic or secret seed, optionally followed by a derivation path, e.g. "<mnemonic>//miner/1"
+//
+// Return:
+//   - *KeyringSigner: in-memory signer
+//   - error: error message
+func NewKeyringSigner(scheme Scheme, suri string) (*KeyringSigner, error) {
+	sch, err := scheme.subkeyScheme()
+	if err != nil {
+		return nil, err
+	}
+	pair, err := subkey.DeriveKeyPair(sch, suri)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyringSigner{scheme: scheme, pair: pair}, nil
+}
+
+// PublicKey returns the public key of the account
+func (k *KeyringSigner) PublicKey() []byte {
+	return k.pair.Public()
+}
+
+// Scheme returns the signature scheme of the key
+func (k *KeyringSigner) Scheme() Scheme {
+	return k.scheme
+}
+
+// Sign signs the payload with the key
+func (k *KeyringSigner) Sign(payload []byte) ([]byte, error) {
+	return k.pair.Sign(payload)
+}
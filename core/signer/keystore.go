@@ -0,0 +1,99 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	ErrAccountExists   = errors.New("account already exists")
+	ErrAccountNotFound = errors.New("account not found")
+)
+
+// Keystore holds several named accounts, it is safe for concurrent use
+type Keystore struct {
+	lock     sync.RWMutex
+	accounts map[string]Signer
+}
+
+// NewKeystore creates an empty keystore
+func NewKeystore() *Keystore {
+	return &Keystore{accounts: make(map[string]Signer)}
+}
+
+// Add adds the signer of an account under the given name
+func (k *Keystore) Add(name string, s Signer) error {
+	if name == "" {
+		return errors.New("empty account name")
+	}
+	if s == nil {
+		return errors.New("nil signer")
+	}
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if _, ok := k.accounts[name]; ok {
+		return fmt.Errorf("%w: %s", ErrAccountExists, name)
+	}
+	k.accounts[name] = s
+	return nil
+}
+
+// Derive adds the account derived from seed under the given name
+//   - name: account name
+//   - scheme: signature scheme of the key
+//   - seed: mnemonic or secret seed
+//   - path: derivation path, "//" starts a hard and "/" a soft junction,
+//     e.g. "//miner/1", ed25519 keys only support hard junctions
+//
+// Return:
+//   - Signer: signer of the derived account
+//   - error: error message
+func (k *Keystore) Derive(name string, scheme Scheme, seed, path string) (Signer, error) {
+	s, err := NewKeyringSigner(scheme, seed+path)
+	if err != nil {
+		return nil, err
+	}
+	if err = k.Add(name, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the signer of the named account
+func (k *Keystore) Get(name string) (Signer, error) {
+	k.lock.RLock()
+	defer k.lock.RUnlock()
+	s, ok := k.accounts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrAccountNotFound, name)
+	}
+	return s, nil
+}
+
+// Remove removes the named account
+func (k *Keystore) Remove(name string) {
+	k.lock.Lock()
+	delete(k.accounts, name)
+	k.lock.Unlock()
+}
+
+// Names returns the names of all accounts in ascending order
+func (k *Keystore) Names() []string {
+	k.lock.RLock()
+	names := make([]string, 0, len(k.accounts))
+	for name := range k.accounts {
+		names = append(names, name)
+	}
+	k.lock.RUnlock()
+	sort.Strings(names)
+	return names
+}
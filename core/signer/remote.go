@@ -0,0 +1,191 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+)
+
+// Paths of the remote signer http api, relative to its endpoint:
+//
+//	GET  /account  -> {"public_key": "0x..", "scheme": "sr25519"}
+//	POST /sign     {"public_key": "0x..", "payload": "0x.."} -> {"signature": "0x.."}
+const (
+	RemotePathAccount = "/account"
+	RemotePathSign    = "/sign"
+)
+
+// DefaultRemoteTimeout is the default timeout of a request to the remote signer
+const DefaultRemoteTimeout = time.Second * 30
+
+// maximum size of a response of the remote signer
+const maxRemoteResponse = 1 << 20
+
+type accountResponse struct {
+	PublicKey hexutil.Bytes `json:"public_key"`
+	Scheme    string        `json:"scheme"`
+}
+
+type signRequest struct {
+	PublicKey hexutil.Bytes `json:"public_key"`
+	Payload   hexutil.Bytes `json:"payload"`
+}
+
+type signResponse struct {
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// RemoteSigner signs through a remote signing service over http,
+// e.g. a proxy in front of an HSM or KMS
+type RemoteSigner struct {
+	endpoint  string
+	client    *http.Client
+	publicKey []byte
+	scheme    Scheme
+}
+
+var _ ContextSigner = (*RemoteSigner)(nil)
+
+// NewRemoteSigner creates a signer that delegates signing to the remote signer
+//   - ctx: context of the account query
+//   - endpoint: base url of the remote signer
+//   - client: http client used for all requests, e.g. with tls client certificates,
+//     nil uses a client with DefaultRemoteTimeout
+//
+// Return:
+//   - *RemoteSigner: remote signer
+//   - error: error message
+func NewRemoteSigner(ctx context.Context, endpoint string, client *http.Client) (*RemoteSigner, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultRemoteTimeout}
+	}
+	r := &RemoteSigner{
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		client:   client,
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint+RemotePathAccount, nil)
+	if err != nil {
+		return nil, err
+	}
+	var acc accountResponse
+	if err = r.do(req, &acc); err != nil {
+		return nil, errors.Wrap(err, "[account]")
+	}
+	if len(acc.PublicKey) == 0 {
+		return nil, errors.New("remote signer returned an empty public key")
+	}
+	r.scheme, err = ParseScheme(acc.Scheme)
+	if err != nil {
+		return nil, err
+	}
+	r.publicKey = acc.PublicKey
+	return r, nil
+}
+
+// PublicKey returns the public key of the account
+func (r *RemoteSigner) PublicKey() []byte {
+	return r.publicKey
+}
+
+// Scheme returns the signature scheme of the key
+func (r *RemoteSigner) Scheme() Scheme {
+	return r.scheme
+}
+
+// Sign sends the payload to the remote signer and returns its signature
+func (r *RemoteSigner) Sign(payload []byte) ([]byte, error) {
+	return r.SignContext(context.Background(), payload)
+}
+
+// SignContext sends the payload to the remote signer like Sign, the request is canceled when ctx is done
+func (r *RemoteSigner) SignContext(ctx context.Context, payload []byte) ([]byte, error) {
+	body, err := json.Marshal(signRequest{PublicKey: r.publicKey, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint+RemotePathSign, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var res signResponse
+	if err = r.do(req, &res); err != nil {
+		return nil, errors.Wrap(err, "[sign]")
+	}
+	if len(res.Signature) == 0 {
+		return nil, errors.New("remote signer returned an empty signature")
+	}
+	return res.Signature, nil
+}
+
+func (r *RemoteSigner) do(req *http.Request, v any) error {
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteResponse))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	return json.Unmarshal(data, v)
+}
+
+// NewHandler serves the remote signer http api for s,
+// it can run next to an HSM or KMS or stand in for a remote signer in tests
+func NewHandler(s Signer) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(RemotePathAccount, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, accountResponse{PublicKey: s.PublicKey(), Scheme: s.Scheme().String()})
+	})
+	mux.HandleFunc(RemotePathSign, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var sr signRequest
+		if err := json.NewDecoder(io.LimitReader(req.Body, maxRemoteResponse)).Decode(&sr); err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		if !bytes.Equal(sr.PublicKey, s.PublicKey()) {
+			http.Error(w, "unknown public key", http.StatusNotFound)
+			return
+		}
+		sig, err := s.Sign(sr.Payload)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		writeJSON(w, signResponse{Signature: sig})
+	})
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,136 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	subkey "github.com/vedhavyas/go-subkey/v2"
+	"github.com/vedhavyas/go-subkey/v2/ecdsa"
+	"github.com/vedhavyas/go-subkey/v2/ed25519"
+	"github.com/vedhavyas/go-subkey/v2/sr25519"
+	"golang.org/x/crypto/blake2b"
+)
+
+// Scheme is the signature scheme of a key
+type Scheme uint8
+
+const (
+	Sr25519 Scheme = iota
+	Ed25519
+	Ecdsa
+)
+
+var ErrUnknownScheme = errors.New("unknown signature scheme")
+
+// String returns the name of the scheme
+func (s Scheme) String() string {
+	switch s {
+	case Sr25519:
+		return "sr25519"
+	case Ed25519:
+		return "ed25519"
+	case Ecdsa:
+		return "ecdsa"
+	}
+	return "unknown"
+}
+
+// ParseScheme returns the scheme with the given name
+func ParseScheme(name string) (Scheme, error) {
+	switch name {
+	case "sr25519":
+		return Sr25519, nil
+	case "ed25519":
+		return Ed25519, nil
+	case "ecdsa":
+		return Ecdsa, nil
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownScheme, name)
+}
+
+func (s Scheme) subkeyScheme() (subkey.Scheme, error) {
+	switch s {
+	case Sr25519:
+		return sr25519.Scheme{}, nil
+	case Ed25519:
+		return ed25519.Scheme{}, nil
+	case Ecdsa:
+		return ecdsa.Scheme{}, nil
+	}
+	return nil, ErrUnknownScheme
+}
+
+// Signer signs payloads on behalf of one account, the private key
+// does not need to be held by the application
+type Signer interface {
+	// PublicKey returns the public key of the account
+	PublicKey() []byte
+	// Scheme returns the signature scheme of the key
+	Scheme() Scheme
+	// Sign signs the payload, ecdsa signers sign the blake2b-256 hash of the payload
+	Sign(payload []byte) ([]byte, error)
+}
+
+// ContextSigner is implemented by the signers whose signature can be canceled, e.g. RemoteSigner
+type ContextSigner interface {
+	Signer
+	// SignContext signs the payload like Sign, it stops when ctx is done
+	SignContext(ctx context.Context, payload []byte) ([]byte, error)
+}
+
+// SignContext signs the payload with s, with ctx if s is a ContextSigner
+//   - ctx: context of the signature
+//   - s: signer
+//   - payload: payload to sign
+//
+// Return:
+//   - []byte: signature
+//   - error: error message
+func SignContext(ctx context.Context, s Signer, payload []byte) ([]byte, error) {
+	if cs, ok := s.(ContextSigner); ok {
+		return cs.SignContext(ctx, payload)
+	}
+	return s.Sign(payload)
+}
+
+// AccountID returns the account id of the signer on chain,
+// it is the blake2b-256 hash of the public key for ecdsa keys
+func AccountID(s Signer) []byte {
+	if s == nil {
+		return nil
+	}
+	if s.Scheme() == Ecdsa {
+		h := blake2b.Sum256(s.PublicKey())
+		return h[:]
+	}
+	return s.PublicKey()
+}
+
+// Verify verifies the signature of msg with the public key
+//   - scheme: signature scheme of the key
+//   - publicKey: public key
+//   - msg: signed message
+//   - sig: signature
+//
+// Return:
+//   - bool: verification result
+//   - error: error message
+func Verify(scheme Scheme, publicKey, msg, sig []byte) (bool, error) {
+	sch, err := scheme.subkeyScheme()
+	if err != nil {
+		return false, err
+	}
+	pub, err := sch.FromPublicKey(publicKey)
+	if err != nil {
+		return false, err
+	}
+	return pub.Verify(msg, sig), nil
+}
@@ -0,0 +1,99 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package signer
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Substrate well-known mnemonic
+const testMnemonic = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+func TestKeyringSigner(t *testing.T) {
+	for _, scheme := range []Scheme{Sr25519, Ed25519, Ecdsa} {
+		s, err := NewKeyringSigner(scheme, testMnemonic+"//Alice")
+		require.NoError(t, err)
+		assert.Equal(t, scheme, s.Scheme())
+
+		sig, err := s.Sign([]byte("message"))
+		require.NoError(t, err)
+		ok, err := Verify(scheme, s.PublicKey(), []byte("message"), sig)
+		require.NoError(t, err)
+		assert.True(t, ok, scheme.String())
+		assert.Len(t, AccountID(s), 32)
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	local, err := NewKeyringSigner(Sr25519, testMnemonic)
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(local))
+	defer srv.Close()
+
+	remote, err := NewRemoteSigner(context.Background(), srv.URL, srv.Client())
+	require.NoError(t, err)
+	assert.Equal(t, local.PublicKey(), remote.PublicKey())
+	assert.Equal(t, Sr25519, remote.Scheme())
+
+	sig, err := remote.Sign([]byte("message"))
+	require.NoError(t, err)
+	ok, err := Verify(Sr25519, local.PublicKey(), []byte("message"), sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// the request is canceled with the context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = SignContext(ctx, remote, []byte("message"))
+	assert.ErrorIs(t, err, context.Canceled)
+	// the signers without a context sign as usual
+	sig, err = SignContext(ctx, local, []byte("message"))
+	require.NoError(t, err)
+	ok, err = Verify(Sr25519, local.PublicKey(), []byte("message"), sig)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	other, err := NewKeyringSigner(Sr25519, testMnemonic+"//other")
+	require.NoError(t, err)
+	remote.publicKey = other.PublicKey()
+	_, err = remote.Sign([]byte("message"))
+	assert.Error(t, err)
+}
+
+func TestKeystore(t *testing.T) {
+	ks := NewKeystore()
+	miner, err := ks.Derive("miner1", Sr25519, testMnemonic, "//miner/1")
+	require.NoError(t, err)
+	soft, err := ks.Derive("miner1soft", Sr25519, testMnemonic, "//miner/1/soft")
+	require.NoError(t, err)
+	assert.NotEqual(t, miner.PublicKey(), soft.PublicKey())
+	_, err = ks.Derive("gateway", Ed25519, testMnemonic, "//gateway//eu")
+	require.NoError(t, err)
+	_, err = ks.Derive("ecdsa", Ecdsa, testMnemonic, "//gateway")
+	require.NoError(t, err)
+
+	_, err = ks.Derive("miner1", Sr25519, testMnemonic, "//miner/2")
+	assert.ErrorIs(t, err, ErrAccountExists)
+	// ed25519 keys cannot be derived softly
+	_, err = ks.Derive("gatewaysoft", Ed25519, testMnemonic, "//gateway/eu")
+	assert.Error(t, err)
+
+	s, err := ks.Get("miner1")
+	require.NoError(t, err)
+	assert.Equal(t, miner.PublicKey(), s.PublicKey())
+	assert.Equal(t, []string{"ecdsa", "gateway", "miner1", "miner1soft"}, ks.Names())
+
+	ks.Remove("miner1")
+	_, err = ks.Get("miner1")
+	assert.ErrorIs(t, err, ErrAccountNotFound)
+}
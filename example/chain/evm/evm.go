@@ -61,12 +61,12 @@ func main() {
 
 	maxFeePerGas = types.NewU256(*big.NewInt(500000000))
 
-	block_hash, err := sdk.SendEvmCall(source, target, input, value, gasLimit, maxFeePerGas, accessList)
+	receipt, err := sdk.SendEvmCall(source, target, input, value, gasLimit, maxFeePerGas, accessList)
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	fmt.Printf("%s", block_hash)
+	fmt.Printf("%s", receipt.BlockHash)
 }
 
 func NewSDK() (chain.Chainer, error) {
@@ -0,0 +1,54 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	cess "github.com/CESSProject/cess-go-sdk"
+	"github.com/CESSProject/cess-go-sdk/chain"
+)
+
+var RPC_ADDRS = []string{
+	//testnet
+	"wss://testnet-rpc.cess.network/ws/",
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sdk, err := cess.New(ctx, cess.ConnectRpcAddrs(RPC_ADDRS))
+	if err != nil {
+		panic(err)
+	}
+	defer sdk.Close()
+	err = sdk.InitExtrinsicsName()
+	if err != nil {
+		panic(err)
+	}
+
+	// follow the finalized blocks until interrupted
+	blocks, err := sdk.SubscribeFinalizedHeads(ctx, chain.WithBlockData())
+	if err != nil {
+		panic(err)
+	}
+	for block := range blocks {
+		if block.Err != nil {
+			fmt.Println(block.Number, " ERR: ", block.Err)
+			continue
+		}
+		fmt.Println(block.Number, " hash: ", block.Hash.Hex(), " extrinsics: ", len(block.Data.Extrinsics))
+		for _, v := range block.Data.UploadDecInfo {
+			fmt.Println("    upload declaration: ", v.Owner, v.Fid)
+		}
+	}
+}
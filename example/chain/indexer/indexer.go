@@ -0,0 +1,64 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+
+	cess "github.com/CESSProject/cess-go-sdk"
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/CESSProject/cess-go-sdk/indexer"
+)
+
+var RPC_ADDRS = []string{
+	//testnet
+	"wss://testnet-rpc.cess.network/ws/",
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	sdk, err := cess.New(ctx, cess.ConnectRpcAddrs(RPC_ADDRS))
+	if err != nil {
+		panic(err)
+	}
+	defer sdk.Close()
+	err = sdk.InitExtrinsicsName()
+	if err != nil {
+		panic(err)
+	}
+
+	// write the blocks to ndjson files and print the upload declarations
+	files, err := indexer.NewNDJSONSink("blocks", "cess", 0)
+	if err != nil {
+		panic(err)
+	}
+	defer files.Close()
+	sink := indexer.CallbackSink{
+		OnApply: func(ctx context.Context, block chain.BlockData) error {
+			for _, v := range block.UploadDecInfo {
+				fmt.Println(block.BlockId, " upload declaration: ", v.Owner, v.Fid)
+			}
+			return files.Apply(ctx, block)
+		},
+		OnRevert: func(ctx context.Context, block indexer.BlockRef) error {
+			fmt.Println(block.Number, " reverted: ", block.Hash)
+			return files.Revert(ctx, block)
+		},
+	}
+
+	// resumes from the checkpoint when restarted
+	x := indexer.New(sdk, sink, indexer.StartAt(1), indexer.WithCheckpointStore(indexer.NewFileCheckpointStore("checkpoint.json")))
+	if err = x.Run(ctx); err != nil && ctx.Err() == nil {
+		panic(err)
+	}
+}
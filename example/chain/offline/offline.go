@@ -0,0 +1,70 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	cess "github.com/CESSProject/cess-go-sdk"
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
+	"github.com/CESSProject/cess-go-sdk/utils"
+)
+
+// Substrate well-known mnemonic:
+//
+//   - cXgaee2N8E77JJv9gdsGAckv1Qsf3hqWYf7NL4q6ZuQzuAUtB
+//   - https://github.com/substrate-developer-hub/substrate-developer-hub.github.io/issues/613
+var MY_MNEMONIC = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+var MY_ACCOUNT = "cXgaee2N8E77JJv9gdsGAckv1Qsf3hqWYf7NL4q6ZuQzuAUtB"
+
+var RPC_ADDRS = []string{
+	//testnet
+	"wss://testnet-rpc.cess.network/ws/",
+}
+
+func main() {
+	// online machine: no mnemonic, build the transfer for the account
+	sdk, err := cess.New(context.Background(), cess.ConnectRpcAddrs(RPC_ADDRS))
+	if err != nil {
+		panic(err)
+	}
+	defer sdk.Close()
+
+	accountID, err := utils.ParsingPublickey(MY_ACCOUNT)
+	if err != nil {
+		panic(err)
+	}
+	var unsigned chain.UnsignedTx
+	_, err = sdk.TransferToken(MY_ACCOUNT, "1000000000000000000", chain.ExportUnsigned(accountID, &unsigned))
+	if err != nil {
+		panic(err)
+	}
+	data, _ := json.MarshalIndent(unsigned, "", "  ")
+	fmt.Println(string(data))
+
+	// offline machine: sign the transaction
+	accSigner, err := signer.NewKeyringSigner(signer.Sr25519, MY_MNEMONIC)
+	if err != nil {
+		panic(err)
+	}
+	signed, err := chain.SignUnsignedTx(unsigned, accSigner)
+	if err != nil {
+		panic(err)
+	}
+
+	// online machine: broadcast the signed transaction
+	receipt, err := sdk.SubmitSignedTx(signed, chain.WaitForFinalization(0))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(receipt.BlockHash)
+}
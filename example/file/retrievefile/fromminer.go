@@ -11,6 +11,7 @@ import (
 	"fmt"
 
 	"github.com/CESSProject/cess-go-sdk/core/process"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 )
 
 // Substrate well-known mnemonic:
@@ -30,6 +31,10 @@ const (
 )
 
 func main() {
-	err := process.RetrieveFileFromMiners(RPC_ADDRS, MY_MNEMONIC, FID, CIPHER, DIR)
+	accSigner, err := signer.NewKeyringSigner(signer.Sr25519, MY_MNEMONIC)
+	if err != nil {
+		panic(err)
+	}
+	err = process.RetrieveFileFromMiners(RPC_ADDRS, accSigner, FID, CIPHER, DIR)
 	fmt.Println("err: ", err)
 }
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/CESSProject/cess-go-sdk/core/process"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 )
 
 // Substrate well-known mnemonic:
@@ -31,9 +32,13 @@ const TerritoryName = "territory_name"
 var WantMiner = []string{}
 
 func main() {
+	accSigner, err := signer.NewKeyringSigner(signer.Sr25519, MY_MNEMONIC)
+	if err != nil {
+		panic(err)
+	}
 	fid, err := process.StoreFileToMiners(
 		UploadFile,
-		MY_MNEMONIC,
+		accSigner,
 		TerritoryName,
 		time.Second*15,
 		RPC_ADDRS,
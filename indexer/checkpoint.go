@@ -0,0 +1,127 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// BlockRef is a block delivered by the indexer
+type BlockRef struct {
+	Number uint64 `json:"number"`
+	Hash   string `json:"hash"`
+}
+
+// Checkpoint is the position of the indexer
+type Checkpoint struct {
+	// last blocks delivered, oldest first, the indexer resumes after the last one.
+	// The blocks before it are kept to find the common block of a reorg after a restart.
+	Blocks []BlockRef `json:"blocks"`
+}
+
+// Last returns the last block delivered, false if there is none
+func (c Checkpoint) Last() (BlockRef, bool) {
+	if len(c.Blocks) == 0 {
+		return BlockRef{}, false
+	}
+	return c.Blocks[len(c.Blocks)-1], true
+}
+
+// CheckpointStore persists the checkpoint of the indexer
+type CheckpointStore interface {
+	// Load returns the saved checkpoint, nil if there is none
+	Load(ctx context.Context) (*Checkpoint, error)
+	// Save replaces the saved checkpoint
+	Save(ctx context.Context, cp Checkpoint) error
+}
+
+// MemoryCheckpointStore keeps the checkpoint in memory, the indexer starts over after a restart
+type MemoryCheckpointStore struct {
+	lock sync.Mutex
+	cp   *Checkpoint
+}
+
+// NewMemoryCheckpointStore creates an empty checkpoint store in memory
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{}
+}
+
+func (s *MemoryCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.cp == nil {
+		return nil, nil
+	}
+	cp := Checkpoint{Blocks: append([]BlockRef(nil), s.cp.Blocks...)}
+	return &cp, nil
+}
+
+func (s *MemoryCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.cp = &Checkpoint{Blocks: append([]BlockRef(nil), cp.Blocks...)}
+	return nil
+}
+
+// FileCheckpointStore keeps the checkpoint in a json file
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore creates a checkpoint store in the file, it is created by the first save
+//   - path: path of the json file
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+func (s *FileCheckpointStore) Load(ctx context.Context) (*Checkpoint, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "[ReadFile]")
+	}
+	var cp Checkpoint
+	if err = json.Unmarshal(data, &cp); err != nil {
+		return nil, errors.Wrapf(err, "[Unmarshal] checkpoint %s", s.path)
+	}
+	return &cp, nil
+}
+
+// Save writes the checkpoint to a temporary file renamed over the file,
+// so that a crash never leaves a partial checkpoint
+func (s *FileCheckpointStore) Save(ctx context.Context, cp Checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return errors.Wrap(err, "[Marshal]")
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return errors.Wrap(err, "[CreateTemp]")
+	}
+	defer os.Remove(tmp.Name())
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "[Write]")
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrap(err, "[Sync]")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "[Close]")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), s.path), "[Rename]")
+}
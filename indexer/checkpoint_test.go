@@ -0,0 +1,44 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileCheckpointStore(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store := NewFileCheckpointStore(filepath.Join(dir, "checkpoint.json"))
+
+	cp, err := store.Load(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, cp)
+
+	want := Checkpoint{Blocks: []BlockRef{{Number: 1, Hash: "0x01"}, {Number: 2, Hash: "0x02"}}}
+	require.NoError(t, store.Save(ctx, want))
+	require.NoError(t, store.Save(ctx, want))
+	cp, err = store.Load(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, &want, cp)
+
+	// the temporary files are removed
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "checkpoint.json"), []byte("{"), 0644))
+	_, err = store.Load(ctx)
+	assert.Error(t, err)
+}
@@ -0,0 +1,386 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package indexer follows the blocks of the chain from a start block and delivers
+// them parsed to a sink, resuming from a checkpoint after a restart.
+//
+// The blocks far behind the head are parsed in parallel and delivered in order,
+// the blocks near the head are followed one by one as they are produced. When the
+// parent of a block is not the last block delivered, the blocks orphaned by the
+// reorg are reverted before the blocks of the new chain are delivered.
+//
+// Blocks are delivered at least once: the blocks applied to the sink since the
+// last checkpoint are applied again after a crash. During the backfill the
+// checkpoint is saved every Options.CheckpointInterval blocks, near the head it
+// is saved after each block. A sink implementing Flusher is flushed before each
+// checkpoint, so the checkpoint never gets ahead of the blocks stored by the sink.
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/pkg/errors"
+)
+
+// Source is the chain the blocks are read from, it is implemented by chain.Chainer
+type Source interface {
+	QueryBlockNumber(blockhash string) (uint32, error)
+	ChainGetBlockHash(block uint32) (types.Hash, error)
+	ChainGetFinalizedHead() (types.Hash, error)
+	ParseBlockData(blocknumber uint64, opts ...chain.ParseOption) (chain.BlockData, error)
+	SubscribeNewHeads(ctx context.Context, opts ...chain.HeadOption) (<-chan chain.BlockHead, error)
+	SubscribeFinalizedHeads(ctx context.Context, opts ...chain.HeadOption) (<-chan chain.BlockHead, error)
+}
+
+// ErrReorgTooDeep is returned when none of the blocks kept by the indexer is on the chain anymore
+var ErrReorgTooDeep = errors.New("reorg deeper than the blocks kept by the indexer")
+
+// fatalError stops the indexer, the other errors are retried
+type fatalError struct {
+	err error
+}
+
+func (e *fatalError) Error() string { return e.err.Error() }
+
+func (e *fatalError) Unwrap() error { return e.err }
+
+// Indexer delivers the blocks of the chain to a sink
+type Indexer struct {
+	src  Source
+	sink Sink
+	opts Options
+	// last blocks delivered, oldest first
+	blocks []BlockRef
+	// number of the next block to deliver
+	next uint64
+	// number of blocks applied since the last checkpoint
+	unsaved int
+}
+
+// New creates an indexer of the chain
+//   - src: the chain, e.g. a chain.Chainer
+//   - sink: receiver of the blocks
+//   - opts: options of the indexer
+//
+// Return:
+//   - *Indexer: the indexer, start it with Run
+func New(src Source, sink Sink, opts ...Option) *Indexer {
+	o := Options{
+		Workers:            DefaultWorkers,
+		LiveDistance:       DefaultLiveDistance,
+		MaxReorgDepth:      DefaultMaxReorgDepth,
+		PollInterval:       DefaultPollInterval,
+		CheckpointInterval: DefaultCheckpointInterval,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.Checkpoints == nil {
+		o.Checkpoints = NewMemoryCheckpointStore()
+	}
+	if o.Workers < 1 {
+		o.Workers = 1
+	}
+	if o.LiveDistance < 1 {
+		o.LiveDistance = 1
+	}
+	if o.MaxReorgDepth < 1 {
+		o.MaxReorgDepth = 1
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = DefaultPollInterval
+	}
+	if o.CheckpointInterval < 1 {
+		o.CheckpointInterval = 1
+	}
+	return &Indexer{src: src, sink: sink, opts: o}
+}
+
+// Run indexes the chain from the checkpoint, or from the start block if there is none,
+// until ctx is done. The errors of the source are logged and retried.
+//   - ctx: context of the indexer
+//
+// Return:
+//   - error: ctx.Err() when ctx is done, or the error of the sink, of the checkpoint store
+//     or ErrReorgTooDeep
+func (x *Indexer) Run(ctx context.Context) error {
+	cp, err := x.opts.Checkpoints.Load(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[Load] checkpoint")
+	}
+	x.blocks, x.next, x.unsaved = nil, x.opts.Start, 0
+	if cp != nil {
+		if last, ok := cp.Last(); ok {
+			x.blocks, x.next = append(x.blocks, cp.Blocks...), last.Number+1
+		}
+	}
+
+	var (
+		heads       <-chan chain.BlockHead
+		cancelHeads = func() {}
+	)
+	defer func() { cancelHeads() }()
+	for {
+		head, err := x.head()
+		if err == nil {
+			if head >= x.next+x.opts.LiveDistance {
+				cancelHeads()
+				heads = nil
+				if err = x.backfill(ctx, head-x.opts.LiveDistance); err == nil {
+					continue
+				}
+			} else {
+				if heads == nil {
+					heads, cancelHeads = x.subscribe(ctx)
+				}
+				if err = x.follow(ctx, head); err == nil && x.next <= head {
+					// a reorg was reverted
+					continue
+				}
+			}
+		}
+		if err != nil {
+			var fatal *fatalError
+			if errors.As(err, &fatal) {
+				return fatal.err
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			log.Println("[indexer]", err)
+		}
+
+		timer := time.NewTimer(x.opts.PollInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case _, ok := <-heads:
+			if !ok {
+				heads = nil
+			}
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// head returns the number of the best or the finalized block
+func (x *Indexer) head() (uint64, error) {
+	hash := ""
+	if x.opts.Finalized {
+		h, err := x.src.ChainGetFinalizedHead()
+		if err != nil {
+			return 0, err
+		}
+		hash = h.Hex()
+	}
+	number, err := x.src.QueryBlockNumber(hash)
+	return uint64(number), err
+}
+
+// subscribe notifies the new heads, the head is polled if the subscription fails
+func (x *Indexer) subscribe(ctx context.Context) (<-chan chain.BlockHead, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	subscribe := x.src.SubscribeNewHeads
+	if x.opts.Finalized {
+		subscribe = x.src.SubscribeFinalizedHeads
+	}
+	heads, err := subscribe(ctx)
+	if err != nil {
+		log.Println("[indexer] subscribe heads:", err)
+		return nil, cancel
+	}
+	return heads, cancel
+}
+
+// backfill parses the blocks up to the number in parallel and delivers them in order,
+// the checkpoint is saved every CheckpointInterval blocks and when the backfill stops
+func (x *Indexer) backfill(ctx context.Context, to uint64) (err error) {
+	type result struct {
+		data chain.BlockData
+		err  error
+	}
+	type job struct {
+		number uint64
+		result chan<- result
+	}
+
+	defer func() {
+		if x.unsaved == 0 {
+			return
+		}
+		// the blocks applied are saved even when ctx is done
+		if serr := x.save(context.WithoutCancel(ctx)); serr != nil {
+			err = serr
+		}
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan job)
+	// the results in the order of the blocks, it bounds the blocks parsed ahead
+	results := make(chan chan result, 2*x.opts.Workers)
+	for i := 0; i < x.opts.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				data, err := x.src.ParseBlockData(j.number, x.opts.ParseOpts...)
+				j.result <- result{data: data, err: err}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(results)
+		defer close(jobs)
+		for n := x.next; n <= to; n++ {
+			r := make(chan result, 1)
+			select {
+			case results <- r:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- job{number: n, result: r}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for r := range results {
+		var res result
+		select {
+		case res = <-r:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if res.err != nil {
+			return res.err
+		}
+		applied, err := x.apply(ctx, res.data, true)
+		if err != nil || !applied {
+			return err
+		}
+	}
+	return nil
+}
+
+// follow parses the blocks up to the number one by one, it checks that the last
+// block delivered is still on the chain when there is no new block
+func (x *Indexer) follow(ctx context.Context, head uint64) error {
+	if x.next > head {
+		if x.opts.Finalized || len(x.blocks) == 0 {
+			return nil
+		}
+		last := x.blocks[len(x.blocks)-1]
+		hash, err := x.src.ChainGetBlockHash(uint32(last.Number))
+		if err != nil {
+			return err
+		}
+		if hash.Hex() != last.Hash {
+			return x.revert(ctx)
+		}
+		return nil
+	}
+	for x.next <= head {
+		data, err := x.src.ParseBlockData(x.next, x.opts.ParseOpts...)
+		if err != nil {
+			return err
+		}
+		applied, err := x.apply(ctx, data, false)
+		if err != nil || !applied {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply delivers the next block, if its parent is not the last block delivered
+// the orphaned blocks are reverted instead. The checkpoint is saved after the block,
+// or once CheckpointInterval blocks are applied when batch is set.
+//
+// Return:
+//   - bool: whether the block was delivered
+//   - error: error message
+func (x *Indexer) apply(ctx context.Context, data chain.BlockData, batch bool) (bool, error) {
+	if uint64(data.BlockId) != x.next {
+		return false, fmt.Errorf("block %d parsed instead of %d", data.BlockId, x.next)
+	}
+	if len(x.blocks) > 0 && data.PreHash != x.blocks[len(x.blocks)-1].Hash {
+		return false, x.revert(ctx)
+	}
+	if err := x.sink.Apply(ctx, data); err != nil {
+		return false, &fatalError{errors.Wrapf(err, "[Apply] block %d", data.BlockId)}
+	}
+	x.blocks = append(x.blocks, BlockRef{Number: uint64(data.BlockId), Hash: data.BlockHash})
+	if len(x.blocks) > x.opts.MaxReorgDepth {
+		x.blocks = append([]BlockRef(nil), x.blocks[len(x.blocks)-x.opts.MaxReorgDepth:]...)
+	}
+	x.next++
+	x.unsaved++
+	if batch && x.unsaved < x.opts.CheckpointInterval {
+		return true, nil
+	}
+	return true, x.save(ctx)
+}
+
+// revert reverts the blocks delivered after the last block still on the chain
+func (x *Indexer) revert(ctx context.Context) error {
+	common := -1
+	for i := len(x.blocks) - 1; i >= 0; i-- {
+		hash, err := x.src.ChainGetBlockHash(uint32(x.blocks[i].Number))
+		if err != nil {
+			return err
+		}
+		if hash.Hex() == x.blocks[i].Hash {
+			common = i
+			break
+		}
+	}
+	if common < 0 {
+		return &fatalError{errors.Wrapf(ErrReorgTooDeep, "block %d", x.blocks[0].Number)}
+	}
+	for len(x.blocks) > common+1 {
+		last := x.blocks[len(x.blocks)-1]
+		if err := x.sink.Revert(ctx, last); err != nil {
+			return &fatalError{errors.Wrapf(err, "[Revert] block %d", last.Number)}
+		}
+		x.blocks = x.blocks[:len(x.blocks)-1]
+		x.next = last.Number
+		if err := x.save(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// save flushes the sink and saves the blocks delivered as the checkpoint
+func (x *Indexer) save(ctx context.Context) error {
+	if f, ok := x.sink.(Flusher); ok {
+		if err := f.Flush(ctx); err != nil {
+			return &fatalError{errors.Wrap(err, "[Flush] sink")}
+		}
+	}
+	x.unsaved = 0
+	if err := x.opts.Checkpoints.Save(ctx, Checkpoint{Blocks: x.blocks}); err != nil {
+		return &fatalError{errors.Wrap(err, "[Save] checkpoint")}
+	}
+	return nil
+}
@@ -0,0 +1,308 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AstaFrode/go-substrate-rpc-client/v4/types"
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testChain is a chain whose best chain can be replaced by a fork
+type testChain struct {
+	lock      sync.Mutex
+	hashes    []types.Hash
+	finalized uint64
+	// errors returned once by ParseBlockData
+	fail map[uint64]error
+}
+
+func testHash(fork byte, number uint64) types.Hash {
+	var h types.Hash
+	h[0] = fork
+	h[31] = byte(number)
+	h[30] = byte(number >> 8)
+	return h
+}
+
+func newTestChain(n uint64) *testChain {
+	c := &testChain{fail: make(map[uint64]error)}
+	c.extend(0, 0, n)
+	return c
+}
+
+// extend replaces the blocks from the number with n blocks of the fork
+func (c *testChain) extend(fork byte, from, n uint64) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.hashes = c.hashes[:from]
+	for i := from; i < from+n; i++ {
+		c.hashes = append(c.hashes, testHash(fork, i))
+	}
+}
+
+func (c *testChain) QueryBlockNumber(blockhash string) (uint32, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := len(c.hashes) - 1; i >= 0; i-- {
+		if blockhash == "" || c.hashes[i].Hex() == blockhash {
+			return uint32(i), nil
+		}
+	}
+	return 0, errors.New("block not found")
+}
+
+func (c *testChain) ChainGetBlockHash(block uint32) (types.Hash, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if int(block) >= len(c.hashes) {
+		return types.Hash{}, nil
+	}
+	return c.hashes[block], nil
+}
+
+func (c *testChain) ChainGetFinalizedHead() (types.Hash, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.hashes[c.finalized], nil
+}
+
+func (c *testChain) ParseBlockData(blocknumber uint64, opts ...chain.ParseOption) (chain.BlockData, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if err, ok := c.fail[blocknumber]; ok {
+		delete(c.fail, blocknumber)
+		return chain.BlockData{}, err
+	}
+	if blocknumber >= uint64(len(c.hashes)) {
+		return chain.BlockData{}, fmt.Errorf("block %d not found", blocknumber)
+	}
+	data := chain.BlockData{BlockId: uint32(blocknumber), BlockHash: c.hashes[blocknumber].Hex()}
+	if blocknumber > 0 {
+		data.PreHash = c.hashes[blocknumber-1].Hex()
+	}
+	return data, nil
+}
+
+func (c *testChain) SubscribeNewHeads(ctx context.Context, opts ...chain.HeadOption) (<-chan chain.BlockHead, error) {
+	return nil, errors.New("not supported")
+}
+
+func (c *testChain) SubscribeFinalizedHeads(ctx context.Context, opts ...chain.HeadOption) (<-chan chain.BlockHead, error) {
+	return nil, errors.New("not supported")
+}
+
+// testSink records the blocks delivered as "+number:fork" and "-number:fork"
+type testSink struct {
+	lock    sync.Mutex
+	records []string
+	onApply func(block chain.BlockData)
+}
+
+func (s *testSink) sink() CallbackSink {
+	return CallbackSink{
+		OnApply: func(ctx context.Context, block chain.BlockData) error {
+			s.lock.Lock()
+			s.records = append(s.records, fmt.Sprintf("+%d:%s", block.BlockId, block.BlockHash[2:4]))
+			s.lock.Unlock()
+			if s.onApply != nil {
+				s.onApply(block)
+			}
+			return nil
+		},
+		OnRevert: func(ctx context.Context, block BlockRef) error {
+			s.lock.Lock()
+			defer s.lock.Unlock()
+			s.records = append(s.records, fmt.Sprintf("-%d:%s", block.Number, block.Hash[2:4]))
+			return nil
+		},
+	}
+}
+
+func (s *testSink) get() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string(nil), s.records...)
+}
+
+func applied(from, to uint64, fork string) []string {
+	var records []string
+	for i := from; i <= to; i++ {
+		records = append(records, fmt.Sprintf("+%d:%s", i, fork))
+	}
+	return records
+}
+
+// runUntil runs the indexer until the block is applied
+func runUntil(t *testing.T, x *Indexer, s *testSink, number uint64, fork string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	prev := s.onApply
+	s.onApply = func(block chain.BlockData) {
+		if prev != nil {
+			prev(block)
+		}
+		if uint64(block.BlockId) == number && block.BlockHash[2:4] == fork {
+			cancel()
+		}
+	}
+	err := x.Run(ctx)
+	require.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded, "records: %v", s.get())
+	return err
+}
+
+func TestIndexerBackfill(t *testing.T) {
+	c := newTestChain(300)
+	c.fail[20] = errors.New("connection lost")
+	s := &testSink{}
+	store := NewMemoryCheckpointStore()
+	x := New(c, s.sink(), StartAt(1), WithCheckpointStore(store), WithWorkers(4), WithLiveDistance(5),
+		WithMaxReorgDepth(10), WithPollInterval(time.Millisecond))
+
+	err := runUntil(t, x, s, 299, "00")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, applied(1, 299, "00"), s.get())
+
+	cp, err := store.Load(context.Background())
+	require.NoError(t, err)
+	require.Len(t, cp.Blocks, 10)
+	last, ok := cp.Last()
+	assert.True(t, ok)
+	assert.Equal(t, BlockRef{Number: 299, Hash: testHash(0, 299).Hex()}, last)
+
+	// resumes after the checkpoint
+	c.extend(0, 300, 5)
+	s.records = nil
+	assert.ErrorIs(t, runUntil(t, x, s, 304, "00"), context.Canceled)
+	assert.Equal(t, applied(300, 304, "00"), s.get())
+}
+
+func TestIndexerReorg(t *testing.T) {
+	c := newTestChain(30)
+	s := &testSink{}
+	x := New(c, s.sink(), StartAt(1), WithLiveDistance(5), WithPollInterval(time.Millisecond))
+	var once sync.Once
+	s.onApply = func(block chain.BlockData) {
+		if block.BlockId == 29 {
+			// blocks 27 to 29 are orphaned
+			once.Do(func() { c.extend(1, 27, 6) })
+		}
+	}
+
+	err := runUntil(t, x, s, 32, "01")
+	assert.ErrorIs(t, err, context.Canceled)
+	want := applied(1, 29, "00")
+	want = append(want, "-29:00", "-28:00", "-27:00")
+	want = append(want, applied(27, 32, "01")...)
+	assert.Equal(t, want, s.get())
+}
+
+func TestIndexerResumeAfterReorg(t *testing.T) {
+	c := newTestChain(12)
+	store := NewMemoryCheckpointStore()
+	require.NoError(t, store.Save(context.Background(), Checkpoint{Blocks: []BlockRef{
+		{Number: 8, Hash: testHash(0, 8).Hex()},
+		{Number: 9, Hash: testHash(2, 9).Hex()},
+		{Number: 10, Hash: testHash(2, 10).Hex()},
+	}}))
+	s := &testSink{}
+	x := New(c, s.sink(), WithCheckpointStore(store), WithPollInterval(time.Millisecond))
+
+	assert.ErrorIs(t, runUntil(t, x, s, 11, "00"), context.Canceled)
+	want := []string{"-10:02", "-9:02"}
+	assert.Equal(t, append(want, applied(9, 11, "00")...), s.get())
+
+	// none of the blocks of the checkpoint is on the chain
+	require.NoError(t, store.Save(context.Background(), Checkpoint{Blocks: []BlockRef{{Number: 10, Hash: testHash(2, 10).Hex()}}}))
+	c.extend(0, 0, 20)
+	assert.ErrorIs(t, x.Run(context.Background()), ErrReorgTooDeep)
+}
+
+func TestIndexerFinalized(t *testing.T) {
+	c := newTestChain(40)
+	c.finalized = 30
+	s := &testSink{}
+	x := New(c, s.sink(), StartAt(20), FollowFinalized(), WithLiveDistance(3), WithPollInterval(time.Millisecond))
+
+	assert.ErrorIs(t, runUntil(t, x, s, 30, "00"), context.Canceled)
+	assert.Equal(t, applied(20, 30, "00"), s.get())
+}
+
+func TestIndexerSinkError(t *testing.T) {
+	c := newTestChain(10)
+	failed := errors.New("sink failed")
+	sink := CallbackSink{OnApply: func(ctx context.Context, block chain.BlockData) error {
+		if block.BlockId == 5 {
+			return failed
+		}
+		return nil
+	}}
+	store := NewMemoryCheckpointStore()
+	x := New(c, sink, WithCheckpointStore(store), WithPollInterval(time.Millisecond))
+
+	assert.ErrorIs(t, x.Run(context.Background()), failed)
+	cp, err := store.Load(context.Background())
+	require.NoError(t, err)
+	last, _ := cp.Last()
+	assert.Equal(t, uint64(4), last.Number)
+}
+
+// flushSink records the last block flushed
+type flushSink struct {
+	CallbackSink
+	applied, flushed uint64
+}
+
+func (s *flushSink) Flush(ctx context.Context) error {
+	s.flushed = s.applied
+	return nil
+}
+
+// countingStore checks that the sink is flushed before each checkpoint
+type countingStore struct {
+	*MemoryCheckpointStore
+	sink  *flushSink
+	saves []uint64
+}
+
+func (s *countingStore) Save(ctx context.Context, cp Checkpoint) error {
+	last, _ := cp.Last()
+	if last.Number > s.sink.flushed {
+		return fmt.Errorf("checkpoint %d saved before the sink was flushed at %d", last.Number, s.sink.flushed)
+	}
+	s.saves = append(s.saves, last.Number)
+	return s.MemoryCheckpointStore.Save(ctx, cp)
+}
+
+func TestIndexerCheckpointInterval(t *testing.T) {
+	c := newTestChain(120)
+	failed := errors.New("sink failed")
+	sink := &flushSink{}
+	sink.OnApply = func(ctx context.Context, block chain.BlockData) error {
+		if block.BlockId == 90 {
+			return failed
+		}
+		sink.applied = uint64(block.BlockId)
+		return nil
+	}
+	store := &countingStore{MemoryCheckpointStore: NewMemoryCheckpointStore(), sink: sink}
+	x := New(c, sink, StartAt(1), WithCheckpointStore(store), WithCheckpointInterval(25), WithLiveDistance(5),
+		WithPollInterval(time.Millisecond))
+
+	// saved every 25 blocks during the backfill and up to the last block applied when it stops
+	assert.ErrorIs(t, x.Run(context.Background()), failed)
+	assert.Equal(t, []uint64{25, 50, 75, 89}, store.saves)
+}
@@ -0,0 +1,117 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"time"
+
+	"github.com/CESSProject/cess-go-sdk/chain"
+)
+
+const (
+	// DefaultWorkers is the number of blocks parsed in parallel during the backfill
+	DefaultWorkers = 8
+	// DefaultLiveDistance is the distance to the head under which the indexer follows the chain block by block
+	DefaultLiveDistance = 16
+	// DefaultMaxReorgDepth is the number of blocks kept to find the common block of a reorg
+	DefaultMaxReorgDepth = 128
+	// DefaultPollInterval is the interval the head is checked at when no new head is notified
+	DefaultPollInterval = 6 * time.Second
+	// DefaultCheckpointInterval is the number of blocks applied during the backfill between two checkpoints
+	DefaultCheckpointInterval = 64
+)
+
+// Options configures an indexer
+type Options struct {
+	// first block indexed when there is no checkpoint
+	Start uint64
+	// store of the checkpoint, in memory by default
+	Checkpoints CheckpointStore
+	// number of blocks parsed in parallel during the backfill
+	Workers int
+	// the backfill stops this number of blocks before the head, the rest is followed block by block
+	LiveDistance uint64
+	// number of blocks kept to find the common block of a reorg
+	MaxReorgDepth int
+	// follow the finalized blocks instead of the best blocks, there are no reorgs
+	Finalized bool
+	// interval the head is checked at when no new head is notified
+	PollInterval time.Duration
+	// options of ParseBlockData, e.g. chain.WithHandlers
+	ParseOpts []chain.ParseOption
+	// number of blocks applied during the backfill between two checkpoints,
+	// the blocks followed near the head are saved one by one
+	CheckpointInterval int
+}
+
+// Option sets an option of an indexer
+type Option func(o *Options)
+
+// StartAt indexes from the block when there is no checkpoint
+func StartAt(number uint64) Option {
+	return func(o *Options) {
+		o.Start = number
+	}
+}
+
+// WithCheckpointStore saves the position of the indexer in the store, it resumes from there
+func WithCheckpointStore(store CheckpointStore) Option {
+	return func(o *Options) {
+		o.Checkpoints = store
+	}
+}
+
+// WithWorkers parses n blocks in parallel during the backfill
+func WithWorkers(n int) Option {
+	return func(o *Options) {
+		o.Workers = n
+	}
+}
+
+// WithLiveDistance follows the chain block by block from n blocks before the head
+func WithLiveDistance(n uint64) Option {
+	return func(o *Options) {
+		o.LiveDistance = n
+	}
+}
+
+// WithMaxReorgDepth keeps the last n blocks to find the common block of a reorg
+func WithMaxReorgDepth(n int) Option {
+	return func(o *Options) {
+		o.MaxReorgDepth = n
+	}
+}
+
+// FollowFinalized indexes the finalized blocks only
+func FollowFinalized() Option {
+	return func(o *Options) {
+		o.Finalized = true
+	}
+}
+
+// WithPollInterval checks the head at the interval when no new head is notified
+func WithPollInterval(d time.Duration) Option {
+	return func(o *Options) {
+		o.PollInterval = d
+	}
+}
+
+// WithCheckpointInterval saves the checkpoint every n blocks during the backfill,
+// up to n blocks are applied again after a crash
+func WithCheckpointInterval(n int) Option {
+	return func(o *Options) {
+		o.CheckpointInterval = n
+	}
+}
+
+// WithParseOptions parses the blocks with the options, e.g. chain.WithCallArgs
+func WithParseOptions(opts ...chain.ParseOption) Option {
+	return func(o *Options) {
+		o.ParseOpts = append(o.ParseOpts, opts...)
+	}
+}
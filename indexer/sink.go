@@ -0,0 +1,200 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/pkg/errors"
+)
+
+// Sink receives the blocks of the indexer, it is called by one goroutine at a time
+type Sink interface {
+	// Apply delivers the next block of the chain, the blocks are applied in order
+	Apply(ctx context.Context, block chain.BlockData) error
+	// Revert delivers a block orphaned by a reorg, the orphaned blocks are reverted
+	// from the highest before the blocks of the new chain are applied
+	Revert(ctx context.Context, block BlockRef) error
+}
+
+// Flusher is implemented by the sinks that buffer the blocks, Flush is called
+// before each checkpoint and must make the blocks delivered so far durable
+type Flusher interface {
+	Flush(ctx context.Context) error
+}
+
+// CallbackSink delivers the blocks to functions, a nil function ignores them
+type CallbackSink struct {
+	OnApply  func(ctx context.Context, block chain.BlockData) error
+	OnRevert func(ctx context.Context, block BlockRef) error
+}
+
+func (s CallbackSink) Apply(ctx context.Context, block chain.BlockData) error {
+	if s.OnApply == nil {
+		return nil
+	}
+	return s.OnApply(ctx, block)
+}
+
+func (s CallbackSink) Revert(ctx context.Context, block BlockRef) error {
+	if s.OnRevert == nil {
+		return nil
+	}
+	return s.OnRevert(ctx, block)
+}
+
+// Record is a line of the files written by NDJSONSink
+type Record struct {
+	// RecordApply or RecordRevert
+	Type   string           `json:"type"`
+	Number uint64           `json:"number"`
+	Hash   string           `json:"hash"`
+	Block  *chain.BlockData `json:"block,omitempty"`
+}
+
+const (
+	RecordApply  = "apply"
+	RecordRevert = "revert"
+)
+
+// DefaultMaxFileSize is the size after which NDJSONSink starts a new file
+const DefaultMaxFileSize = 64 << 20
+
+// NDJSONSink appends a json record per line for each applied or reverted block.
+// The records are written in the order they are delivered, to files named
+// <prefix>-<sequence>-<number of the first record>.ndjson. The sequence increases
+// with each file, so the files sort in the order they were written even when a reorg
+// goes back to lower block numbers. A new file is started once the current one
+// exceeds the maximum size and each time the sink is created. The files are synced
+// to disk before each checkpoint of the indexer.
+type NDJSONSink struct {
+	lock    sync.Mutex
+	dir     string
+	prefix  string
+	maxSize int64
+	// sequence of the next file
+	seq  uint64
+	file *os.File
+	size int64
+}
+
+// NewNDJSONSink creates a sink writing to files in the directory, which is created if needed
+//   - dir: directory of the files
+//   - prefix: prefix of the file names
+//   - maxSize: size in bytes after which a new file is started, DefaultMaxFileSize if 0
+//
+// Return:
+//   - *NDJSONSink: the sink, close it when done
+//   - error: error message
+func NewNDJSONSink(dir, prefix string, maxSize int64) (*NDJSONSink, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrap(err, "[MkdirAll]")
+	}
+	if maxSize <= 0 {
+		maxSize = DefaultMaxFileSize
+	}
+	seq, err := lastFileSeq(dir, prefix)
+	if err != nil {
+		return nil, err
+	}
+	return &NDJSONSink{dir: dir, prefix: prefix, maxSize: maxSize, seq: seq + 1}, nil
+}
+
+// lastFileSeq returns the highest sequence of the files of the prefix in the directory, 0 if there is none
+func lastFileSeq(dir, prefix string) (uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ReadDir]")
+	}
+	var last uint64
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), prefix+"-")
+		if !ok || entry.IsDir() || !strings.HasSuffix(name, ".ndjson") {
+			continue
+		}
+		seq, _, ok := strings.Cut(name, "-")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseUint(seq, 10, 64)
+		if err == nil && n > last {
+			last = n
+		}
+	}
+	return last, nil
+}
+
+func (s *NDJSONSink) Apply(ctx context.Context, block chain.BlockData) error {
+	return s.write(Record{Type: RecordApply, Number: uint64(block.BlockId), Hash: block.BlockHash, Block: &block})
+}
+
+func (s *NDJSONSink) Revert(ctx context.Context, block BlockRef) error {
+	return s.write(Record{Type: RecordRevert, Number: block.Number, Hash: block.Hash})
+}
+
+func (s *NDJSONSink) write(r Record) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "[Marshal]")
+	}
+	line = append(line, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.file != nil && s.size >= s.maxSize {
+		if err = s.file.Sync(); err != nil {
+			return errors.Wrap(err, "[Sync]")
+		}
+		if err = s.file.Close(); err != nil {
+			return errors.Wrap(err, "[Close]")
+		}
+		s.file = nil
+	}
+	if s.file == nil {
+		name := filepath.Join(s.dir, fmt.Sprintf("%s-%08d-%012d.ndjson", s.prefix, s.seq, r.Number))
+		f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err != nil {
+			return errors.Wrap(err, "[OpenFile]")
+		}
+		s.file, s.size = f, 0
+		s.seq++
+	}
+	n, err := s.file.Write(line)
+	s.size += int64(n)
+	return errors.Wrap(err, "[Write]")
+}
+
+// Flush syncs the current file to disk, the previous files are synced when they are closed
+func (s *NDJSONSink) Flush(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return errors.Wrap(s.file.Sync(), "[Sync]")
+}
+
+// Close closes the current file
+func (s *NDJSONSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
@@ -0,0 +1,79 @@
+/*
+	Copyright (C) CESS. All rights reserved.
+	Copyright (C) Cumulus Encrypted Storage System. All rights reserved.
+
+	SPDX-License-Identifier: Apache-2.0
+*/
+
+package indexer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func TestNDJSONSink(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// a new file after each record
+	sink, err := NewNDJSONSink(dir, "blocks", 1)
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Apply(ctx, chain.BlockData{BlockId: 7, BlockHash: "0x07", PreHash: "0x06"}))
+	require.NoError(t, sink.Revert(ctx, BlockRef{Number: 7, Hash: "0x07"}))
+	require.NoError(t, sink.Flush(ctx))
+	require.NoError(t, sink.Close())
+	require.NoError(t, sink.Flush(ctx))
+
+	// a file per record
+	records := readRecords(t, filepath.Join(dir, "blocks-00000001-000000000007.ndjson"))
+	require.Len(t, records, 1)
+	assert.Equal(t, RecordApply, records[0].Type)
+	assert.Equal(t, uint64(7), records[0].Number)
+	require.NotNil(t, records[0].Block)
+	assert.Equal(t, "0x06", records[0].Block.PreHash)
+	records = readRecords(t, filepath.Join(dir, "blocks-00000002-000000000007.ndjson"))
+	assert.Equal(t, []Record{{Type: RecordRevert, Number: 7, Hash: "0x07"}}, records)
+
+	// the sequence resumes after the existing files, the files sort in the order they were written
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other-00000009-000000000001.ndjson"), nil, 0644))
+	sink, err = NewNDJSONSink(dir, "blocks", 0)
+	require.NoError(t, err)
+	require.NoError(t, sink.Apply(ctx, chain.BlockData{BlockId: 7, BlockHash: "0x17"}))
+	require.NoError(t, sink.Apply(ctx, chain.BlockData{BlockId: 8, BlockHash: "0x18"}))
+	require.NoError(t, sink.Close())
+	files, err := filepath.Glob(filepath.Join(dir, "blocks-*.ndjson"))
+	require.NoError(t, err)
+	for k := range files {
+		files[k] = filepath.Base(files[k])
+	}
+	assert.Equal(t, []string{
+		"blocks-00000001-000000000007.ndjson",
+		"blocks-00000002-000000000007.ndjson",
+		"blocks-00000003-000000000007.ndjson",
+	}, files)
+	assert.Len(t, readRecords(t, filepath.Join(dir, "blocks-00000003-000000000007.ndjson")), 2)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/CESSProject/cess-go-sdk/chain"
+	"github.com/CESSProject/cess-go-sdk/core/signer"
 )
 
 // ConnectRpcAddrs configuration rpc address
@@ -48,3 +49,42 @@ func Name(name string) Option {
 		return nil
 	}
 }
+
+// ClientOptions appends options that are passed to the chain client
+func ClientOptions(opts ...chain.ClientOption) Option {
+	return func(cfg *Config) error {
+		cfg.ClientOpts = append(cfg.ClientOpts, opts...)
+		return nil
+	}
+}
+
+// RpcHealthCheck configures the health checks of the rpc endpoints
+//   - interval: interval between two checks, 0 disables the background checks
+//   - maxLag: number of blocks an endpoint may lag behind the best endpoint
+func RpcHealthCheck(interval time.Duration, maxLag uint32) Option {
+	return ClientOptions(chain.WithHealthCheck(interval, maxLag))
+}
+
+// TransactionTip configures the tip paid for every transaction
+//   - tip: amount of the tip in the smallest unit, 1 CESS is "1000000000000000000"
+func TransactionTip(tip string) Option {
+	return ClientOptions(chain.WithDefaultTip(tip))
+}
+
+// TransactionLifetime configures the number of blocks every transaction is valid for
+//   - blocks: lifetime in blocks, 0 makes the transactions immortal
+func TransactionLifetime(blocks uint32) Option {
+	return ClientOptions(chain.WithDefaultLifetime(blocks))
+}
+
+// AccountSigner configures the signer of the signature account,
+// it replaces the mnemonic, e.g. with a remote signer
+func AccountSigner(s signer.Signer) Option {
+	return ClientOptions(chain.WithSigner(s))
+}
+
+// AccountKeystore configures a keystore with several accounts,
+// each transaction can choose its account with chain.WithAccount
+func AccountKeystore(ks *signer.Keystore) Option {
+	return ClientOptions(chain.WithKeystore(ks))
+}
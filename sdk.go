@@ -28,7 +28,7 @@ func New(ctx context.Context, opts ...Option) (chain.Chainer, error) {
 //
 // If no rpc endpoint are provided, the CESS blockchain network cannot be accessed.
 //
-// If no account mnemonic are provided, block transactions cannot be conducted.
+// If no account mnemonic or signer are provided, block transactions cannot be conducted.
 func NewWithoutDefaults(ctx context.Context, opts ...Option) (chain.Chainer, error) {
 	var cfg Config
 	if err := cfg.Apply(opts...); err != nil {